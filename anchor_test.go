@@ -0,0 +1,83 @@
+package noise
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCellsFrom(t *testing.T) {
+	cells := Collect(cellsFrom(5, 3, 2.3, -1.6))
+	assert.Len(t, cells, 11*7)
+	assert.Equal(t, [2]int{2, -2}, cells[0])
+
+	// Every cell is visited once, in order of increasing distance
+	seen := make(map[[2]int]bool)
+	q := &cellQueue{ax: 2.3, ay: -1.6}
+	for i, c := range cells {
+		assert.False(t, seen[c])
+		seen[c] = true
+		if i > 0 {
+			assert.LessOrEqual(t, q.dist(cells[i-1]), q.dist(c))
+		}
+	}
+
+	// Anchors outside of the rectangle start from the nearest edge
+	assert.Equal(t, [2]int{5, 3}, Collect(cellsFrom(5, 3, 100, 100))[0])
+}
+
+func TestSSI2From(t *testing.T) {
+	const ax, ay = 10, -5
+	pts := Collect(SSI2From(1, 20, 20, ax, ay))
+	assert.NotEmpty(t, pts)
+
+	// Points keep the minimum distance of SSI2
+	for i := range pts {
+		for j := i + 1; j < len(pts); j++ {
+			assert.GreaterOrEqual(t, dist2(pts[i], pts[j][0], pts[j][1]), float32(1))
+		}
+	}
+
+	// Points come out roughly sorted by distance, within the jitter of a cell
+	for i := 1; i < len(pts); i++ {
+		d0 := dist2(pts[i-1], ax, ay)
+		d1 := dist2(pts[i], ax, ay)
+		assert.Less(t, sqrt32(d0)-sqrt32(d1), float32(1.5))
+	}
+
+	// Deterministic
+	assert.Equal(t, pts, Collect(SSI2From(1, 20, 20, ax, ay)))
+	assert.Empty(t, Collect(SSI2From(1, 0, 20, ax, ay)))
+}
+
+func TestSparse2From(t *testing.T) {
+	pts := Collect(Sparse2From(1, 256, 128, 8, 200, 20))
+	assert.NotEmpty(t, pts)
+
+	first := pts[0]
+	assert.Less(t, dist2([2]float32{float32(first[0]), float32(first[1])}, 200, 20), float32(8*8))
+	for _, p := range pts {
+		assert.True(t, p[0] >= 0 && p[0] < 256 && p[1] >= 0 && p[1] < 128)
+	}
+
+	// Streaming stops early
+	assert.Len(t, Collect(Take(Sparse2From(1, 256, 128, 8, 200, 20), 5)), 5)
+	assert.Empty(t, Collect(Sparse2From(1, 0, 128, 8, 0, 0)))
+
+	// Fractional gaps keep their spacing, integral ones are unchanged whatever their type
+	pts = Collect(Sparse2From(1, 128, 96, 2.5, 100, 20))
+	assert.NotEmpty(t, pts)
+	for i, p := range pts {
+		for _, q := range pts[i+1:] {
+			dx, dy := float32(p[0]-q[0]), float32(p[1]-q[1])
+			assert.GreaterOrEqual(t, dx*dx+dy*dy, float32(2.5*2.5))
+		}
+	}
+	assert.Equal(t, Collect(Sparse2From(1, 256, 128, 8, 200, 20)), Collect(Sparse2From(1, 256, 128, float32(8), 200, 20)))
+}
+
+// sqrt32 returns the square root of v
+func sqrt32(v float32) float32 {
+	return float32(math.Sqrt(float64(v)))
+}
@@ -0,0 +1,193 @@
+package noise
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+	"slices"
+)
+
+// ---------------------------------- Animation ----------------------------------
+
+// Animate renders a w × h animation of a 3D source, where frame i samples the
+// plane z = i·dt at integer pixel coordinates, so wrap the source with Scale to set
+// its frequency. Frames share a 256-color grayscale palette indexed by the value in
+// [-1, 1], so copying a colormap such as Viridis().Palette(256) over it recolors the
+// whole animation. Each frame is shown for 100ms and the animation loops forever;
+// for seamless loops, animate a source built on EvalLoop.
+//
+// Example:
+//
+//	anim := Animate(Scale(NewFBM(12345), 0.05, 0.05, 1), 32, 128, 128, 0.1)
+//	err := gif.EncodeAll(file, anim)
+func Animate(src Source3, frames, w, h int, dt float32) *gif.GIF {
+	if frames <= 0 || w <= 0 || h <= 0 {
+		panic("invalid argument to Animate")
+	}
+
+	palette := make(color.Palette, 256)
+	for i := range palette {
+		palette[i] = color.RGBA{uint8(i), uint8(i), uint8(i), 255}
+	}
+
+	anim := &gif.GIF{
+		Image: make([]*image.Paletted, 0, frames),
+		Delay: make([]int, 0, frames),
+	}
+
+	for i := 0; i < frames; i++ {
+		img := image.NewPaletted(image.Rect(0, 0, w, h), palette)
+		z := float32(i) * dt
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				v := min(max((src.Eval3(float32(x), float32(y), z)+1)/2, 0), 1)
+				img.Pix[y*img.Stride+x] = uint8(v * 255)
+			}
+		}
+
+		anim.Image = append(anim.Image, img)
+		anim.Delay = append(anim.Delay, 10)
+	}
+	return anim
+}
+
+// EncodeAPNG writes a paletted animation as an animated PNG, which unlike GIF keeps
+// the palette alpha and is decoded losslessly by browsers. Viewers without APNG
+// support show the first frame. All frames must share the palette of the first one.
+//
+// Example:
+//
+//	err := EncodeAPNG(file, Animate(Scale(NewFBM(12345), 0.05, 0.05, 1), 32, 128, 128, 0.1))
+func EncodeAPNG(dst io.Writer, anim *gif.GIF) error {
+	if len(anim.Image) == 0 {
+		return errors.New("noise: animation has no frames")
+	}
+
+	first := anim.Image[0]
+	for _, img := range anim.Image[1:] {
+		if !slices.Equal(img.Palette, first.Palette) {
+			return errors.New("noise: apng frames must share a palette")
+		}
+	}
+
+	w, h := anim.Config.Width, anim.Config.Height
+	if w == 0 || h == 0 {
+		w, h = first.Rect.Max.X, first.Rect.Max.Y
+	}
+
+	// Image header, indexed colors with 8 bits per pixel
+	out := &apngWriter{w: bufio.NewWriter(dst)}
+	out.w.WriteString("\x89PNG\r\n\x1a\n")
+	out.chunk("IHDR", be32(uint32(w)), be32(uint32(h)), []byte{8, 3, 0, 0, 0})
+
+	plte, trns := make([]byte, 0, 3*len(first.Palette)), make([]byte, 0, len(first.Palette))
+	opaque := true
+	for _, c := range first.Palette {
+		rgba := color.NRGBAModel.Convert(c).(color.NRGBA)
+		plte = append(plte, rgba.R, rgba.G, rgba.B)
+		trns = append(trns, rgba.A)
+		opaque = opaque && rgba.A == 255
+	}
+	out.chunk("PLTE", plte)
+	if !opaque {
+		out.chunk("tRNS", trns)
+	}
+
+	// Animation control, where GIF counts repeats and APNG counts plays
+	plays := uint32(0)
+	switch {
+	case anim.LoopCount < 0:
+		plays = 1
+	case anim.LoopCount > 0:
+		plays = uint32(anim.LoopCount) + 1
+	}
+	out.chunk("acTL", be32(uint32(len(anim.Image))), be32(plays))
+
+	var seq uint32
+	for i, img := range anim.Image {
+		delay := 0
+		if i < len(anim.Delay) {
+			delay = anim.Delay[i]
+		}
+
+		r := img.Rect
+		out.chunk("fcTL", be32(seq), be32(uint32(r.Dx())), be32(uint32(r.Dy())),
+			be32(uint32(r.Min.X)), be32(uint32(r.Min.Y)),
+			[]byte{byte(delay >> 8), byte(delay), 0, 100, 0, 0})
+		seq++
+
+		data, err := deflateRows(img)
+		if err != nil {
+			return err
+		}
+
+		if i == 0 {
+			out.chunk("IDAT", data)
+		} else {
+			out.chunk("fdAT", be32(seq), data)
+			seq++
+		}
+	}
+
+	out.chunk("IEND")
+	if out.err != nil {
+		return out.err
+	}
+	return out.w.Flush()
+}
+
+// apngWriter writes PNG chunks, remembering the first error
+type apngWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+// chunk writes a chunk made of the concatenated parts, followed by its checksum
+func (a *apngWriter) chunk(name string, parts ...[]byte) {
+	if a.err != nil {
+		return
+	}
+
+	size := 0
+	for _, p := range parts {
+		size += len(p)
+	}
+
+	crc := crc32.NewIEEE()
+	a.w.Write(be32(uint32(size)))
+	io.WriteString(io.MultiWriter(a.w, crc), name)
+	for _, p := range parts {
+		crc.Write(p)
+		a.w.Write(p)
+	}
+	_, a.err = a.w.Write(be32(crc.Sum32()))
+}
+
+// deflateRows compresses the pixels of a frame, each row without a filter
+func deflateRows(img *image.Paletted) ([]byte, error) {
+	var buf bytes.Buffer
+	z := zlib.NewWriter(&buf)
+	for y := img.Rect.Min.Y; y < img.Rect.Max.Y; y++ {
+		row := img.Pix[img.PixOffset(img.Rect.Min.X, y):][:img.Rect.Dx()]
+		if _, err := z.Write(append([]byte{0}, row...)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := z.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// be32 encodes a big-endian uint32
+func be32(v uint32) []byte {
+	return binary.BigEndian.AppendUint32(nil, v)
+}
@@ -0,0 +1,70 @@
+package noise
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnimate(t *testing.T) {
+	anim := Animate(Scale(NewSimplex(42), 0.1, 0.1, 1), 4, 16, 12, 0.5)
+	assert.Len(t, anim.Image, 4)
+	assert.Equal(t, []int{10, 10, 10, 10}, anim.Delay)
+	assert.Same(t, &anim.Image[0].Palette[0], &anim.Image[3].Palette[0])
+	assert.Equal(t, 16, anim.Image[0].Rect.Dx())
+	assert.Equal(t, 12, anim.Image[0].Rect.Dy())
+	assert.NotEqual(t, anim.Image[0].Pix, anim.Image[1].Pix)
+
+	// Frame i samples the plane z = i·dt
+	s := NewSimplex(42)
+	v := (s.Eval3(0.3, 0.5, 1) + 1) / 2
+	assert.Equal(t, uint8(v*255), anim.Image[2].ColorIndexAt(3, 5))
+
+	var buf bytes.Buffer
+	assert.NoError(t, gif.EncodeAll(&buf, anim))
+	assert.Panics(t, func() { Animate(s, 0, 16, 16, 0.1) })
+	assert.Panics(t, func() { Animate(s, 4, 16, 0, 0.1) })
+}
+
+func TestEncodeAPNG(t *testing.T) {
+	anim := Animate(Scale(NewSimplex(42), 0.1, 0.1, 1), 3, 16, 16, 0.5)
+	var buf bytes.Buffer
+	assert.NoError(t, EncodeAPNG(&buf, anim))
+
+	// Decoders without APNG support see the first frame
+	img, err := png.Decode(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 16; x++ {
+			assert.Equal(t, anim.Image[0].At(x, y), color.RGBAModel.Convert(img.At(x, y)))
+		}
+	}
+
+	// Walk the chunks, counting the frames
+	chunks := map[string]int{}
+	data := buf.Bytes()[8:]
+	for len(data) >= 12 {
+		n := binary.BigEndian.Uint32(data)
+		chunks[string(data[4:8])]++
+		data = data[12+n:]
+	}
+	assert.Equal(t, 1, chunks["acTL"])
+	assert.Equal(t, 3, chunks["fcTL"])
+	assert.Equal(t, 1, chunks["IDAT"])
+	assert.Equal(t, 2, chunks["fdAT"])
+	assert.Equal(t, 0, chunks["tRNS"])
+	assert.Equal(t, 1, chunks["IEND"])
+}
+
+func TestEncodeAPNGErrors(t *testing.T) {
+	assert.Error(t, EncodeAPNG(&bytes.Buffer{}, &gif.GIF{}))
+
+	anim := Animate(NewSimplex(42), 2, 4, 4, 0.5)
+	anim.Image[1].Palette = Viridis().Palette(256)
+	assert.Error(t, EncodeAPNG(&bytes.Buffer{}, anim))
+}
@@ -0,0 +1,33 @@
+package noise
+
+import "iter"
+
+// SSI2Aniso generates a 2D hard-core pattern like SSI2, but with a different
+// minimum distance along each axis, for patterns such as plowed fields, plantation
+// rows or parking lots. Method: the SSI2 pattern of unit spacing is stretched by
+// dx horizontally and dy vertically, so any two points satisfy
+// (Δx/dx)² + (Δy/dy)² ≥ 1, i.e. each point keeps the others outside of an
+// ellipse with semi-axes dx and dy. The radii r1 and r2 are in lattice cells, so
+// the pattern covers [−r1·dx, +r1·dx] × [−r2·dy, +r2·dy].
+// Deterministic for a given seed, and empty if dx <= 0 or dy <= 0.
+//
+// Example:
+//
+//	// Rows of crops, 1 unit apart along a row and 3 units between rows
+//	for p := range SSI2Aniso(12345, 64, 16, 1, 3) {
+//	    x, y := p[0], p[1]
+//	    // use x, y
+//	}
+func SSI2Aniso(seed uint32, r1, r2 int, dx, dy float32) iter.Seq[[2]float32] {
+	return func(yield func([2]float32) bool) {
+		if dx <= 0 || dy <= 0 {
+			return
+		}
+
+		for p := range SSI2(seed, r1, r2) {
+			if !yield([2]float32{p[0] * dx, p[1] * dy}) {
+				return
+			}
+		}
+	}
+}
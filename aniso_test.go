@@ -0,0 +1,36 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSSI2Aniso(t *testing.T) {
+	const dx, dy = 1.5, 4
+	pts := Collect(SSI2Aniso(1, 24, 8, dx, dy))
+	assert.Len(t, pts, len(Collect(SSI2(1, 24, 8))))
+
+	// Every pair keeps out of the other's ellipse
+	closest := float32(1e9)
+	for i := range pts {
+		assert.LessOrEqual(t, abs(pts[i][0]), float32(24.5*dx))
+		assert.LessOrEqual(t, abs(pts[i][1]), float32(8.5*dy))
+		for j := i + 1; j < len(pts); j++ {
+			ex, ey := (pts[i][0]-pts[j][0])/dx, (pts[i][1]-pts[j][1])/dy
+			closest = min(closest, ex*ex+ey*ey)
+		}
+	}
+	assert.GreaterOrEqual(t, closest, float32(0.999))
+
+	// The pattern spans the stretched rectangle
+	var sx, sy float32
+	for _, p := range pts {
+		sx, sy = max(sx, abs(p[0])), max(sy, abs(p[1]))
+	}
+	assert.Greater(t, sx, float32(24*dx*0.9))
+	assert.Greater(t, sy, float32(8*dy*0.9))
+
+	assert.Empty(t, Collect(SSI2Aniso(1, 24, 8, 0, 1)))
+	assert.Len(t, Collect(Take(SSI2Aniso(1, 24, 8, 1, 2), 3)), 3)
+}
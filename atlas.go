@@ -0,0 +1,109 @@
+package noise
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// ---------------------------------- Texture Atlas ----------------------------------
+
+// Sheet configures a texture atlas. Zero fields take the default values listed
+// below, so Sheet{} is a sensible starting point.
+type Sheet struct {
+	Count   int     // Number of variations, default 16
+	Tile    int     // Width and height of a tile in pixels, default 64
+	Step    float32 // Distance in noise units between two pixels, default 0.05
+	Padding int     // Transparent pixels between two tiles, default 0
+}
+
+// withDefaults returns the options with the zero fields replaced by defaults
+func (o Sheet) withDefaults() Sheet {
+	if o.Count <= 0 {
+		o.Count = 16
+	}
+	if o.Tile <= 0 {
+		o.Tile = 64
+	}
+	if o.Step == 0 {
+		o.Step = 0.05
+	}
+	o.Padding = max(o.Padding, 0)
+	return o
+}
+
+// AtlasTile is the metadata of a tile in a texture atlas
+type AtlasTile struct {
+	Seed uint32     `json:"seed"` // Seed of the variation
+	Rect [4]int     `json:"rect"` // Pixel rectangle as x, y, width, height
+	UV   [4]float32 `json:"uv"`   // Texture coordinates as u0, v0, u1, v1
+}
+
+// Atlas is a texture atlas packing variations of a source into a single image. It
+// marshals to JSON as its metadata, without the image itself.
+type Atlas struct {
+	Image  *image.RGBA `json:"-"`
+	Width  int         `json:"width"`
+	Height int         `json:"height"`
+	Tiles  []AtlasTile `json:"tiles"`
+}
+
+// NewAtlas renders variations of a source into a texture atlas, packed row by row
+// in a grid as close to a square as possible. Each variation is created by the
+// variant function with a seed derived from the atlas seed, and its values are
+// mapped to colors by the palette. A nil palette maps [-1, 1] to grayscale.
+//
+// Example:
+//
+//	atlas := NewAtlas(12345, Sheet{Count: 16, Tile: 128}, func(seed uint32) Source2 {
+//	    return NewFBM(seed)
+//	}, nil)
+//	png.Encode(imageFile, atlas.Image)
+//	json.NewEncoder(metaFile).Encode(atlas)
+func NewAtlas(seed uint32, opts Sheet, variant func(seed uint32) Source2, palette func(float32) color.Color) *Atlas {
+	o := opts.withDefaults()
+	cols := int(math.Ceil(math.Sqrt(float64(o.Count))))
+	rows := (o.Count + cols - 1) / cols
+	stride := o.Tile + o.Padding
+
+	a := &Atlas{
+		Width:  cols*stride - o.Padding,
+		Height: rows*stride - o.Padding,
+		Tiles:  make([]AtlasTile, o.Count),
+	}
+	a.Image = image.NewRGBA(image.Rect(0, 0, a.Width, a.Height))
+
+	for i := range a.Tiles {
+		x0, y0 := (i%cols)*stride, (i/cols)*stride
+		t := AtlasTile{
+			Seed: Uint32(seed, uint64(i)),
+			Rect: [4]int{x0, y0, o.Tile, o.Tile},
+			UV: [4]float32{
+				float32(x0) / float32(a.Width),
+				float32(y0) / float32(a.Height),
+				float32(x0+o.Tile) / float32(a.Width),
+				float32(y0+o.Tile) / float32(a.Height),
+			},
+		}
+
+		src := variant(t.Seed)
+		for y := 0; y < o.Tile; y++ {
+			for x := 0; x < o.Tile; x++ {
+				v := src.Eval2(float32(x)*o.Step, float32(y)*o.Step)
+				a.Image.Set(x0+x, y0+y, shadeOf(v, palette))
+			}
+		}
+		a.Tiles[i] = t
+	}
+	return a
+}
+
+// shadeOf maps a value to a color with the palette, or to grayscale if it is nil
+func shadeOf(v float32, palette func(float32) color.Color) color.Color {
+	if palette != nil {
+		return palette(v)
+	}
+
+	g := uint8(min(max((v+1)*127.5+0.5, 0), 255))
+	return color.RGBA{g, g, g, 255}
+}
@@ -0,0 +1,50 @@
+package noise
+
+import (
+	"encoding/json"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAtlas(t *testing.T) {
+	variant := func(seed uint32) Source2 { return NewFBM(seed) }
+	a := NewAtlas(1, Sheet{Count: 5, Tile: 16, Padding: 2}, variant, nil)
+	assert.Equal(t, 3*18-2, a.Width)
+	assert.Equal(t, 2*18-2, a.Height)
+	assert.Equal(t, a.Width, a.Image.Bounds().Dx())
+	assert.Len(t, a.Tiles, 5)
+
+	// Tiles are laid out row by row, each with its own seed
+	assert.Equal(t, [4]int{36, 0, 16, 16}, a.Tiles[2].Rect)
+	assert.Equal(t, [4]int{0, 18, 16, 16}, a.Tiles[3].Rect)
+	assert.Equal(t, [4]float32{0, 0, 16.0 / 52, 16.0 / 34}, a.Tiles[0].UV)
+	assert.NotEqual(t, a.Tiles[0].Seed, a.Tiles[1].Seed)
+
+	// Each tile renders its variation, and padding stays transparent
+	v := NewFBM(a.Tiles[3].Seed).Eval2(0.05, 0.1)
+	assert.Equal(t, shadeOf(v, nil), a.Image.At(1, 20))
+	assert.Equal(t, color.RGBA{}, a.Image.At(17, 0))
+	assert.Equal(t, a.Image.Pix, NewAtlas(1, Sheet{Count: 5, Tile: 16, Padding: 2}, variant, nil).Image.Pix)
+}
+
+func TestAtlasJSON(t *testing.T) {
+	red := func(float32) color.Color { return color.RGBA{255, 0, 0, 255} }
+	a := NewAtlas(1, Sheet{Count: 1, Tile: 4}, func(seed uint32) Source2 { return NewSimplex(seed) }, red)
+	assert.Equal(t, color.RGBA{255, 0, 0, 255}, a.Image.At(3, 3))
+
+	out, err := json.Marshal(a)
+	assert.NoError(t, err)
+
+	var meta map[string]any
+	assert.NoError(t, json.Unmarshal(out, &meta))
+	assert.Equal(t, float64(4), meta["width"])
+	assert.Len(t, meta["tiles"], 1)
+	assert.NotContains(t, string(out), "Image")
+
+	// Defaults to 16 tiles of 64 pixels
+	d := NewAtlas(1, Sheet{}, func(seed uint32) Source2 { return NewSimplex(seed) }, nil)
+	assert.Len(t, d.Tiles, 16)
+	assert.Equal(t, 256, d.Width)
+}
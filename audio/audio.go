@@ -0,0 +1,149 @@
+// Package audio streams deterministic white, pink and brown noise as PCM samples,
+// built on the hash core of the noise package so that the same seed always
+// produces the same waveform.
+package audio
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"math/bits"
+
+	"github.com/kelindar/noise"
+)
+
+// Color is the spectral color of a noise stream
+type Color int
+
+// Supported noise colors
+const (
+	White Color = iota // Flat spectrum
+	Pink               // Power falls off by 3 dB per octave
+	Brown              // Power falls off by 6 dB per octave
+)
+
+// Format configures the PCM encoding of a stream. Zero fields take the default
+// values listed below.
+type Format struct {
+	SampleRate int // Samples per second, default 44100
+	BitDepth   int // 8 (unsigned), 16 or 24 (signed little-endian), default 16
+}
+
+// withDefaults returns the format with the zero fields replaced by defaults
+func (f Format) withDefaults() Format {
+	if f.SampleRate == 0 {
+		f.SampleRate = 44100
+	}
+	if f.BitDepth == 0 {
+		f.BitDepth = 16
+	}
+	return f
+}
+
+// pinkRows is the number of generators summed by the Voss-McCartney algorithm,
+// which covers the audible range at common sample rates
+const pinkRows = 16
+
+// Stream is an endless, deterministic stream of mono noise samples. It implements
+// io.Reader, producing PCM samples in the configured format.
+type Stream struct {
+	seed   uint32
+	color  Color
+	format Format
+	index  uint64            // index of the next sample
+	rows   [pinkRows]float32 // generators of the pink noise
+	sum    float32           // sum of the pink noise generators
+	level  float32           // integrator of the brown noise
+	leak   float32           // leak of the brown noise integrator
+}
+
+// New creates a noise stream of the given color. It panics if the bit depth is
+// not supported.
+//
+// Example:
+//
+//	rain := audio.New(12345, audio.Pink, audio.Format{SampleRate: 48000})
+//	io.CopyN(speaker, rain, 48000*2) // one second of 16-bit samples
+func New(seed uint32, color Color, format Format) *Stream {
+	format = format.withDefaults()
+	switch format.BitDepth {
+	case 8, 16, 24:
+	default:
+		panic("noise: unsupported bit depth, must be 8, 16 or 24")
+	}
+
+	// The brown integrator leaks with a cutoff of about 20 Hz, which keeps the
+	// signal from drifting away while preserving its low frequencies
+	s := &Stream{
+		seed:   seed,
+		color:  color,
+		format: format,
+		leak:   float32(1 - 2*math.Pi*20/float64(format.SampleRate)),
+	}
+
+	// Seed the pink generators so the stream does not start from silence
+	for i := range s.rows {
+		s.rows[i] = s.white(uint64(i) << 48)
+		s.sum += s.rows[i]
+	}
+	return s
+}
+
+// Next returns the next sample in [-1, 1]
+func (s *Stream) Next() float32 {
+	i := s.index
+	s.index++
+
+	switch s.color {
+	case Pink:
+		// Voss-McCartney: the k-th generator is refreshed every 2^k samples, at the
+		// samples whose index has k trailing zeros
+		if i > 0 {
+			row := bits.TrailingZeros64(i) % pinkRows
+			next := s.white(i | uint64(row+1)<<56)
+			s.sum += next - s.rows[row]
+			s.rows[row] = next
+		}
+		return min(max((s.sum+s.white(i))/(pinkRows+1)*3, -1), 1)
+	case Brown:
+		s.level = float32(s.level*s.leak) + float32(s.white(i)*0.06)
+		return min(max(s.level, -1), 1)
+	default:
+		return s.white(i)
+	}
+}
+
+// Read fills p with whole PCM samples, implementing io.Reader. It never returns
+// io.EOF since the stream is endless, but returns io.ErrShortBuffer if p is too
+// small to hold a single sample.
+func (s *Stream) Read(p []byte) (int, error) {
+	size := s.format.BitDepth / 8
+	if len(p) < size {
+		return 0, io.ErrShortBuffer
+	}
+
+	n := len(p) / size * size
+	for i := 0; i < n; i += size {
+		v := s.Next()
+		switch size {
+		case 1:
+			p[i] = uint8(int(v*127) + 128)
+		case 2:
+			binary.LittleEndian.PutUint16(p[i:], uint16(int16(v*math.MaxInt16)))
+		case 3:
+			u := uint32(int32(v * (1<<23 - 1)))
+			p[i], p[i+1], p[i+2] = byte(u), byte(u>>8), byte(u>>16)
+		}
+	}
+	return n, nil
+}
+
+// SampleRate returns the number of samples per second of the stream
+func (s *Stream) SampleRate() int {
+	return s.format.SampleRate
+}
+
+// white returns a uniform sample in [-1, 1) for the key
+func (s *Stream) white(x uint64) float32 {
+	return noise.Float32(s.seed, x)*2 - 1
+}
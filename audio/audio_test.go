@@ -0,0 +1,77 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeterministic(t *testing.T) {
+	for _, c := range []Color{White, Pink, Brown} {
+		a, b := make([]byte, 4096), make([]byte, 4096)
+		_, err := New(42, c, Format{}).Read(a)
+		assert.NoError(t, err)
+		_, err = New(42, c, Format{}).Read(b)
+		assert.NoError(t, err)
+		assert.Equal(t, a, b)
+		assert.NotEqual(t, make([]byte, 4096), a)
+	}
+}
+
+func TestRead(t *testing.T) {
+	s := New(1, White, Format{BitDepth: 24})
+	n, err := s.Read(make([]byte, 10))
+	assert.NoError(t, err)
+	assert.Equal(t, 9, n)
+
+	_, err = s.Read(make([]byte, 2))
+	assert.Equal(t, io.ErrShortBuffer, err)
+	assert.Equal(t, 44100, s.SampleRate())
+	assert.Panics(t, func() { New(1, White, Format{BitDepth: 12}) })
+}
+
+func TestEncoding(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := io.CopyN(&buf, New(7, White, Format{}), 2*1000)
+	assert.NoError(t, err)
+
+	ref := New(7, White, Format{})
+	for i := 0; i < 1000; i++ {
+		v := int16(binary.LittleEndian.Uint16(buf.Bytes()[i*2:]))
+		assert.Equal(t, int16(ref.Next()*math.MaxInt16), v)
+	}
+
+	u8 := make([]byte, 1000)
+	New(7, White, Format{BitDepth: 8}).Read(u8)
+	var mean float64
+	for _, v := range u8 {
+		mean += float64(v)
+	}
+	assert.InDelta(t, 128, mean/1000, 8)
+}
+
+func TestSpectrum(t *testing.T) {
+	// Redder noise has more power in low frequencies, so consecutive samples
+	// differ less relative to the overall power of the signal
+	smoothness := func(c Color) float64 {
+		s := New(3, c, Format{})
+		var diff, power float64
+		prev := s.Next()
+		for i := 0; i < 50000; i++ {
+			v := s.Next()
+			assert.True(t, v >= -1 && v <= 1)
+			diff += float64((v - prev) * (v - prev))
+			power += float64(v * v)
+			prev = v
+		}
+		return diff / power
+	}
+
+	white, pink, brown := smoothness(White), smoothness(Pink), smoothness(Brown)
+	assert.Greater(t, white, pink)
+	assert.Greater(t, pink, brown)
+}
@@ -0,0 +1,61 @@
+package audio
+
+import "github.com/kelindar/noise"
+
+// LFO is a low frequency oscillator driven by 1D fBM instead of a periodic wave,
+// for modulating the parameters of procedural sounds or animation curves. Its value
+// only depends on the sample index, so it can be seeked to any position and stays
+// in phase with other streams at the same sample rate. The exported fields may be
+// tuned after NewLFO.
+type LFO struct {
+	Rate      float64 // Average number of oscillations per second, default 1
+	Depth     float64 // Amplitude of the modulation, default 1
+	Center    float64 // Value around which the modulation oscillates, default 0
+	Smoothing float64 // Smoothness in [0, 1], 1 removes all fine detail, default 0.5
+	rate      float64
+	fbm       *noise.FBM
+	index     uint64
+}
+
+// NewLFO creates a noise-driven oscillator for the given sample rate
+//
+// Example:
+//
+//	wobble := audio.NewLFO(12345, 48000)
+//	wobble.Rate, wobble.Depth, wobble.Center = 0.5, 200, 800
+//	cutoff := wobble.ValueAt(i) // filter cutoff in Hz at sample i
+func NewLFO(seed uint32, sampleRate int) *LFO {
+	if sampleRate <= 0 {
+		panic("noise: sample rate must be positive")
+	}
+
+	return &LFO{
+		Rate:      1,
+		Depth:     1,
+		Smoothing: 0.5,
+		rate:      float64(sampleRate),
+		fbm:       noise.NewFBM(seed),
+	}
+}
+
+// ValueAt returns the value of the oscillator at the given sample index, within
+// [Center - Depth, Center + Depth]
+func (l *LFO) ValueAt(sampleIndex uint64) float64 {
+	smoothing := min(max(l.Smoothing, 0), 1)
+	octaves := 1 + int((1-smoothing)*4)
+	t := float64(sampleIndex) / l.rate * l.Rate
+	v := l.fbm.Eval64(2, 0.5, octaves, t)
+	return l.Center + float64(v*l.Depth)
+}
+
+// Next returns the value at the current position and advances by one sample
+func (l *LFO) Next() float64 {
+	v := l.ValueAt(l.index)
+	l.index++
+	return v
+}
+
+// Seek moves the current position to the given sample index
+func (l *LFO) Seek(sampleIndex uint64) {
+	l.index = sampleIndex
+}
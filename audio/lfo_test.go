@@ -0,0 +1,43 @@
+package audio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLFO(t *testing.T) {
+	l := NewLFO(42, 1000)
+	l.Center, l.Depth = 10, 2
+	for i := uint64(0); i < 5000; i++ {
+		v := l.Next()
+		assert.Equal(t, l.ValueAt(i), v)
+		assert.True(t, v >= 8 && v <= 12)
+	}
+
+	// Seeking is phase-stable
+	l.Seek(123456789)
+	v := l.Next()
+	assert.Equal(t, NewLFO(42, 1000).ValueAt(123456789)*2+10, v)
+	assert.Panics(t, func() { NewLFO(1, 0) })
+}
+
+func TestLFOSmoothing(t *testing.T) {
+	// Smoother settings vary less from one sample to the next, relative to their power
+	roughness := func(smoothing float64) float64 {
+		l := NewLFO(7, 100)
+		l.Rate = 5
+		l.Smoothing = smoothing
+		var diff, power float64
+		prev := l.Next()
+		for i := 0; i < 5000; i++ {
+			v := l.Next()
+			diff += (v - prev) * (v - prev)
+			power += v * v
+			prev = v
+		}
+		return diff / power
+	}
+
+	assert.Greater(t, roughness(0), roughness(1))
+}
@@ -0,0 +1,72 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCellularCave(t *testing.T) {
+	const w, h = 80, 50
+	walls := CellularCave(1, w, h, 0.45, 5)
+	assert.Len(t, walls, w*h)
+	assert.Equal(t, walls, CellularCave(1, w, h, 0.45, 5))
+	assert.NotEqual(t, walls, CellularCave(2, w, h, 0.45, 5))
+
+	// Smoothing leaves few isolated cells behind
+	isolated := 0
+	for y := 1; y < h-1; y++ {
+		for x := 1; x < w-1; x++ {
+			i := y*w + x
+			if walls[i] != walls[i-1] && walls[i] != walls[i+1] && walls[i] != walls[i-w] && walls[i] != walls[i+w] {
+				isolated++
+			}
+		}
+	}
+	assert.Less(t, isolated, 10)
+
+	// Without iterations, the fill probability is respected
+	noisy := CellularCave(1, 100, 100, 0.3, 0)
+	filled := 0
+	for _, v := range noisy {
+		if v {
+			filled++
+		}
+	}
+	assert.InDelta(t, 3000, filled, 200)
+	assert.Nil(t, CellularCave(1, 0, 10, 0.45, 5))
+}
+
+func TestClusters(t *testing.T) {
+	cells := []bool{
+		true, true, false, true,
+		false, false, false, true,
+		true, false, true, true,
+	}
+
+	open := Clusters(cells, 4, 3, false)
+	assert.Equal(t, [][]int{{2, 4, 5, 6, 9}}, open)
+
+	walls := Clusters(cells, 4, 3, true)
+	assert.Equal(t, [][]int{{3, 7, 10, 11}, {0, 1}, {8}}, walls)
+	assert.Nil(t, Clusters(cells, 4, 4, true))
+}
+
+func TestPercolates(t *testing.T) {
+	cells := []bool{
+		false, true, false,
+		false, true, false,
+		true, false, true,
+	}
+	assert.False(t, Percolates(cells, 3, 3, true))
+	assert.False(t, Percolates(cells, 3, 3, false))
+
+	cells[7] = true
+	assert.True(t, Percolates(cells, 3, 3, true))
+
+	// Random grids percolate well above the threshold, and rarely well below it
+	dense := CellularCave(1, 64, 64, 0.75, 0)
+	sparse := CellularCave(1, 64, 64, 0.4, 0)
+	assert.True(t, Percolates(dense, 64, 64, true))
+	assert.False(t, Percolates(sparse, 64, 64, true))
+}
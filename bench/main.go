@@ -29,6 +29,7 @@ func runBenchmarks(b *bench.B) {
 	rnd2D := dataRand2D(size)
 	seq3D := dataSeq3D(size)
 	rnd3D := dataRand3D(size)
+	grid := make([]float32, 32*32)
 
 	// Benchmark table
 	benchmarks := []struct {
@@ -61,6 +62,10 @@ func runBenchmarks(b *bench.B) {
 			_ = s.Eval(p[0], p[1], p[2])
 		}},
 
+		{"simplex 2D (fill 32x32)", func(i int) {
+			s.Fill2D(grid, 32, 32, float32(i), 0, 0.1)
+		}},
+
 		// FBM benchmarks
 		{"fbm 1D (seq)", func(i int) {
 			p := seq1D[i%len(seq1D)]
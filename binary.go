@@ -0,0 +1,165 @@
+package noise
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// ---------------------------------- Binary Encoding ----------------------------------
+
+// binaryFormat is the revision of the binary encoding, stored in every header
+const binaryFormat = 1
+
+// Type tags of the binary encoding
+const (
+	tagSimplex = 's'
+	tagFBM     = 'f'
+	tagJSON    = 'j'
+)
+
+// MarshalBinary encodes the generator including its permutation table, not just
+// its seed, so that a persisted world keeps generating the same terrain even if a
+// later version changes how tables are built from seeds. It implements
+// encoding.BinaryMarshaler, which also makes the generator usable with gob.
+//
+// Example:
+//
+//	state, err := NewSimplex(12345).MarshalBinary()
+//	restored := new(Simplex)
+//	err = restored.UnmarshalBinary(state)
+func (s *Simplex) MarshalBinary() ([]byte, error) {
+	out := header(tagSimplex, 4+256+1)
+	out = binary.LittleEndian.AppendUint32(out, s.seed)
+	out = append(out, s.perm[:256]...)
+
+	// The version follows the table, omitted for V1 to keep older encodings valid
+	if Version(s.version) > V1 {
+		out = append(out, s.version)
+	}
+	return out, nil
+}
+
+// UnmarshalBinary restores a generator encoded by MarshalBinary, implementing
+// encoding.BinaryUnmarshaler
+func (s *Simplex) UnmarshalBinary(data []byte) error {
+	data, err := readHeader(data, tagSimplex)
+	switch {
+	case err != nil:
+		return err
+	case len(data) != 4+256 && len(data) != 4+256+1:
+		return fmt.Errorf("noise: invalid simplex encoding length %d", len(data))
+	}
+
+	version := V1
+	if len(data) > 4+256 {
+		if version = Version(data[4+256]); version < V1 || version > Latest {
+			return fmt.Errorf("noise: unknown simplex version %d", version)
+		}
+	}
+
+	// The table must be a permutation, otherwise the gradients are skewed
+	var seen [256]bool
+	for _, v := range data[4 : 4+256] {
+		if seen[v] {
+			return fmt.Errorf("noise: invalid simplex permutation table")
+		}
+		seen[v] = true
+	}
+
+	s.seed = binary.LittleEndian.Uint32(data)
+	s.version = uint8(version)
+	copy(s.perm[:256], data[4:4+256])
+	copy(s.perm[256:], data[4:4+256])
+	return nil
+}
+
+// MarshalBinary encodes the generator parameters and its basis, implementing
+// encoding.BinaryMarshaler. A simplex basis is encoded with its permutation
+// table, while other bases are embedded in their JSON form, so any basis that
+// MarshalSource accepts can be encoded.
+func (f *FBM) MarshalBinary() ([]byte, error) {
+	basis, err := marshalBasis(f.basis)
+	if err != nil {
+		return nil, err
+	}
+
+	out := header(tagFBM, 12+len(basis))
+	out = binary.LittleEndian.AppendUint32(out, math.Float32bits(f.Lacunarity))
+	out = binary.LittleEndian.AppendUint32(out, math.Float32bits(f.Gain))
+	out = binary.LittleEndian.AppendUint32(out, uint32(int32(f.Octaves)))
+	return append(out, basis...), nil
+}
+
+// UnmarshalBinary restores a generator encoded by MarshalBinary, implementing
+// encoding.BinaryUnmarshaler
+func (f *FBM) UnmarshalBinary(data []byte) error {
+	data, err := readHeader(data, tagFBM)
+	switch {
+	case err != nil:
+		return err
+	case len(data) < 12:
+		return fmt.Errorf("noise: invalid fBM encoding length %d", len(data))
+	}
+
+	basis, err := unmarshalBasis(data[12:])
+	if err != nil {
+		return err
+	}
+
+	*f = *NewFBMWith(basis)
+	f.Lacunarity = math.Float32frombits(binary.LittleEndian.Uint32(data[0:]))
+	f.Gain = math.Float32frombits(binary.LittleEndian.Uint32(data[4:]))
+	f.Octaves = int(int32(binary.LittleEndian.Uint32(data[8:])))
+	return nil
+}
+
+// marshalBasis encodes the basis of a fractal
+func marshalBasis(src Source) ([]byte, error) {
+	if s, ok := src.(*Simplex); ok {
+		return s.MarshalBinary()
+	}
+
+	encoded, err := MarshalSource(src)
+	if err != nil {
+		return nil, err
+	}
+	return append(header(tagJSON, len(encoded)), encoded...), nil
+}
+
+// unmarshalBasis decodes the basis of a fractal
+func unmarshalBasis(data []byte) (Source, error) {
+	if len(data) >= 4 && data[3] == tagJSON {
+		data, err := readHeader(data, tagJSON)
+		if err != nil {
+			return nil, err
+		}
+		return UnmarshalSource(data)
+	}
+
+	s := new(Simplex)
+	if err := s.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// header allocates an encoding with room for the payload and writes its header
+func header(tag byte, size int) []byte {
+	out := make([]byte, 0, 4+size)
+	return append(out, 'N', 'Z', binaryFormat, tag)
+}
+
+// readHeader validates the header of an encoding and returns its payload
+func readHeader(data []byte, tag byte) ([]byte, error) {
+	switch {
+	case len(data) < 4 || data[0] != 'N' || data[1] != 'Z':
+		return nil, fmt.Errorf("noise: invalid binary encoding")
+	case data[2] != binaryFormat:
+		return nil, fmt.Errorf("noise: unsupported binary format %d", data[2])
+	case data[3] != tag:
+		return nil, fmt.Errorf("noise: unexpected binary encoding of type %q", data[3])
+	default:
+		return data[4:], nil
+	}
+}
@@ -0,0 +1,86 @@
+package noise
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimplexBinary(t *testing.T) {
+	s := NewSimplex(12345)
+	data, err := s.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Len(t, data, 4+4+256)
+
+	out := new(Simplex)
+	assert.NoError(t, out.UnmarshalBinary(data))
+	assert.Equal(t, s.perm, out.perm)
+	assert.Equal(t, s.Eval3(1.5, 2.5, 3.5), out.Eval3(1.5, 2.5, 3.5))
+
+	// The table is restored as stored, even if it differs from the seed's
+	data[8], data[9] = data[9], data[8]
+	assert.NoError(t, out.UnmarshalBinary(data))
+	assert.NotEqual(t, s.perm, out.perm)
+	assert.Equal(t, out.perm[0], out.perm[256])
+	assert.Equal(t, out.perm[1], out.perm[257])
+
+	// Invalid encodings are rejected
+	data[8] = data[9]
+	assert.ErrorContains(t, out.UnmarshalBinary(data), "permutation")
+	assert.ErrorContains(t, out.UnmarshalBinary(data[:100]), "length")
+	assert.ErrorContains(t, out.UnmarshalBinary(nil), "invalid binary")
+	assert.ErrorContains(t, out.UnmarshalBinary([]byte{'N', 'Z', 9, 's'}), "unsupported")
+	assert.ErrorContains(t, out.UnmarshalBinary([]byte{'N', 'Z', 1, 'f'}), "unexpected")
+
+	// Later versions append their version to the table
+	data, err = NewSimplexV2(12345).MarshalBinary()
+	assert.NoError(t, err)
+	assert.Len(t, data, 4+4+256+1)
+	assert.NoError(t, out.UnmarshalBinary(data))
+	assert.Equal(t, uint8(V2), out.version)
+
+	data[len(data)-1] = 9
+	assert.ErrorContains(t, out.UnmarshalBinary(data), "version")
+}
+
+func TestFBMBinary(t *testing.T) {
+	for _, f := range []*FBM{
+		NewFBM(12345),
+		NewFBMWith(Ridged(NewSimplex(1))),
+		NewFBMWith(NewValue(2)),
+	} {
+		f.Lacunarity, f.Gain, f.Octaves = 2.5, 0.4, 6
+		data, err := f.MarshalBinary()
+		assert.NoError(t, err)
+
+		out := new(FBM)
+		assert.NoError(t, out.UnmarshalBinary(data))
+		assert.Equal(t, f.Lacunarity, out.Lacunarity)
+		assert.Equal(t, f.Gain, out.Gain)
+		assert.Equal(t, f.Octaves, out.Octaves)
+		assert.Equal(t, f.Eval2(1.5, 2.5), out.Eval2(1.5, 2.5))
+		assert.Equal(t, f.Eval3(1.5, 2.5, 3.5), out.Eval3(1.5, 2.5, 3.5))
+	}
+
+	_, err := NewFBMWith(SourceFunc(func(x, y, z float32) float32 { return 0 })).MarshalBinary()
+	assert.Error(t, err)
+	assert.ErrorContains(t, new(FBM).UnmarshalBinary([]byte{'N', 'Z', 1, 'f', 0}), "length")
+}
+
+func TestGob(t *testing.T) {
+	type world struct {
+		Terrain *FBM
+		Detail  *Simplex
+	}
+
+	in := world{Terrain: NewFBM(1), Detail: NewSimplex(2)}
+	var buf bytes.Buffer
+	assert.NoError(t, gob.NewEncoder(&buf).Encode(in))
+
+	var out world
+	assert.NoError(t, gob.NewDecoder(&buf).Decode(&out))
+	assert.Equal(t, in.Terrain.Eval2(1.5, 2.5), out.Terrain.Eval2(1.5, 2.5))
+	assert.Equal(t, in.Detail.Eval2(1.5, 2.5), out.Detail.Eval2(1.5, 2.5))
+}
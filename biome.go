@@ -0,0 +1,181 @@
+package noise
+
+import "math"
+
+// ---------------------------------- Biomes ----------------------------------
+
+// Biome identifies a biome in a Whittaker table
+type Biome uint8
+
+// Default biomes, as used by DefaultWhittaker
+const (
+	BiomeOcean Biome = iota
+	BiomeTundra
+	BiomeTaiga
+	BiomeGrassland
+	BiomeShrubland
+	BiomeTemperateForest
+	BiomeTemperateRainforest
+	BiomeDesert
+	BiomeSavanna
+	BiomeSeasonalForest
+	BiomeRainforest
+)
+
+// BiomeWeight is the contribution of a biome to a cell, for smooth transitions
+type BiomeWeight struct {
+	Biome  Biome
+	Weight float32
+}
+
+// Whittaker is a biome classification table. Temperature and moisture in [-1, 1]
+// are split into equal bands, indexing the rows and columns of the table. Cells below
+// sea level are ocean, and the temperature drops with the elevation above it.
+type Whittaker struct {
+	Table    [][]Biome // Rows from cold to hot, columns from dry to wet
+	Ocean    Biome     // Biome of the cells below sea level
+	SeaLevel float32   // Elevation of the sea level, in [-1, 1]
+	Lapse    float32   // Temperature drop per unit of elevation above sea level
+}
+
+// DefaultWhittaker returns a simplified Whittaker table of 4 temperature by 4
+// moisture bands, with a sea level of 0 and a lapse rate of 1.
+func DefaultWhittaker() Whittaker {
+	return Whittaker{
+		Table: [][]Biome{
+			{BiomeTundra, BiomeTundra, BiomeTaiga, BiomeTaiga},
+			{BiomeGrassland, BiomeShrubland, BiomeTemperateForest, BiomeTemperateRainforest},
+			{BiomeDesert, BiomeShrubland, BiomeSeasonalForest, BiomeTemperateRainforest},
+			{BiomeDesert, BiomeSavanna, BiomeSeasonalForest, BiomeRainforest},
+		},
+		Ocean:    BiomeOcean,
+		SeaLevel: 0,
+		Lapse:    1,
+	}
+}
+
+// Classify returns the biome of a cell and the weights of up to 4 biomes, obtained
+// by bilinear interpolation between the centers of the neighbouring table entries.
+// Weights of duplicated biomes are merged, unused entries have a zero weight, and
+// the weights sum to 1.
+func (wt *Whittaker) Classify(temperature, moisture, elevation float32) (Biome, [4]BiomeWeight) {
+	var out [4]BiomeWeight
+	if elevation < wt.SeaLevel || len(wt.Table) == 0 || len(wt.Table[0]) == 0 {
+		out[0] = BiomeWeight{Biome: wt.Ocean, Weight: 1}
+		return wt.Ocean, out
+	}
+
+	temperature -= float32((elevation - wt.SeaLevel) * wt.Lapse)
+	rows, cols := len(wt.Table), len(wt.Table[0])
+	ft := float32(min(max((temperature+1)/2, 0), 1) * float32(rows))
+	fm := float32(min(max((moisture+1)/2, 0), 1) * float32(cols))
+
+	// Position relative to the centers of the table entries
+	ti, tu := band(ft-0.5, rows)
+	mi, mu := band(fm-0.5, cols)
+	corners := [4]BiomeWeight{
+		{wt.Table[ti][mi], (1 - tu) * (1 - mu)},
+		{wt.Table[ti][min(mi+1, cols-1)], (1 - tu) * mu},
+		{wt.Table[min(ti+1, rows-1)][mi], tu * (1 - mu)},
+		{wt.Table[min(ti+1, rows-1)][min(mi+1, cols-1)], tu * mu},
+	}
+
+	// Merge the weights of duplicated biomes
+	n := 0
+	for _, c := range corners {
+		merged := false
+		for i := 0; i < n; i++ {
+			if out[i].Biome == c.Biome {
+				out[i].Weight += c.Weight
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			out[n] = c
+			n++
+		}
+	}
+
+	biome := wt.Table[min(int(ft), rows-1)][min(int(fm), cols-1)]
+	return biome, out
+}
+
+// band returns the index of the lower table entry and the interpolation factor
+func band(v float32, n int) (int, float32) {
+	switch {
+	case v <= 0:
+		return 0, 0
+	case v >= float32(n-1):
+		return n - 1, 0
+	default:
+		i := int(v)
+		return i, v - float32(i)
+	}
+}
+
+// BiomeMap is a grid of biomes, in row-major order
+type BiomeMap struct {
+	Width, Height int
+	Index         []Biome          // Dominant biome of each cell
+	Weights       [][4]BiomeWeight // Blend weights of each cell
+}
+
+// Biomes classifies a w×h grid into biomes using three independent fBM fields for
+// temperature, moisture and elevation, all derived from the seed and sampled every
+// step units. The classification follows the given Whittaker table, and the blend
+// weights allow smooth transitions when texturing or mixing biome parameters.
+//
+// Example:
+//
+//	m := Biomes(12345, 512, 512, 0.005, DefaultWhittaker())
+//	if m.Index[y*512+x] == BiomeDesert {
+//	    // place a cactus
+//	}
+func Biomes(seed uint32, w, h int, step float32, table Whittaker) BiomeMap {
+	if w <= 0 || h <= 0 {
+		return BiomeMap{}
+	}
+
+	fields := newBiomeFields(seed)
+	out := BiomeMap{
+		Width:   w,
+		Height:  h,
+		Index:   make([]Biome, w*h),
+		Weights: make([][4]BiomeWeight, w*h),
+	}
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			fx, fy := float32(x)*step, float32(y)*step
+			i := y*w + x
+			out.Index[i], out.Weights[i] = fields.classify(&table, fx, fy, fields.elevation.Eval2(fx, fy))
+		}
+	}
+	return out
+}
+
+// biomeFields are the temperature, moisture and elevation fields of a seed
+type biomeFields struct {
+	temperature, moisture, elevation *FBM
+}
+
+// newBiomeFields derives the three fields from the seed
+func newBiomeFields(seed uint32) biomeFields {
+	return biomeFields{
+		temperature: NewFBM(uint32(xxhash64(1, uint64(seed)))),
+		moisture:    NewFBM(uint32(xxhash64(2, uint64(seed)))),
+		elevation:   NewFBM(uint32(xxhash64(3, uint64(seed)))),
+	}
+}
+
+// classify returns the biome at (x, y) for the given elevation
+func (f *biomeFields) classify(table *Whittaker, x, y, elevation float32) (Biome, [4]BiomeWeight) {
+	return table.Classify(stretch(f.temperature.Eval2(x, y)), stretch(f.moisture.Eval2(x, y)), elevation)
+}
+
+// stretch widens the bell-shaped distribution of fBM towards [-1, 1], so that the
+// outer bands of a table are reached as often as the inner ones
+func stretch(v float32) float32 {
+	return float32(math.Tanh(float64(v) * 2.5))
+}
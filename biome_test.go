@@ -0,0 +1,71 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	wt := DefaultWhittaker()
+
+	// Below sea level
+	b, w := wt.Classify(0.5, 0.5, -0.1)
+	assert.Equal(t, BiomeOcean, b)
+	assert.Equal(t, BiomeWeight{BiomeOcean, 1}, w[0])
+
+	// Corners of the table
+	b, w = wt.Classify(-1, -1, 0)
+	assert.Equal(t, BiomeTundra, b)
+	assert.Equal(t, BiomeWeight{BiomeTundra, 1}, w[0])
+
+	b, _ = wt.Classify(1, -1, 0)
+	assert.Equal(t, BiomeDesert, b)
+
+	b, _ = wt.Classify(1, 1, 0)
+	assert.Equal(t, BiomeRainforest, b)
+
+	// Elevation cools the climate
+	b, _ = wt.Classify(1, 1, 1.5)
+	assert.Equal(t, BiomeTemperateRainforest, b)
+
+	// Weights always sum to 1 and include the dominant biome
+	for i := 0; i < 1000; i++ {
+		temp := Float32(1, uint64(i))*2 - 1
+		moist := Float32(2, uint64(i))*2 - 1
+		b, w := wt.Classify(temp, moist, 0.1)
+
+		var total float32
+		found := false
+		for _, bw := range w {
+			total += bw.Weight
+			found = found || (bw.Biome == b && bw.Weight > 0)
+		}
+		assert.InDelta(t, 1, total, 1e-5)
+		assert.True(t, found)
+	}
+}
+
+func TestClassifyEmpty(t *testing.T) {
+	wt := Whittaker{Ocean: 7}
+	b, _ := wt.Classify(0, 0, 1)
+	assert.Equal(t, Biome(7), b)
+}
+
+func TestBiomes(t *testing.T) {
+	m := Biomes(42, 128, 128, 0.02, DefaultWhittaker())
+	assert.Equal(t, 128, m.Width)
+	assert.Len(t, m.Index, 128*128)
+	assert.Len(t, m.Weights, 128*128)
+	assert.Equal(t, m, Biomes(42, 128, 128, 0.02, DefaultWhittaker()))
+
+	// A reasonable variety of biomes
+	seen := map[Biome]bool{}
+	for _, b := range m.Index {
+		seen[b] = true
+	}
+	assert.GreaterOrEqual(t, len(seen), 4)
+	assert.True(t, seen[BiomeOcean])
+
+	assert.Equal(t, BiomeMap{}, Biomes(42, 0, 10, 0.02, DefaultWhittaker()))
+}
@@ -0,0 +1,82 @@
+package noise
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// ---------------------------------- Budgets ----------------------------------
+
+// Budget limits how much work a streaming iterator may do, so that servers can
+// time-slice the generation of huge maps. Zero fields impose no limit.
+type Budget struct {
+	MaxPoints   int           // Maximum number of values to emit
+	MaxDuration time.Duration // Maximum time spent iterating, measured from the first value requested
+}
+
+// WithContext returns an iterator that stops as soon as the context is cancelled
+// or its deadline passes. It works with any of the sparse, SSI or scatter
+// iterators, and the caller can check ctx.Err() afterwards to tell a cancellation
+// from a complete run.
+//
+// Example:
+//
+//	for p := range WithContext(ctx, Sparse2(12345, 8192, 8192, 8)) {
+//	    // use p, the loop ends when ctx is cancelled
+//	}
+func WithContext[T any](ctx context.Context, seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if ctx.Err() != nil {
+			return
+		}
+
+		done := ctx.Done()
+		for v := range seq {
+			select {
+			case <-done:
+				return
+			default:
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// WithBudget returns an iterator that stops once the budget is exhausted, either
+// after emitting MaxPoints values or once MaxDuration has elapsed, whichever
+// comes first. The time spent in the caller's loop body counts towards the budget.
+//
+// Example:
+//
+//	// Spend at most 2ms of each frame populating the world
+//	for p := range WithBudget(SSI2(12345, 512, 512), Budget{MaxDuration: 2 * time.Millisecond}) {
+//	    // use p
+//	}
+func WithBudget[T any](seq iter.Seq[T], budget Budget) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if budget.MaxPoints < 0 || budget.MaxDuration < 0 {
+			return
+		}
+
+		var deadline time.Time
+		if budget.MaxDuration > 0 {
+			deadline = time.Now().Add(budget.MaxDuration)
+		}
+
+		n := 0
+		for v := range seq {
+			switch {
+			case budget.MaxPoints > 0 && n >= budget.MaxPoints:
+				return
+			case !deadline.IsZero() && time.Now().After(deadline):
+				return
+			case !yield(v):
+				return
+			}
+			n++
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package noise
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithContext(t *testing.T) {
+	all := 0
+	for range Sparse2(1, 256, 256, 8) {
+		all++
+	}
+
+	// Runs to completion without cancellation
+	n := 0
+	for range WithContext(context.Background(), Sparse2(1, 256, 256, 8)) {
+		n++
+	}
+	assert.Equal(t, all, n)
+
+	// Stops right after the cancellation
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	n = 0
+	for range WithContext(ctx, Sparse2(1, 256, 256, 8)) {
+		if n++; n == 10 {
+			cancel()
+		}
+	}
+	assert.Equal(t, 10, n)
+
+	// Emits nothing once cancelled
+	n = 0
+	for range WithContext(ctx, SSI1(1, 100)) {
+		n++
+	}
+	assert.Equal(t, 0, n)
+}
+
+func TestWithBudget(t *testing.T) {
+	var got [][2]float32
+	for p := range WithBudget(SSI2(1, 64, 64), Budget{MaxPoints: 25}) {
+		got = append(got, p)
+	}
+	assert.Len(t, got, 25)
+
+	// Same points as the unbounded iterator
+	i := 0
+	for p := range SSI2(1, 64, 64) {
+		if i == len(got) {
+			break
+		}
+		assert.Equal(t, got[i], p)
+		i++
+	}
+
+	// Stops once the time is up
+	n := 0
+	for range WithBudget(SSI2(1, 256, 256), Budget{MaxDuration: 5 * time.Millisecond}) {
+		time.Sleep(time.Millisecond)
+		n++
+	}
+	assert.Greater(t, n, 0)
+	assert.Less(t, n, 100)
+
+	// No limits
+	all, n := 0, 0
+	for range SSI1(1, 10) {
+		all++
+	}
+	for range WithBudget(SSI1(1, 10), Budget{}) {
+		n++
+	}
+	assert.Equal(t, all, n)
+
+	n = 0
+	for range WithBudget(SSI1(1, 10), Budget{MaxPoints: -1}) {
+		n++
+	}
+	assert.Equal(t, 0, n)
+}
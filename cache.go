@@ -0,0 +1,93 @@
+package noise
+
+import (
+	"math"
+	"sync"
+)
+
+// ---------------------------------- Cache ----------------------------------
+
+// cache memoizes the most recently evaluated coordinates of a source
+type cache struct {
+	mu   sync.Mutex
+	src  Source
+	size int
+	keys []cacheKey // most recently used first
+	vals []float32
+}
+
+// cacheKey is a coordinate stored in the cache, keyed by its exact bits
+type cacheKey struct {
+	x, y, z uint32
+	dim     uint8
+}
+
+// Cache returns a source that memoizes the last size coordinates evaluated on src,
+// with least-recently-used eviction. It is meant for pipelines where the same
+// source feeds several modules, e.g. a continental mask driving both a Select and
+// a Blend, so that it is only evaluated once per coordinate. A size <= 1 only
+// remembers the last coordinate, which matches the libnoise Cache module.
+//
+// The cache is safe for concurrent use, but goroutines evaluating interleaved
+// coordinates will evict each other's entries, so it is best kept per goroutine.
+func Cache(src Source, size int) Source {
+	return &cache{
+		src:  src,
+		size: max(size, 1),
+	}
+}
+
+// Eval2 evaluates the source in 2D, or returns the memoized value
+func (c *cache) Eval2(x, y float32) float32 {
+	key := cacheKey{x: math.Float32bits(x), y: math.Float32bits(y), dim: 2}
+	if v, ok := c.load(key); ok {
+		return v
+	}
+
+	v := c.src.Eval2(x, y)
+	c.store(key, v)
+	return v
+}
+
+// Eval3 evaluates the source in 3D, or returns the memoized value
+func (c *cache) Eval3(x, y, z float32) float32 {
+	key := cacheKey{x: math.Float32bits(x), y: math.Float32bits(y), z: math.Float32bits(z), dim: 3}
+	if v, ok := c.load(key); ok {
+		return v
+	}
+
+	v := c.src.Eval3(x, y, z)
+	c.store(key, v)
+	return v
+}
+
+// load looks up a key and moves it to the front on a hit
+func (c *cache) load(key cacheKey) (float32, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, k := range c.keys {
+		if k == key {
+			v := c.vals[i]
+			copy(c.keys[1:i+1], c.keys[:i])
+			copy(c.vals[1:i+1], c.vals[:i])
+			c.keys[0], c.vals[0] = key, v
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// store inserts a key at the front, evicting the least recently used entry
+func (c *cache) store(key cacheKey, v float32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.keys) < c.size {
+		c.keys = append(c.keys, cacheKey{})
+		c.vals = append(c.vals, 0)
+	}
+
+	n := len(c.keys)
+	copy(c.keys[1:n], c.keys[:n-1])
+	copy(c.vals[1:n], c.vals[:n-1])
+	c.keys[0], c.vals[0] = key, v
+}
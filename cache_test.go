@@ -0,0 +1,69 @@
+package noise
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	calls := 0
+	src := SourceFunc(func(x, y, z float32) float32 {
+		calls++
+		return x + y + z
+	})
+
+	c := Cache(src, 1)
+	assert.Equal(t, float32(3), c.Eval2(1, 2))
+	assert.Equal(t, float32(3), c.Eval2(1, 2))
+	assert.Equal(t, 1, calls)
+
+	// 2D and 3D keys are distinct, even on the z = 0 plane
+	assert.Equal(t, float32(3), c.Eval3(1, 2, 0))
+	assert.Equal(t, 2, calls)
+
+	// A single entry is evicted by any other coordinate
+	c.Eval2(3, 4)
+	c.Eval3(1, 2, 0)
+	assert.Equal(t, 4, calls)
+}
+
+func TestCacheLRU(t *testing.T) {
+	calls := 0
+	src := SourceFunc(func(x, y, z float32) float32 {
+		calls++
+		return x
+	})
+
+	c := Cache(src, 2)
+	c.Eval2(1, 0) // [1]
+	c.Eval2(2, 0) // [2, 1]
+	c.Eval2(1, 0) // [1, 2]
+	assert.Equal(t, 2, calls)
+
+	c.Eval2(3, 0) // [3, 1], evicts 2
+	c.Eval2(1, 0)
+	assert.Equal(t, 3, calls)
+
+	c.Eval2(2, 0)
+	assert.Equal(t, 4, calls)
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	s := NewSimplex(42)
+	c := Cache(s, 4)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				x := float32(i % 7)
+				assert.Equal(t, s.Eval2(x, 0.5), c.Eval2(x, 0.5))
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,71 @@
+package noise
+
+// ---------------------------------- Caves ----------------------------------
+
+// Cave is a 3D cave density field combining winding tunnels, formed where two ridged
+// noise fields are both close to zero, with larger caverns where a low frequency field
+// dips. The domain is warped by fBM so tunnels meander, and squashed vertically so
+// caves are wider than they are tall. The exported fields may be tuned after Caves.
+type Cave struct {
+	Frequency float32 // Frequency of the tunnels, in cycles per unit
+	Squash    float32 // Vertical squash factor, > 1 makes caves flatter
+	Warp      float32 // Strength of the domain warping, in units
+	Tunnel    float32 // Half-width of the tunnels, in noise units
+	Cavern    float32 // Threshold under which the cavern field opens up, in [-1, 1]
+	a, b, c   *Simplex
+	warp      *FBM
+}
+
+// Caves creates a cave generator with sensible defaults for voxel terrain where a
+// unit is a block, with y as the vertical axis.
+//
+// Example:
+//
+//	caves := Caves(12345)
+//	if caves.Density(x, y, z) < 0 {
+//	    // carve out the block
+//	}
+func Caves(seed uint32) *Cave {
+	return &Cave{
+		Frequency: 0.02,
+		Squash:    2,
+		Warp:      8,
+		Tunnel:    0.08,
+		Cavern:    -0.6,
+		a:         NewSimplex(uint32(xxhash64(1, uint64(seed)))),
+		b:         NewSimplex(uint32(xxhash64(2, uint64(seed)))),
+		c:         NewSimplex(uint32(xxhash64(3, uint64(seed)))),
+		warp:      NewFBM(uint32(xxhash64(4, uint64(seed)))),
+	}
+}
+
+// Density returns the cave density at (x, y, z) in [-1, 1], which is negative in
+// open space (tunnels and caverns) and positive in solid rock.
+func (c *Cave) Density(x, y, z float32) float32 {
+	y *= c.Squash
+
+	// Warp the domain so that tunnels meander instead of following the lattice
+	const w = 0.01
+	wx, wy, wz := float32(x*w), float32(y*w), float32(z*w)
+	x += float32(c.Warp * c.warp.Eval3(wx, wy, wz))
+	y += float32(c.Warp * c.warp.Eval3(wx+31.4, wy+47.2, wz+19.7))
+	z += float32(c.Warp * c.warp.Eval3(wx+73.1, wy+11.9, wz+53.3))
+
+	// Tunnels are where both fields are near zero, the intersection of two sheets
+	fx, fy, fz := x*c.Frequency, y*c.Frequency, z*c.Frequency
+	tunnel := max(abs(c.a.Eval3(fx, fy, fz)), abs(c.b.Eval3(fx, fy, fz))) - c.Tunnel
+
+	// Caverns are where a lower frequency field dips below the threshold
+	cavern := c.c.Eval3(fx*0.25, fy*0.25, fz*0.25) - c.Cavern
+	return min(max(min(tunnel, cavern), -1), 1)
+}
+
+// Eval2 evaluates the density on the y = 0 plane, implementing Source2
+func (c *Cave) Eval2(x, y float32) float32 {
+	return c.Density(x, 0, y)
+}
+
+// Eval3 evaluates the density, implementing Source3
+func (c *Cave) Eval3(x, y, z float32) float32 {
+	return c.Density(x, y, z)
+}
@@ -0,0 +1,32 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaves(t *testing.T) {
+	c := Caves(42)
+	assert.Equal(t, c.Density(1, 2, 3), Caves(42).Density(1, 2, 3))
+	assert.Equal(t, c.Density(10, 0, 20), c.Eval2(10, 20))
+	assert.Equal(t, c.Density(1, 2, 3), c.Eval3(1, 2, 3))
+
+	// Mostly solid rock, with some open space
+	var open, total int
+	for x := 0; x < 64; x++ {
+		for y := 0; y < 16; y++ {
+			for z := 0; z < 64; z++ {
+				v := c.Density(float32(x), float32(y), float32(z))
+				assert.True(t, v >= -1 && v <= 1)
+				if v < 0 {
+					open++
+				}
+				total++
+			}
+		}
+	}
+
+	assert.Greater(t, open, 0)
+	assert.Less(t, open, total/2)
+}
@@ -0,0 +1,52 @@
+package noise
+
+import "math"
+
+// ---------------------------------- Cellular Noise ----------------------------------
+
+// Cellular represents a cellular (Worley) noise generator. Each integer cell holds
+// one randomly placed feature point and the noise is the distance to the nearest
+// one (F1), which produces cell, scale and stone-like patterns.
+type Cellular struct {
+	seed uint32
+}
+
+// NewCellular creates a new cellular noise generator with the given seed
+func NewCellular(seed uint32) *Cellular {
+	return &Cellular{seed: seed}
+}
+
+// Eval2 evaluates 2D cellular noise, implementing Source2. The F1 distance is
+// mapped to [-1, 1], so that -1 is at a feature point.
+func (c *Cellular) Eval2(x, y float32) float32 {
+	ix, iy := floor(x), floor(y)
+	best := float32(math.MaxFloat32)
+	for cy := iy - 1; cy <= iy+1; cy++ {
+		for cx := ix - 1; cx <= ix+1; cx++ {
+			h := hashCell(cx, cy, 0, c.seed)
+			dx := float32(cx) + Float32(c.seed, h) - x
+			dy := float32(cy) + Float32(c.seed^1, h) - y
+			best = min(best, float32(dx*dx)+float32(dy*dy))
+		}
+	}
+	return min(float32(math.Sqrt(float64(best))), 1)*2 - 1
+}
+
+// Eval3 evaluates 3D cellular noise, implementing Source3. The F1 distance is
+// mapped to [-1, 1], so that -1 is at a feature point.
+func (c *Cellular) Eval3(x, y, z float32) float32 {
+	ix, iy, iz := floor(x), floor(y), floor(z)
+	best := float32(math.MaxFloat32)
+	for cz := iz - 1; cz <= iz+1; cz++ {
+		for cy := iy - 1; cy <= iy+1; cy++ {
+			for cx := ix - 1; cx <= ix+1; cx++ {
+				h := hashCell(cx, cy, cz, c.seed)
+				dx := float32(cx) + Float32(c.seed, h) - x
+				dy := float32(cy) + Float32(c.seed^1, h) - y
+				dz := float32(cz) + Float32(c.seed^2, h) - z
+				best = min(best, float32(dx*dx)+float32(dy*dy)+float32(dz*dz))
+			}
+		}
+	}
+	return min(float32(math.Sqrt(float64(best))), 1)*2 - 1
+}
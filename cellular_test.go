@@ -0,0 +1,30 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCellular(t *testing.T) {
+	c := NewCellular(42)
+	assert.Equal(t, c.Eval2(1.3, 2.7), NewCellular(42).Eval2(1.3, 2.7))
+	assert.NotEqual(t, c.Eval2(1.3, 2.7), NewCellular(43).Eval2(1.3, 2.7))
+
+	for i := -50; i < 50; i++ {
+		x := float32(i) * 0.13
+		v2, v3 := c.Eval2(x, -x), c.Eval3(x, -x, 2*x)
+		assert.True(t, v2 >= -1 && v2 <= 1)
+		assert.True(t, v3 >= -1 && v3 <= 1)
+	}
+}
+
+func TestCellularFeature(t *testing.T) {
+	c := NewCellular(42)
+
+	// The feature point of a cell evaluates to the minimum
+	h := hashCell(3, -4, 0, 42)
+	x := 3 + Float32(42, h)
+	y := -4 + Float32(42^1, h)
+	assert.Equal(t, float32(-1), c.Eval2(x, y))
+}
@@ -0,0 +1,123 @@
+// Command libnoise builds the core generators of the noise package as a shared
+// library with a C ABI, so that engines written in C, C++ or Rust can link against
+// the same implementation as a Go backend and produce bit-identical output:
+//
+//	go build -buildmode=c-shared -o libnoise.so ./cmd/libnoise
+//
+// The build also writes libnoise.h declaring the functions below. Seeds select the
+// same generators as NewSimplex and NewFBM, whose output is frozen at noise.V1, and
+// recently used ones are cached. Every function is safe to call from multiple
+// threads. The signatures are stable: new functions may be added, but existing ones
+// are never changed.
+//
+//	float noise_simplex2(uint32_t seed, float x, float y);
+//	float noise_simplex3(uint32_t seed, float x, float y, float z);
+//	float noise_fbm2(uint32_t seed, float x, float y, float lacunarity, float gain, int octaves);
+//	float noise_fbm3(uint32_t seed, float x, float y, float z, float lacunarity, float gain, int octaves);
+//	float noise_white2(uint32_t seed, float x, float y);
+//	float noise_white3(uint32_t seed, float x, float y, float z);
+//	void  noise_fill2(uint32_t seed, float* dst, int w, int h, float x, float y, float step);
+//	int   noise_version(void);
+//	int   noise_self_test(void);
+package main
+
+// #include <stdint.h>
+import "C"
+
+import (
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/kelindar/noise"
+)
+
+// Generators are cached, since building their tables costs more than an evaluation.
+// Each slot holds one immutable generator and is replaced when a different seed maps
+// to it, so callers iterating over many seeds do not grow the memory without bound.
+var (
+	simplexCache [64]atomic.Pointer[cached[noise.Simplex]]
+	fbmCache     [64]atomic.Pointer[cached[noise.FBM]]
+)
+
+// cached is a generator along with the seed it was built from
+type cached[T any] struct {
+	seed uint32
+	gen  *T
+}
+
+// load returns the generator of a seed from its cache slot, building it if needed
+func load[T any](cache []atomic.Pointer[cached[T]], seed C.uint32_t, build func(uint32) *T) *T {
+	slot := &cache[(uint32(seed)*0x9e3779b1)>>26] // top 6 bits select one of 64 slots
+	if c := slot.Load(); c != nil && c.seed == uint32(seed) {
+		return c.gen
+	}
+
+	c := &cached[T]{seed: uint32(seed), gen: build(uint32(seed))}
+	slot.Store(c)
+	return c.gen
+}
+
+// simplexOf returns the cached simplex generator of a seed
+func simplexOf(seed C.uint32_t) *noise.Simplex {
+	return load(simplexCache[:], seed, noise.NewSimplex)
+}
+
+// fbmOf returns the cached fBM generator of a seed
+func fbmOf(seed C.uint32_t) *noise.FBM {
+	return load(fbmCache[:], seed, noise.NewFBM)
+}
+
+//export noise_simplex2
+func noise_simplex2(seed C.uint32_t, x, y C.float) C.float {
+	return C.float(simplexOf(seed).Eval2(float32(x), float32(y)))
+}
+
+//export noise_simplex3
+func noise_simplex3(seed C.uint32_t, x, y, z C.float) C.float {
+	return C.float(simplexOf(seed).Eval3(float32(x), float32(y), float32(z)))
+}
+
+//export noise_fbm2
+func noise_fbm2(seed C.uint32_t, x, y, lacunarity, gain C.float, octaves C.int) C.float {
+	return C.float(fbmOf(seed).Eval(float32(lacunarity), float32(gain), int(octaves), float32(x), float32(y)))
+}
+
+//export noise_fbm3
+func noise_fbm3(seed C.uint32_t, x, y, z, lacunarity, gain C.float, octaves C.int) C.float {
+	return C.float(fbmOf(seed).Eval(float32(lacunarity), float32(gain), int(octaves), float32(x), float32(y), float32(z)))
+}
+
+//export noise_white2
+func noise_white2(seed C.uint32_t, x, y C.float) C.float {
+	return C.float(noise.White(uint32(seed), float32(x), float32(y)))
+}
+
+//export noise_white3
+func noise_white3(seed C.uint32_t, x, y, z C.float) C.float {
+	return C.float(noise.White(uint32(seed), float32(x), float32(y), float32(z)))
+}
+
+//export noise_fill2
+func noise_fill2(seed C.uint32_t, dst *C.float, w, h C.int, x, y, step C.float) {
+	if dst == nil || w <= 0 || h <= 0 {
+		return
+	}
+
+	grid := unsafe.Slice((*float32)(unsafe.Pointer(dst)), int(w)*int(h))
+	simplexOf(seed).Fill2D(grid, int(w), int(h), float32(x), float32(y), float32(step))
+}
+
+//export noise_version
+func noise_version() C.int {
+	return C.int(noise.V1) // version of NewSimplex and NewFBM
+}
+
+//export noise_self_test
+func noise_self_test() C.int {
+	if noise.SelfTest() != nil {
+		return 0
+	}
+	return 1
+}
+
+func main() {}
@@ -0,0 +1,294 @@
+// Command noise renders procedural noise to PNG heightmaps, GIF animations, raw
+// float32 grids or PFM and EXR floating-point images.
+//
+// Usage:
+//
+//	noise -algo fbm -seed 42 -octaves 6 -size 512 -freq 0.01 -out terrain.png
+//	noise -algo simplex -frames 32 -out clouds.gif
+//	noise -algo ridged -format raw -out heights.r32
+//	noise -algo fbm -format png16 -size 1025 -out heightmap.png
+//	noise -algo fbm -size 1024 -out displacement.exr
+//	noise -preset terrain.json -color -out map.png
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kelindar/noise"
+)
+
+// Terrain colormap, from deep water to snow
+var terrain = noise.Terrain()
+
+// options are the command-line options of the tool
+type options struct {
+	algo       string
+	preset     string
+	format     string
+	out        string
+	seed       uint
+	octaves    int
+	lacunarity float64
+	gain       float64
+	width      int
+	height     int
+	frames     int
+	freq       float64
+	color      bool
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run parses the arguments and renders the requested output
+func run(args []string, stderr io.Writer) error {
+	var opts options
+	fs := flag.NewFlagSet("noise", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&opts.algo, "algo", "fbm", "algorithm: simplex, fbm, ridged, value, cellular or white")
+	fs.StringVar(&opts.preset, "preset", "", "JSON pipeline to render instead of -algo")
+	fs.StringVar(&opts.format, "format", "", "output format: png, png16, gif, raw, pfm, exr, npy or csv (default from the -out extension)")
+	fs.StringVar(&opts.out, "out", "noise.png", "output file, or - for stdout")
+	fs.UintVar(&opts.seed, "seed", 42, "random seed")
+	fs.IntVar(&opts.octaves, "octaves", 6, "number of fBM octaves")
+	fs.Float64Var(&opts.lacunarity, "lacunarity", 2, "frequency multiplier between octaves")
+	fs.Float64Var(&opts.gain, "gain", 0.5, "amplitude multiplier between octaves")
+	fs.IntVar(&opts.width, "size", 512, "width of the output, in pixels")
+	fs.IntVar(&opts.height, "height", 0, "height of the output, in pixels (default -size)")
+	fs.IntVar(&opts.frames, "frames", 0, "number of frames along the z axis, for gif or raw output")
+	fs.Float64Var(&opts.freq, "freq", 0.01, "base frequency, in noise units per pixel")
+	fs.BoolVar(&opts.color, "color", false, "color the output with a terrain palette")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if opts.height <= 0 {
+		opts.height = opts.width
+	}
+	if opts.format == "" {
+		opts.format = formatOf(opts.out)
+	}
+	if opts.width <= 0 || opts.height <= 0 {
+		return errors.New("noise: size must be positive")
+	}
+
+	// Reject the format before creating the file, as only gif and raw hold frames
+	switch opts.format {
+	case "gif", "raw":
+	case "png", "png16", "pfm", "exr", "npy", "csv":
+		if opts.frames > 0 {
+			return fmt.Errorf("noise: format %q holds a single image, use gif or raw for -frames", opts.format)
+		}
+	default:
+		return fmt.Errorf("noise: unknown format %q", opts.format)
+	}
+
+	src, err := sourceOf(opts)
+	if err != nil {
+		return err
+	}
+
+	frames, err := render(src, opts)
+	if err != nil {
+		return err
+	}
+
+	// Write to stdout, or to the output file whose close error reports a failed flush
+	if opts.out == "-" {
+		return write(os.Stdout, frames, opts)
+	}
+
+	file, err := os.Create(opts.out)
+	if err != nil {
+		return err
+	}
+
+	if err := write(file, frames, opts); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// write encodes the rendered frames into a buffered writer
+func write(w io.Writer, frames [][]float32, opts options) error {
+	buf := bufio.NewWriter(w)
+	if err := encode(buf, frames, opts); err != nil {
+		return err
+	}
+	return buf.Flush()
+}
+
+// formatOf infers the output format from a file name
+func formatOf(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".gif":
+		return "gif"
+	case ".raw", ".r32", ".f32", ".bin":
+		return "raw"
+	case ".pfm":
+		return "pfm"
+	case ".exr":
+		return "exr"
+	case ".npy":
+		return "npy"
+	case ".csv":
+		return "csv"
+	default:
+		return "png"
+	}
+}
+
+// sourceOf builds the noise source described by the options
+func sourceOf(opts options) (noise.Source, error) {
+	if opts.preset != "" {
+		data, err := os.ReadFile(opts.preset)
+		if err != nil {
+			return nil, err
+		}
+		return noise.UnmarshalSource(data)
+	}
+
+	seed := uint32(opts.seed)
+	fractal := func(basis noise.Source) noise.Source {
+		f := noise.NewFBMWith(basis)
+		f.Lacunarity = float32(opts.lacunarity)
+		f.Gain = float32(opts.gain)
+		f.Octaves = opts.octaves
+		return f
+	}
+
+	switch opts.algo {
+	case "simplex":
+		return noise.NewSimplex(seed), nil
+	case "fbm":
+		return fractal(noise.NewSimplex(seed)), nil
+	case "ridged":
+		return fractal(noise.Ridged(noise.NewSimplex(seed))), nil
+	case "value":
+		return fractal(noise.NewValue(seed)), nil
+	case "cellular":
+		return fractal(noise.NewCellular(seed)), nil
+	case "white":
+		return noise.SourceFunc(func(x, y, z float32) float32 {
+			return noise.White(seed, x, y, z)
+		}), nil
+	default:
+		return nil, fmt.Errorf("noise: unknown algorithm %q", opts.algo)
+	}
+}
+
+// render evaluates the source over every frame, returning one grid per frame
+func render(src noise.Source, opts options) ([][]float32, error) {
+	freq := float32(opts.freq)
+	if opts.frames <= 0 {
+		grid, err := noise.RenderTiles(context.Background(), opts.width, opts.height, 64, func(x, y int) float32 {
+			return src.Eval2(float32(x)*freq, float32(y)*freq)
+		}, 0)
+		return [][]float32{grid}, err
+	}
+
+	frames := make([][]float32, 0, opts.frames)
+	for f := 0; f < opts.frames; f++ {
+		z := float32(f) * freq
+		grid, err := noise.RenderTiles(context.Background(), opts.width, opts.height, 64, func(x, y int) float32 {
+			return src.Eval3(float32(x)*freq, float32(y)*freq, z)
+		}, 0)
+		if err != nil {
+			return nil, err
+		}
+		frames = append(frames, grid)
+	}
+	return frames, nil
+}
+
+// encode writes the rendered frames in the requested format
+func encode(w io.Writer, frames [][]float32, opts options) error {
+	switch opts.format {
+	case "raw":
+		for _, grid := range frames {
+			if err := noise.Export(w, noise.RawFloat32, grid, opts.width, opts.height); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "png16":
+		return noise.Export(w, noise.PNG16, frames[0], opts.width, opts.height)
+	case "pfm":
+		return noise.Export(w, noise.PFM, frames[0], opts.width, opts.height)
+	case "exr":
+		return noise.Export(w, noise.EXR, frames[0], opts.width, opts.height)
+	case "npy":
+		return noise.Export(w, noise.NPY, frames[0], opts.width, opts.height)
+	case "csv":
+		return noise.Export(w, noise.CSV, frames[0], opts.width, opts.height)
+	case "png":
+		return png.Encode(w, imageOf(frames[0], opts))
+	case "gif":
+		anim := &gif.GIF{}
+		for _, grid := range frames {
+			img := imageOf(grid, opts)
+			frame := image.NewPaletted(img.Bounds(), paletteOf(opts))
+			for y := 0; y < opts.height; y++ {
+				for x := 0; x < opts.width; x++ {
+					frame.Set(x, y, img.At(x, y))
+				}
+			}
+
+			anim.Image = append(anim.Image, frame)
+			anim.Delay = append(anim.Delay, 5)
+		}
+		return gif.EncodeAll(w, anim)
+	default:
+		return fmt.Errorf("noise: unknown format %q", opts.format)
+	}
+}
+
+// imageOf converts a grid of values in [-1, 1] to an image
+func imageOf(grid []float32, opts options) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, opts.width, opts.height))
+	for y := 0; y < opts.height; y++ {
+		for x := 0; x < opts.width; x++ {
+			v := min(max((grid[y*opts.width+x]+1)/2, 0), 1)
+			img.Set(x, y, colorOf(v, opts.color))
+		}
+	}
+	return img
+}
+
+// colorOf maps a value in [0, 1] to a greyscale or terrain color
+func colorOf(v float32, colored bool) color.Color {
+	if !colored {
+		return color.Gray{Y: uint8(v * 255)}
+	}
+	return terrain.At(v*2 - 1)
+}
+
+// paletteOf returns the color palette used for gif frames
+func paletteOf(opts options) color.Palette {
+	if opts.color {
+		return terrain.Palette(256)
+	}
+
+	p := make(color.Palette, 256)
+	for i := range p {
+		p[i] = color.Gray{Y: uint8(i)}
+	}
+	return p
+}
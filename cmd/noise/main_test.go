@@ -0,0 +1,64 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		format string
+		frames int
+		size   int64 // Expected size of the output, zero if compressed
+		err    bool
+	}{
+		{format: "png"},
+		{format: "png16"},
+		{format: "gif"},
+		{format: "gif", frames: 3},
+		{format: "raw", size: 8 * 8 * 4},
+		{format: "raw", frames: 3, size: 3 * 8 * 8 * 4},
+		{format: "pfm"},
+		{format: "exr"},
+		{format: "npy"},
+		{format: "csv"},
+		{format: "png", frames: 3, err: true},
+		{format: "png16", frames: 3, err: true},
+		{format: "pfm", frames: 3, err: true},
+		{format: "exr", frames: 3, err: true},
+		{format: "npy", frames: 3, err: true},
+		{format: "csv", frames: 3, err: true},
+		{format: "bmp", err: true},
+	}
+
+	for _, tc := range tests {
+		name := tc.format + "/" + strconv.Itoa(tc.frames)
+		out := filepath.Join(t.TempDir(), "out")
+		err := run([]string{
+			"-size", "8",
+			"-frames", strconv.Itoa(tc.frames),
+			"-format", tc.format,
+			"-out", out,
+		}, io.Discard)
+
+		// Rejected requests must not leave a partial file behind
+		if tc.err {
+			assert.Error(t, err, name)
+			assert.NoFileExists(t, out, name)
+			continue
+		}
+
+		assert.NoError(t, err, name)
+		info, err := os.Stat(out)
+		assert.NoError(t, err, name)
+		assert.Greater(t, info.Size(), int64(0), name)
+		if tc.size > 0 {
+			assert.Equal(t, tc.size, info.Size(), name)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+//go:build js && wasm
+
+// Command wasm exposes the generators of the noise package to JavaScript, so that
+// web tools can preview exactly the same deterministic output as a Go server. It
+// builds with the standard toolchain or with TinyGo for a smaller binary:
+//
+//	GOOS=js GOARCH=wasm go build -o noise.wasm ./cmd/wasm
+//	tinygo build -o noise.wasm -target wasm ./cmd/wasm
+//
+// Once instantiated with the wasm_exec.js of the matching toolchain, it registers a
+// global "noise" object:
+//
+//	noise.simplex2(seed, x, y)                           // number in [-1, 1]
+//	noise.simplex3(seed, x, y, z)                        // number in [-1, 1]
+//	noise.fbm2(seed, x, y, lacunarity, gain, octaves)    // number in [-1, 1]
+//	noise.fbm3(seed, x, y, z, lacunarity, gain, octaves) // number in [-1, 1]
+//	noise.white2(seed, x, y)                             // number in [-1, 1]
+//	noise.fill2(seed, w, h, x, y, step)                  // Float32Array of w*h simplex values
+//	noise.sparse2(seed, w, h, gap)                       // Int32Array of x, y pairs
+package main
+
+import (
+	"syscall/js"
+	"unsafe"
+
+	"github.com/kelindar/noise"
+)
+
+// Generators are cached per seed, since building their tables costs more than an
+// evaluation. JavaScript is single-threaded, so the caches need no locking.
+var (
+	simplex = map[uint32]*noise.Simplex{}
+	fbm     = map[uint32]*noise.FBM{}
+)
+
+func main() {
+	js.Global().Set("noise", js.ValueOf(map[string]any{
+		"simplex2": js.FuncOf(func(_ js.Value, args []js.Value) any {
+			return simplexOf(args[0]).Eval2(f32(args[1]), f32(args[2]))
+		}),
+		"simplex3": js.FuncOf(func(_ js.Value, args []js.Value) any {
+			return simplexOf(args[0]).Eval3(f32(args[1]), f32(args[2]), f32(args[3]))
+		}),
+		"fbm2": js.FuncOf(func(_ js.Value, args []js.Value) any {
+			return fbmOf(args[0]).Eval(f32(args[3]), f32(args[4]), args[5].Int(), f32(args[1]), f32(args[2]))
+		}),
+		"fbm3": js.FuncOf(func(_ js.Value, args []js.Value) any {
+			return fbmOf(args[0]).Eval(f32(args[4]), f32(args[5]), args[6].Int(), f32(args[1]), f32(args[2]), f32(args[3]))
+		}),
+		"white2": js.FuncOf(func(_ js.Value, args []js.Value) any {
+			return noise.White(seedOf(args[0]), f32(args[1]), f32(args[2]))
+		}),
+		"fill2": js.FuncOf(func(_ js.Value, args []js.Value) any {
+			w, h := args[1].Int(), args[2].Int()
+			grid := make([]float32, w*h)
+			simplexOf(args[0]).Fill2D(grid, w, h, f32(args[3]), f32(args[4]), f32(args[5]))
+			return float32Array(grid)
+		}),
+		"sparse2": js.FuncOf(func(_ js.Value, args []js.Value) any {
+			var points []int32
+			for p := range noise.Sparse2(seedOf(args[0]), args[1].Int(), args[2].Int(), args[3].Float()) {
+				points = append(points, int32(p[0]), int32(p[1]))
+			}
+			return int32Array(points)
+		}),
+	}))
+
+	// Keep the module alive so that its functions remain callable
+	select {}
+}
+
+// seedOf converts a JavaScript number to a seed
+func seedOf(v js.Value) uint32 {
+	return uint32(v.Int())
+}
+
+// f32 converts a JavaScript number to a float32
+func f32(v js.Value) float32 {
+	return float32(v.Float())
+}
+
+// simplexOf returns the cached simplex generator of a seed
+func simplexOf(v js.Value) *noise.Simplex {
+	seed := seedOf(v)
+	if s, ok := simplex[seed]; ok {
+		return s
+	}
+
+	s := noise.NewSimplex(seed)
+	simplex[seed] = s
+	return s
+}
+
+// fbmOf returns the cached fBM generator of a seed
+func fbmOf(v js.Value) *noise.FBM {
+	seed := seedOf(v)
+	if f, ok := fbm[seed]; ok {
+		return f
+	}
+
+	f := noise.NewFBM(seed)
+	fbm[seed] = f
+	return f
+}
+
+// float32Array copies values into a new JavaScript Float32Array
+func float32Array(values []float32) js.Value {
+	bytes := js.Global().Get("Uint8Array").New(4 * len(values))
+	if len(values) > 0 {
+		js.CopyBytesToJS(bytes, unsafe.Slice((*byte)(unsafe.Pointer(&values[0])), 4*len(values)))
+	}
+	return js.Global().Get("Float32Array").New(bytes.Get("buffer"))
+}
+
+// int32Array copies values into a new JavaScript Int32Array
+func int32Array(values []int32) js.Value {
+	bytes := js.Global().Get("Uint8Array").New(4 * len(values))
+	if len(values) > 0 {
+		js.CopyBytesToJS(bytes, unsafe.Slice((*byte)(unsafe.Pointer(&values[0])), 4*len(values)))
+	}
+	return js.Global().Get("Int32Array").New(bytes.Get("buffer"))
+}
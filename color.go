@@ -0,0 +1,125 @@
+package noise
+
+import (
+	"image/color"
+	"math"
+)
+
+// ---------------------------------- Colors ----------------------------------
+
+// ColorJitter returns a deterministic variation of the base color, shifting its hue
+// by up to ±hueVar degrees and its saturation and lightness by up to ±satVar and
+// ±lumVar, both in [0, 1]. The alpha of the base color is preserved, which makes it
+// easy to tint instances of the same object differently.
+//
+// Example:
+//
+//	leaf := color.RGBA{60, 140, 40, 255}
+//	tint := ColorJitter(12345, treeID, leaf, 15, 0.1, 0.05)
+func ColorJitter(seed uint32, key uint64, base color.RGBA, hueVar, satVar, lumVar float32) color.RGBA {
+	if base.A == 0 {
+		return base
+	}
+
+	// Colors are alpha-premultiplied, so work on the straight color
+	a := float64(base.A) / 255
+	r := float64(base.R) / 255 / a
+	g := float64(base.G) / 255 / a
+	b := float64(base.B) / 255 / a
+
+	h, s, l := rgbToHSL(r, g, b)
+	h += float64(float64(hueVar) * (2*Float64(seed, key) - 1))
+	s += float64(float64(satVar) * (2*Float64(seed^1, key) - 1))
+	l += float64(float64(lumVar) * (2*Float64(seed^2, key) - 1))
+
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+
+	r, g, b = hslToRGB(h, min(max(s, 0), 1), min(max(l, 0), 1))
+	return color.RGBA{
+		R: uint8(math.Round(r * a * 255)),
+		G: uint8(math.Round(g * a * 255)),
+		B: uint8(math.Round(b * a * 255)),
+		A: base.A,
+	}
+}
+
+// SamplePalette returns a deterministic color along the gradient through the
+// palette colors, at a uniformly distributed position. Use Pick instead to choose
+// one of the palette colors without blending.
+//
+// Example:
+//
+//	sunset := []color.RGBA{{255, 94, 58, 255}, {255, 149, 0, 255}, {255, 219, 76, 255}}
+//	sky := SamplePalette(12345, cloudID, sunset)
+func SamplePalette(seed uint32, key uint64, palette []color.RGBA) color.RGBA {
+	switch len(palette) {
+	case 0:
+		panic("invalid argument to SamplePalette")
+	case 1:
+		return palette[0]
+	}
+
+	t := Float64(seed, key) * float64(len(palette)-1)
+	i := int(t)
+	f := t - float64(i)
+	mix := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a) + float64(f*(float64(b)-float64(a)))))
+	}
+
+	a, b := palette[i], palette[i+1]
+	return color.RGBA{mix(a.R, b.R), mix(a.G, b.G), mix(a.B, b.B), mix(a.A, b.A)}
+}
+
+// rgbToHSL converts a color with components in [0, 1] to hue in degrees,
+// saturation and lightness
+func rgbToHSL(r, g, b float64) (h, s, l float64) {
+	hi, lo := max(r, g, b), min(r, g, b)
+	l = (hi + lo) / 2
+	if hi == lo {
+		return 0, 0, l
+	}
+
+	d := hi - lo
+	if l > 0.5 {
+		s = d / (2 - hi - lo)
+	} else {
+		s = d / (hi + lo)
+	}
+
+	switch hi {
+	case r:
+		h = math.Mod((g-b)/d+6, 6)
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	return h * 60, s, l
+}
+
+// hslToRGB converts a hue in degrees, saturation and lightness to a color with
+// components in [0, 1]
+func hslToRGB(h, s, l float64) (r, g, b float64) {
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - c/2
+
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	return r + m, g + m, b + m
+}
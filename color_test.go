@@ -0,0 +1,54 @@
+package noise
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColorJitter(t *testing.T) {
+	base := color.RGBA{60, 140, 40, 255}
+	assert.Equal(t, base, ColorJitter(42, 1, base, 0, 0, 0))
+	assert.Equal(t, ColorJitter(42, 7, base, 20, 0.1, 0.1), ColorJitter(42, 7, base, 20, 0.1, 0.1))
+
+	var changed int
+	for key := uint64(0); key < 200; key++ {
+		c := ColorJitter(42, key, base, 10, 0.05, 0.05)
+		assert.Equal(t, uint8(255), c.A)
+		assert.Greater(t, c.G, c.R) // still green
+		if c != base {
+			changed++
+		}
+	}
+	assert.Greater(t, changed, 150)
+
+	// Alpha is preserved and colors stay premultiplied
+	half := color.RGBA{50, 25, 0, 128}
+	c := ColorJitter(42, 3, half, 5, 0.1, 0.1)
+	assert.Equal(t, uint8(128), c.A)
+	assert.True(t, c.R <= 128 && c.G <= 128 && c.B <= 128)
+	assert.Equal(t, color.RGBA{}, ColorJitter(42, 3, color.RGBA{}, 5, 0.1, 0.1))
+}
+
+func TestHSL(t *testing.T) {
+	for _, c := range [][3]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {0.2, 0.5, 0.7}, {0.9, 0.9, 0.1}, {0.5, 0.5, 0.5}} {
+		h, s, l := rgbToHSL(c[0], c[1], c[2])
+		r, g, b := hslToRGB(h, s, l)
+		assert.InDelta(t, c[0], r, 1e-9)
+		assert.InDelta(t, c[1], g, 1e-9)
+		assert.InDelta(t, c[2], b, 1e-9)
+	}
+}
+
+func TestSamplePalette(t *testing.T) {
+	palette := []color.RGBA{{0, 0, 0, 255}, {200, 100, 0, 255}}
+	for key := uint64(0); key < 100; key++ {
+		c := SamplePalette(42, key, palette)
+		assert.InDelta(t, float64(c.R)/2, c.G, 1)
+		assert.Equal(t, uint8(255), c.A)
+	}
+
+	assert.Equal(t, palette[0], SamplePalette(42, 1, palette[:1]))
+	assert.Panics(t, func() { SamplePalette(42, 1, nil) })
+}
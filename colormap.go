@@ -0,0 +1,205 @@
+package noise
+
+import (
+	"image/color"
+	"math"
+	"sort"
+)
+
+// ---------------------------------- Colormaps ----------------------------------
+
+// colormapSize is the number of precomputed entries of a colormap
+const colormapSize = 1024
+
+// ColorStop is a color at a given value of a colormap
+type ColorStop struct {
+	Value float32    // Noise value in [-1, 1]
+	Color color.RGBA // Color at this value
+}
+
+// Colormap maps noise values in [-1, 1] to colors, interpolating between its stops
+// in the CIELAB color space, where equal steps look like equal changes in color.
+// It is immutable once constructed, so it is safe for concurrent use.
+type Colormap struct {
+	lut [colormapSize]color.RGBA
+}
+
+// NewColormap creates a colormap through the stops, which are sorted by value.
+// Values outside of the first and last stops take their colors.
+//
+// Example:
+//
+//	lava := NewColormap(
+//	    ColorStop{-1, color.RGBA{20, 0, 0, 255}},
+//	    ColorStop{0.5, color.RGBA{230, 60, 0, 255}},
+//	    ColorStop{1, color.RGBA{255, 240, 120, 255}},
+//	)
+//	img := Image(src, bounds, lava.Color)
+func NewColormap(stops ...ColorStop) *Colormap {
+	if len(stops) == 0 {
+		panic("invalid argument to NewColormap")
+	}
+
+	stops = append([]ColorStop(nil), stops...)
+	sort.SliceStable(stops, func(i, j int) bool {
+		return stops[i].Value < stops[j].Value
+	})
+
+	lab := make([][4]float64, len(stops))
+	for i, s := range stops {
+		l, a, b := rgbToLab(s.Color)
+		lab[i] = [4]float64{l, a, b, float64(s.Color.A)}
+	}
+
+	c := &Colormap{}
+	for i := range c.lut {
+		v := float32(i)/(colormapSize-1)*2 - 1
+		k := sort.Search(len(stops), func(k int) bool { return stops[k].Value >= v })
+		switch {
+		case k == 0:
+			c.lut[i] = stops[0].Color
+		case k == len(stops):
+			c.lut[i] = stops[k-1].Color
+		default:
+			t := float64((v - stops[k-1].Value) / (stops[k].Value - stops[k-1].Value))
+			var mix [4]float64
+			for j := range mix {
+				mix[j] = lab[k-1][j] + (lab[k][j]-lab[k-1][j])*t
+			}
+			c.lut[i] = labToRGB(mix[0], mix[1], mix[2], mix[3])
+		}
+	}
+	return c
+}
+
+// At returns the color of a noise value in [-1, 1], clamping values outside of it
+func (c *Colormap) At(v float32) color.RGBA {
+	i := int((min(max(v, -1), 1)+1)/2*(colormapSize-1) + 0.5)
+	return c.lut[i]
+}
+
+// Color returns the color of a noise value, for use as a palette function
+func (c *Colormap) Color(v float32) color.Color {
+	return c.At(v)
+}
+
+// Palette returns n colors evenly spread over [-1, 1], for paletted images
+func (c *Colormap) Palette(n int) color.Palette {
+	p := make(color.Palette, n)
+	for i := range p {
+		p[i] = c.At(float32(i)/float32(max(n-1, 1))*2 - 1)
+	}
+	return p
+}
+
+// Viridis returns the perceptually uniform viridis colormap, from dark purple to
+// yellow, which stays readable for color-blind viewers and in grayscale.
+func Viridis() *Colormap {
+	return evenColormap(
+		color.RGBA{68, 1, 84, 255}, color.RGBA{71, 44, 122, 255}, color.RGBA{59, 82, 139, 255},
+		color.RGBA{44, 114, 142, 255}, color.RGBA{33, 145, 140, 255}, color.RGBA{40, 174, 128, 255},
+		color.RGBA{94, 201, 98, 255}, color.RGBA{173, 220, 48, 255}, color.RGBA{253, 231, 37, 255},
+	)
+}
+
+// Turbo returns the turbo rainbow colormap, from dark blue through green to dark red,
+// which shows fine detail at the cost of perceptual uniformity.
+func Turbo() *Colormap {
+	return evenColormap(
+		color.RGBA{35, 23, 27, 255}, color.RGBA{73, 62, 175, 255}, color.RGBA{68, 106, 238, 255},
+		color.RGBA{50, 149, 247, 255}, color.RGBA{38, 189, 225, 255}, color.RGBA{41, 221, 187, 255},
+		color.RGBA{64, 243, 146, 255}, color.RGBA{102, 253, 109, 255}, color.RGBA{150, 250, 80, 255},
+		color.RGBA{198, 235, 59, 255}, color.RGBA{238, 208, 45, 255}, color.RGBA{255, 171, 36, 255},
+		color.RGBA{255, 128, 29, 255}, color.RGBA{238, 84, 21, 255}, color.RGBA{201, 45, 12, 255},
+		color.RGBA{161, 18, 2, 255}, color.RGBA{144, 13, 0, 255},
+	)
+}
+
+// Terrain returns a colormap for heightmaps, with water below 0 followed by sand,
+// grass, rock and snow towards 1.
+func Terrain() *Colormap {
+	return NewColormap(
+		ColorStop{-1, color.RGBA{41, 128, 185, 255}},
+		ColorStop{0, color.RGBA{52, 152, 219, 255}},
+		ColorStop{0.01, color.RGBA{255, 234, 167, 255}},
+		ColorStop{0.15, color.RGBA{253, 203, 110, 255}},
+		ColorStop{0.3, color.RGBA{248, 194, 145, 255}},
+		ColorStop{0.45, color.RGBA{184, 233, 148, 255}},
+		ColorStop{0.6, color.RGBA{120, 224, 143, 255}},
+		ColorStop{0.8, color.RGBA{189, 195, 199, 255}},
+		ColorStop{1, color.RGBA{236, 240, 241, 255}},
+	)
+}
+
+// evenColormap creates a colormap with its colors evenly spread over [-1, 1]
+func evenColormap(colors ...color.RGBA) *Colormap {
+	stops := make([]ColorStop, len(colors))
+	for i, c := range colors {
+		stops[i] = ColorStop{Value: float32(i)/float32(len(colors)-1)*2 - 1, Color: c}
+	}
+	return NewColormap(stops...)
+}
+
+// ---------------------------------- CIELAB ----------------------------------
+
+// D65 white point of the XYZ color space
+const whiteX, whiteY, whiteZ = 0.95047, 1.0, 1.08883
+
+// rgbToLab converts an sRGB color to CIELAB
+func rgbToLab(c color.RGBA) (l, a, b float64) {
+	r, g, bl := toLinear(c.R), toLinear(c.G), toLinear(c.B)
+	x := (0.4124564*r + 0.3575761*g + 0.1804375*bl) / whiteX
+	y := (0.2126729*r + 0.7151522*g + 0.0721750*bl) / whiteY
+	z := (0.0193339*r + 0.1191920*g + 0.9503041*bl) / whiteZ
+
+	fx, fy, fz := labF(x), labF(y), labF(z)
+	return 116*fy - 16, 500 * (fx - fy), 200 * (fy - fz)
+}
+
+// labToRGB converts a CIELAB color to sRGB, clamping colors outside of the gamut
+func labToRGB(l, a, b, alpha float64) color.RGBA {
+	fy := (l + 16) / 116
+	x := labInv(fy+a/500) * whiteX
+	y := labInv(fy) * whiteY
+	z := labInv(fy-b/200) * whiteZ
+
+	r := 3.2404542*x - 1.5371385*y - 0.4985314*z
+	g := -0.9692660*x + 1.8760108*y + 0.0415560*z
+	bl := 0.0556434*x - 0.2040259*y + 1.0572252*z
+	return color.RGBA{fromLinear(r), fromLinear(g), fromLinear(bl), uint8(min(max(alpha+0.5, 0), 255))}
+}
+
+// labF is the non-linear compression of the CIELAB transform
+func labF(t float64) float64 {
+	if t > 216.0/24389 {
+		return math.Cbrt(t)
+	}
+	return (24389.0/27*t + 16) / 116
+}
+
+// labInv is the inverse of labF
+func labInv(t float64) float64 {
+	if t3 := t * t * t; t3 > 216.0/24389 {
+		return t3
+	}
+	return (116*t - 16) * 27 / 24389
+}
+
+// toLinear converts an sRGB channel to linear light
+func toLinear(c uint8) float64 {
+	v := float64(c) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// fromLinear converts linear light to an sRGB channel
+func fromLinear(v float64) uint8 {
+	if v <= 0.0031308 {
+		v *= 12.92
+	} else {
+		v = 1.055*math.Pow(v, 1/2.4) - 0.055
+	}
+	return uint8(min(max(v*255+0.5, 0), 255))
+}
@@ -0,0 +1,63 @@
+package noise
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColormap(t *testing.T) {
+	red, blue := color.RGBA{255, 0, 0, 255}, color.RGBA{0, 0, 255, 255}
+	c := NewColormap(ColorStop{1, blue}, ColorStop{-1, red})
+	assert.Equal(t, red, c.At(-1))
+	assert.Equal(t, blue, c.At(1))
+	assert.Equal(t, red, c.At(-5))
+	assert.Equal(t, blue, c.At(5))
+	assert.Equal(t, c.At(0.3), c.Color(0.3))
+
+	// Interpolating in CIELAB keeps the midpoint saturated brighter than an sRGB blend
+	mid := c.At(0)
+	assert.Greater(t, mid.R, uint8(128))
+	assert.Greater(t, mid.B, uint8(128))
+	assert.Less(t, mid.G, uint8(50))
+
+	single := NewColormap(ColorStop{0, red})
+	assert.Equal(t, red, single.At(0.7))
+	assert.Panics(t, func() { NewColormap() })
+}
+
+func TestColormapLab(t *testing.T) {
+	for _, c := range []color.RGBA{
+		{0, 0, 0, 255}, {255, 255, 255, 255}, {41, 128, 185, 255}, {253, 231, 37, 128},
+	} {
+		l, a, b := rgbToLab(c)
+		assert.Equal(t, c, labToRGB(l, a, b, float64(c.A)))
+	}
+
+	l, a, b := rgbToLab(color.RGBA{255, 255, 255, 255})
+	assert.InDelta(t, 100, l, 1e-3)
+	assert.InDelta(t, 0, a, 1e-3)
+	assert.InDelta(t, 0, b, 1e-3)
+}
+
+func TestBuiltinColormaps(t *testing.T) {
+	assert.Equal(t, color.RGBA{68, 1, 84, 255}, Viridis().At(-1))
+	assert.Equal(t, color.RGBA{253, 231, 37, 255}, Viridis().At(1))
+	assert.Equal(t, color.RGBA{144, 13, 0, 255}, Turbo().At(1))
+	assert.Equal(t, color.RGBA{41, 128, 185, 255}, Terrain().At(-1))
+	assert.Equal(t, color.RGBA{236, 240, 241, 255}, Terrain().At(1))
+
+	// Viridis gets lighter all the way
+	var last float64
+	for i := 0; i <= 20; i++ {
+		l, _, _ := rgbToLab(Viridis().At(float32(i)/10 - 1))
+		assert.Greater(t, l, last)
+		last = l
+	}
+
+	p := Turbo().Palette(16)
+	assert.Len(t, p, 16)
+	assert.Equal(t, Turbo().At(-1), p[0])
+	assert.Equal(t, Turbo().At(1), p[15])
+}
@@ -0,0 +1,40 @@
+package noise
+
+import "sync/atomic"
+
+// ---------------------------------- Default Generator ----------------------------------
+
+// defaultSimplex is the generator behind the package-level Noise2 and Noise3
+var defaultSimplex = func() *atomic.Pointer[Simplex] {
+	p := new(atomic.Pointer[Simplex])
+	p.Store(NewSimplex(0))
+	return p
+}()
+
+// Default returns the simplex generator used by Noise2 and Noise3, seeded with 0
+// unless changed by SetDefaultSeed
+func Default() *Simplex {
+	return defaultSimplex.Load()
+}
+
+// SetDefaultSeed replaces the default generator with one seeded with the given
+// seed. It is safe to call concurrently with Noise2 and Noise3, but evaluations
+// that race with it may see either generator, so it is best called at startup.
+//
+// Example:
+//
+//	noise.SetDefaultSeed(world.Seed)
+//	h := noise.Noise2(x, y)
+func SetDefaultSeed(seed uint32) {
+	defaultSimplex.Store(NewSimplex(seed))
+}
+
+// Noise2 evaluates 2D simplex noise with the default generator
+func Noise2(x, y float32) float32 {
+	return defaultSimplex.Load().noise2D(x, y)
+}
+
+// Noise3 evaluates 3D simplex noise with the default generator
+func Noise3(x, y, z float32) float32 {
+	return defaultSimplex.Load().noise3D(x, y, z)
+}
@@ -0,0 +1,25 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefault(t *testing.T) {
+	defer SetDefaultSeed(0)
+	assert.Equal(t, NewSimplex(0).Eval2(1.5, 2.5), Noise2(1.5, 2.5))
+	assert.Equal(t, NewSimplex(0).Eval3(1.5, 2.5, 3.5), Noise3(1.5, 2.5, 3.5))
+
+	SetDefaultSeed(42)
+	assert.Equal(t, NewSimplex(42).perm, Default().perm)
+	assert.Equal(t, NewSimplex(42).Eval2(1.5, 2.5), Noise2(1.5, 2.5))
+	assert.Equal(t, NewSimplex(42).Eval3(1.5, 2.5, 3.5), Noise3(1.5, 2.5, 3.5))
+}
+
+func BenchmarkNoise2(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Noise2(float32(i), 0.5)
+	}
+}
@@ -0,0 +1,143 @@
+package noise
+
+// ---------------------------------- Midpoint Displacement ----------------------------------
+
+// DiamondSquare generates a size × size heightmap in row-major order with the
+// diamond-square algorithm, which repeatedly sets the midpoints of squares and
+// diamonds to the average of their corners plus a random offset. The offsets shrink
+// by the roughness at every level, 0.5 being a good starting point, and the result
+// lies in [-1, 1] with the characteristic creases along the grid axes.
+//
+// Without wrapping, size must be a power of two plus one, such as 513, and the
+// corners are random. With wrapping, size must be a power of two, such as 512, and
+// the heightmap tiles seamlessly since neighbours are read across the edges.
+// Complexity: O(n) for n = size².
+//
+// Example:
+//
+//	heights := DiamondSquare(12345, 513, 0.5, false)
+func DiamondSquare(seed uint32, size int, roughness float32, wrap bool) []float32 {
+	step := displaceStep(size, wrap)
+	if step < 0 {
+		panic("invalid argument to DiamondSquare")
+	}
+
+	out := make([]float32, size*size)
+	at := func(x, y int) (float32, bool) {
+		if wrap {
+			return out[(y&(size-1))*size+x&(size-1)], true
+		}
+		if x < 0 || y < 0 || x >= size || y >= size {
+			return 0, false
+		}
+		return out[y*size+x], true
+	}
+
+	offset := func(x, y int, amp float32) float32 {
+		return float32(amp * (Float32(seed, uint64(y*size+x))*2 - 1))
+	}
+
+	// Seed the corners, or the single corner shared by all four when wrapping
+	amp, total := float32(1), float32(1)
+	for y := 0; y < size; y += max(step, 1) {
+		for x := 0; x < size; x += max(step, 1) {
+			out[y*size+x] = offset(x, y, amp)
+		}
+	}
+
+	for ; step > 1; step /= 2 {
+		half := step / 2
+		amp *= roughness
+		total += amp
+
+		// Diamond step, the center of every square
+		for y := half; y < size; y += step {
+			for x := half; x < size; x += step {
+				a, _ := at(x-half, y-half)
+				b, _ := at(x+half, y-half)
+				c, _ := at(x-half, y+half)
+				d, _ := at(x+half, y+half)
+				out[y*size+x] = (a+b+c+d)/4 + offset(x, y, amp)
+			}
+		}
+
+		// Square step, the center of every diamond, which may lie on the border
+		for y := 0; y < size; y += half {
+			for x := (y/half + 1) % 2 * half; x < size; x += step {
+				var sum float32
+				var count int
+				for _, p := range [4][2]int{{x - half, y}, {x + half, y}, {x, y - half}, {x, y + half}} {
+					if v, ok := at(p[0], p[1]); ok {
+						sum += v
+						count++
+					}
+				}
+				out[y*size+x] = sum/float32(count) + offset(x, y, amp)
+			}
+		}
+	}
+
+	for i := range out {
+		out[i] /= total
+	}
+	return out
+}
+
+// MidpointDisplacement generates a 1D heightfield of the given size with midpoint
+// displacement, which repeatedly sets the midpoint of every segment to the average
+// of its ends plus a random offset shrinking by the roughness at every level. The
+// result lies in [-1, 1]. Without wrapping, size must be a power of two plus one;
+// with wrapping, it must be a power of two and the last point joins the first.
+// Complexity: O(n).
+//
+// Example:
+//
+//	skyline := MidpointDisplacement(12345, 1025, 0.5, false)
+func MidpointDisplacement(seed uint32, size int, roughness float32, wrap bool) []float32 {
+	step := displaceStep(size, wrap)
+	if step < 0 {
+		panic("invalid argument to MidpointDisplacement")
+	}
+
+	out := make([]float32, size)
+	offset := func(x int, amp float32) float32 {
+		return float32(amp * (Float32(seed, uint64(x))*2 - 1))
+	}
+
+	amp, total := float32(1), float32(1)
+	for x := 0; x < size; x += max(step, 1) {
+		out[x] = offset(x, amp)
+	}
+
+	for ; step > 1; step /= 2 {
+		half := step / 2
+		amp *= roughness
+		total += amp
+		for x := half; x < size; x += step {
+			out[x] = (out[x-half]+out[(x+half)%len(out)])/2 + offset(x, amp)
+		}
+	}
+
+	for i := range out {
+		out[i] /= total
+	}
+	return out
+}
+
+// displaceStep returns the initial step between the corners of a displacement grid,
+// or -1 if the size is invalid
+func displaceStep(size int, wrap bool) int {
+	n := size
+	if !wrap {
+		n = size - 1
+	}
+
+	switch {
+	case size <= 0 || n < 0 || n&(n-1) != 0:
+		return -1
+	case n == 0:
+		return 0
+	default:
+		return n
+	}
+}
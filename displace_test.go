@@ -0,0 +1,68 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiamondSquare(t *testing.T) {
+	for _, tc := range []struct {
+		size int
+		wrap bool
+	}{{129, false}, {128, true}} {
+		out := DiamondSquare(1, tc.size, 0.5, tc.wrap)
+		assert.Len(t, out, tc.size*tc.size)
+		assert.Equal(t, out, DiamondSquare(1, tc.size, 0.5, tc.wrap))
+		assert.NotEqual(t, out, DiamondSquare(2, tc.size, 0.5, tc.wrap))
+		for _, v := range out {
+			assert.True(t, v >= -1 && v <= 1)
+		}
+	}
+
+	assert.Len(t, DiamondSquare(1, 1, 0.5, false), 1)
+	assert.Len(t, DiamondSquare(1, 1, 0.5, true), 1)
+	assert.Panics(t, func() { DiamondSquare(1, 128, 0.5, false) })
+	assert.Panics(t, func() { DiamondSquare(1, 129, 0.5, true) })
+	assert.Panics(t, func() { DiamondSquare(1, 0, 0.5, true) })
+}
+
+func TestDiamondSquareWrap(t *testing.T) {
+	const size = 128
+	out := DiamondSquare(1, size, 0.6, true)
+
+	// Across the seam, neighbours differ about as much as inside the map
+	var seam, inner float32
+	for i := 0; i < size; i++ {
+		seam += abs(out[i*size+size-1]-out[i*size]) + abs(out[(size-1)*size+i]-out[i])
+		inner += abs(out[i*size+size/2-1]-out[i*size+size/2]) + abs(out[(size/2-1)*size+i]-out[size/2*size+i])
+	}
+	assert.Less(t, seam, inner*2)
+
+	// Without roughness, there is nothing but the single corner
+	flat := DiamondSquare(1, size, 0, true)
+	for _, v := range flat {
+		assert.Equal(t, flat[0], v)
+	}
+}
+
+func TestMidpointDisplacement(t *testing.T) {
+	out := MidpointDisplacement(1, 1025, 0.5, false)
+	assert.Len(t, out, 1025)
+	assert.Equal(t, out, MidpointDisplacement(1, 1025, 0.5, false))
+	for _, v := range out {
+		assert.True(t, v >= -1 && v <= 1)
+	}
+
+	// Without roughness, the line joins both ends
+	line := MidpointDisplacement(1, 9, 0, false)
+	for i, v := range line {
+		assert.InDelta(t, float64(line[0]+(line[8]-line[0])*float32(i)/8), float64(v), 1e-6)
+	}
+
+	wrapped := MidpointDisplacement(1, 1024, 0.5, true)
+	assert.Len(t, wrapped, 1024)
+	assert.Less(t, abs(wrapped[1023]-wrapped[0]), float32(0.1))
+	assert.Panics(t, func() { MidpointDisplacement(1, 1024, 0.5, false) })
+	assert.Panics(t, func() { MidpointDisplacement(1, -1, 0.5, true) })
+}
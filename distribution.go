@@ -0,0 +1,114 @@
+package noise
+
+import "math"
+
+// ---------------------------------- Distributions ----------------------------------
+
+// Exp64 returns a deterministic exponentially distributed float64 with the given
+// rate based on x, such as the time until the next event of a process occurring
+// rate times per unit of time on average.
+func Exp64(seed uint32, rate float64, x uint64) float64 {
+	if rate <= 0 {
+		panic("invalid argument to Exp64")
+	}
+
+	return -math.Log(1-Float64(seed, x)) / rate
+}
+
+// PoissonN returns a deterministic Poisson distributed count with the given mean
+// based on x, such as the number of events in a unit of time. Means above 30 use
+// a normal approximation, so the cost stays bounded.
+func PoissonN(seed uint32, mean float64, x uint64) int {
+	return poisson(seed, mean, x)
+}
+
+// Binomial returns a deterministic count of successes among n independent trials
+// with a probability p of success each, based on x. Large numbers of trials use a
+// normal approximation, so the cost stays bounded.
+func Binomial(seed uint32, n int, p float64, x uint64) int {
+	switch {
+	case n < 0 || p < 0 || p > 1:
+		panic("invalid argument to Binomial")
+	case p > 0.5:
+		return n - Binomial(seed, n, 1-p, x)
+	case n == 0 || p == 0:
+		return 0
+	}
+
+	mean := float64(n) * p
+	if mean > 30 {
+		sd := math.Sqrt(float64(mean * (1 - p)))
+		k := math.Round(mean + float64(sd*Norm64(seed, x)))
+		return int(min(max(k, 0), float64(n)))
+	}
+
+	// Invert the CDF, walking up from zero successes
+	u := Float64(seed, x)
+	q := p / (1 - p)
+	pk := math.Pow(1-p, float64(n))
+	cdf := pk
+	k := 0
+	for u > cdf && k < n {
+		pk *= float64(n-k) / float64(k+1) * q
+		cdf += pk
+		k++
+	}
+	return k
+}
+
+// Geometric returns a deterministic number of failures before the first success
+// of independent trials with a probability p of success each, based on x.
+func Geometric(seed uint32, p float64, x uint64) int {
+	switch {
+	case p <= 0 || p > 1:
+		panic("invalid argument to Geometric")
+	case p == 1:
+		return 0
+	}
+
+	return int(math.Floor(math.Log(1-Float64(seed, x)) / math.Log(1-p)))
+}
+
+// NormIn returns a deterministic normally distributed float64 with the given mean
+// and standard deviation based on x
+func NormIn(seed uint32, mean, stddev float64, x uint64) float64 {
+	return mean + float64(stddev*Norm64(seed, x))
+}
+
+// NormIn32 returns a deterministic normally distributed float32 with the given
+// mean and standard deviation based on x
+func NormIn32(seed uint32, mean, stddev float32, x uint64) float32 {
+	return float32(NormIn(seed, float64(mean), float64(stddev), x))
+}
+
+// TruncNorm returns a deterministic normally distributed float64 with the given
+// mean and standard deviation, truncated to [lo, hi] based on x. Unlike clamping,
+// values do not pile up at the bounds since the distribution is renormalized.
+// Method: inverse transform sampling of the normal CDF restricted to [lo, hi].
+func TruncNorm(seed uint32, mean, stddev, lo, hi float64, x uint64) float64 {
+	switch {
+	case lo > hi || stddev < 0:
+		panic("invalid argument to TruncNorm")
+	case stddev == 0 || lo == hi:
+		return min(max(mean, lo), hi)
+	}
+
+	// Map a uniform value into the CDF range of the bounds, then invert it
+	cdf := func(v float64) float64 {
+		return 0.5 * math.Erfc(-(v-mean)/(stddev*math.Sqrt2))
+	}
+
+	a, b := cdf(lo), cdf(hi)
+	u := a + float64(Float64(seed, x)*(b-a))
+	v := mean + float64(stddev*math.Sqrt2*math.Erfinv(2*u-1))
+	if math.IsNaN(v) {
+		v = mean
+	}
+	return min(max(v, lo), hi)
+}
+
+// TruncNorm32 returns a deterministic normally distributed float32 with the given
+// mean and standard deviation, truncated to [lo, hi] based on x
+func TruncNorm32(seed uint32, mean, stddev, lo, hi float32, x uint64) float32 {
+	return float32(TruncNorm(seed, float64(mean), float64(stddev), float64(lo), float64(hi), x))
+}
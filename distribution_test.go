@@ -0,0 +1,111 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// moments returns the sample mean and variance of fn over n keys
+func moments(n int, fn func(x uint64) float64) (mean, variance float64) {
+	for i := 0; i < n; i++ {
+		mean += fn(uint64(i))
+	}
+	mean /= float64(n)
+
+	for i := 0; i < n; i++ {
+		d := fn(uint64(i)) - mean
+		variance += d * d
+	}
+	return mean, variance / float64(n)
+}
+
+func TestExp64(t *testing.T) {
+	mean, variance := moments(20000, func(x uint64) float64 {
+		v := Exp64(42, 2, x)
+		assert.GreaterOrEqual(t, v, 0.0)
+		return v
+	})
+
+	assert.InDelta(t, 0.5, mean, 0.02)
+	assert.InDelta(t, 0.25, variance, 0.02)
+	assert.Panics(t, func() { Exp64(42, 0, 1) })
+}
+
+func TestPoissonN(t *testing.T) {
+	for _, lambda := range []float64{0.5, 4, 100} {
+		mean, variance := moments(20000, func(x uint64) float64 {
+			return float64(PoissonN(42, lambda, x))
+		})
+
+		assert.InDelta(t, lambda, mean, lambda*0.03+0.02)
+		assert.InDelta(t, lambda, variance, lambda*0.06+0.03)
+	}
+	assert.Equal(t, 0, PoissonN(42, 0, 1))
+}
+
+func TestBinomial(t *testing.T) {
+	for _, tc := range []struct {
+		n int
+		p float64
+	}{{10, 0.3}, {20, 0.9}, {1000, 0.4}, {50, 0}, {50, 1}} {
+		mean, variance := moments(20000, func(x uint64) float64 {
+			k := Binomial(42, tc.n, tc.p, x)
+			assert.True(t, k >= 0 && k <= tc.n)
+			return float64(k)
+		})
+
+		np := float64(tc.n) * tc.p
+		assert.InDelta(t, np, mean, np*0.02+0.01)
+		assert.InDelta(t, np*(1-tc.p), variance, np*(1-tc.p)*0.06+0.01)
+	}
+
+	assert.Panics(t, func() { Binomial(42, -1, 0.5, 1) })
+	assert.Panics(t, func() { Binomial(42, 10, 1.5, 1) })
+}
+
+func TestGeometric(t *testing.T) {
+	mean, variance := moments(20000, func(x uint64) float64 {
+		return float64(Geometric(42, 0.25, x))
+	})
+
+	assert.InDelta(t, 3, mean, 0.1)
+	assert.InDelta(t, 12, variance, 0.8)
+	assert.Equal(t, 0, Geometric(42, 1, 7))
+	assert.Panics(t, func() { Geometric(42, 0, 1) })
+}
+
+func TestNormIn(t *testing.T) {
+	mean, variance := moments(20000, func(x uint64) float64 {
+		return NormIn(42, 10, 3, x)
+	})
+
+	assert.InDelta(t, 10, mean, 0.1)
+	assert.InDelta(t, 9, variance, 0.4)
+	assert.Equal(t, float32(NormIn(42, 1, 2, 5)), NormIn32(42, 1, 2, 5))
+}
+
+func TestTruncNorm(t *testing.T) {
+	mean, _ := moments(20000, func(x uint64) float64 {
+		v := TruncNorm(42, 0, 1, -0.5, 2, x)
+		assert.True(t, v >= -0.5 && v <= 2)
+		return v
+	})
+
+	// Mean of a standard normal truncated to [-0.5, 2] is about 0.446
+	assert.InDelta(t, 0.446, mean, 0.02)
+
+	// No pile up at the lower bound, where the density is about 0.54 per unit
+	var low int
+	for x := uint64(0); x < 20000; x++ {
+		if TruncNorm(42, 0, 1, -0.5, 2, x) < -0.4 {
+			low++
+		}
+	}
+	assert.InDelta(t, 0.054*20000, low, 120)
+
+	v := TruncNorm32(42, 5, 2, 4, 6, 1)
+	assert.True(t, v >= 4 && v <= 6)
+	assert.Equal(t, 3.0, TruncNorm(42, 5, 0, 1, 3, 1))
+	assert.Panics(t, func() { TruncNorm(42, 0, 1, 2, 1, 1) })
+}
@@ -0,0 +1,106 @@
+package noise
+
+import (
+	"math"
+
+	"github.com/kelindar/bitmap"
+)
+
+// ---------------------------------- Dithering ----------------------------------
+
+// Threshold returns the dithering threshold in [0, 1) of the pixel (x, y)
+type Threshold func(x, y int) float32
+
+// Bayer returns the n × n ordered dithering matrix in row-major order, where n is
+// a power of two. Its thresholds are evenly spread in [0, 1) and as far from their
+// neighbours as possible, which gives the cross-hatched look of retro rendering.
+func Bayer(n int) []float32 {
+	if n <= 0 || n&(n-1) != 0 {
+		panic("invalid argument to Bayer")
+	}
+
+	// Interleave the bits of x^y and y, reversed, to get the rank of each cell
+	bits := 0
+	for 1<<bits < n {
+		bits++
+	}
+
+	out := make([]float32, n*n)
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			rank, xy := 0, x^y
+			for b := 0; b < bits; b++ {
+				rank = rank<<2 | (xy>>b&1)<<1 | (y >> b & 1)
+			}
+			out[y*n+x] = (float32(rank) + 0.5) / float32(n*n)
+		}
+	}
+	return out
+}
+
+// BayerThreshold returns a threshold that tiles the n × n Bayer matrix
+func BayerThreshold(n int) Threshold {
+	m := Bayer(n)
+	return func(x, y int) float32 {
+		return m[(y&(n-1))*n+x&(n-1)]
+	}
+}
+
+// IGN returns the interleaved gradient noise of Jimenez at pixel (x, y), offset by
+// the seed. It is a cheap threshold in [0, 1) whose neighbouring values differ a
+// lot, so dithering with it looks almost like blue noise without any texture.
+// Changing the seed every frame shifts the pattern for temporal accumulation.
+func IGN(seed uint32, x, y int) float32 {
+	h := xxhash64(uint64(seed), 0)
+	fx := float64(x + int(h&0xfff))
+	fy := float64(y + int(h>>12&0xfff))
+
+	// Both products are rounded separately to keep the result exact everywhere
+	f := float64(0.06711056*fx) + float64(0.00583715*fy)
+	f = float64(52.9829189 * (f - math.Floor(f)))
+	return float32(f - math.Floor(f))
+}
+
+// IGNThreshold returns a threshold of interleaved gradient noise for the seed
+func IGNThreshold(seed uint32) Threshold {
+	return func(x, y int) float32 {
+		return IGN(seed, x, y)
+	}
+}
+
+// WhiteThreshold returns a threshold of white noise, which realizes a probability
+// field stochastically: each pixel is set with probability equal to its value.
+func WhiteThreshold(seed uint32) Threshold {
+	return func(x, y int) float32 {
+		return Float32(seed, uint64(uint32(x))<<32|uint64(uint32(y)))
+	}
+}
+
+// Dither thresholds a w × h grid of values in [0, 1], stored in row-major order,
+// into a bitmap where the bit y*w+x is set if the value exceeds the threshold of
+// its pixel. The density of set bits follows the values.
+//
+// Example:
+//
+//	fog := make([]float32, 256*256)
+//	// ... fill fog with values in [0, 1]
+//	mask := Dither(fog, 256, 256, BayerThreshold(8))
+func Dither(values []float32, w, h int, threshold Threshold) bitmap.Bitmap {
+	if w < 0 || h < 0 || len(values) < w*h {
+		panic("invalid argument to Dither")
+	}
+
+	var out bitmap.Bitmap
+	if w*h > 0 {
+		out.Grow(uint32(w*h - 1))
+	}
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if values[y*w+x] > threshold(x, y) {
+				out.Set(uint32(y*w + x))
+			}
+		}
+	}
+	return out
+}
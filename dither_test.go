@@ -0,0 +1,70 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBayer(t *testing.T) {
+	assert.Equal(t, []float32{0.125, 0.625, 0.875, 0.375}, Bayer(2))
+
+	// The reference 4 × 4 matrix
+	want := []int{
+		0, 8, 2, 10,
+		12, 4, 14, 6,
+		3, 11, 1, 9,
+		15, 7, 13, 5,
+	}
+	for i, v := range Bayer(4) {
+		assert.Equal(t, float32(want[i])+0.5, v*16)
+	}
+
+	// Every rank appears exactly once
+	seen := make(map[float32]bool)
+	for _, v := range Bayer(16) {
+		assert.False(t, seen[v])
+		assert.True(t, v > 0 && v < 1)
+		seen[v] = true
+	}
+	assert.Len(t, seen, 256)
+	assert.Equal(t, []float32{0.5}, Bayer(1))
+	assert.Panics(t, func() { Bayer(3) })
+
+	th := BayerThreshold(4)
+	assert.Equal(t, th(1, 2), th(5, 6))
+	assert.Equal(t, Bayer(4)[2*4+1], th(1, 2))
+}
+
+func TestIGN(t *testing.T) {
+	var sum float64
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			v := IGN(1, x, y)
+			assert.True(t, v >= 0 && v < 1)
+			sum += float64(v)
+		}
+	}
+	assert.InDelta(t, 0.5, sum/4096, 0.02)
+	assert.NotEqual(t, IGN(1, 3, 4), IGN(2, 3, 4))
+	assert.Equal(t, IGN(1, 3, 4), IGNThreshold(1)(3, 4))
+}
+
+func TestDither(t *testing.T) {
+	const w, h = 64, 64
+	for _, th := range []Threshold{BayerThreshold(8), IGNThreshold(1), WhiteThreshold(1)} {
+		for _, level := range []float32{0, 0.25, 0.5, 0.75, 1} {
+			values := make([]float32, w*h)
+			for i := range values {
+				values[i] = level
+			}
+
+			// The density of set bits follows the value
+			out := Dither(values, w, h, th)
+			assert.InDelta(t, float64(level), float64(out.Count())/(w*h), 0.03)
+		}
+	}
+
+	assert.Equal(t, 0, Dither(nil, 0, 0, BayerThreshold(2)).Count())
+	assert.Panics(t, func() { Dither(make([]float32, 3), 2, 2, BayerThreshold(2)) })
+}
@@ -0,0 +1,174 @@
+package noise
+
+import (
+	"math"
+
+	"github.com/kelindar/bitmap"
+)
+
+// ---------------------------------- Aggregation ----------------------------------
+
+// DLA configures the diffusion-limited aggregation. Zero fields take the default
+// values listed below, so DLA{} is a sensible starting point.
+type DLA struct {
+	Particles  int      // Number of particles to attach, default a tenth of the cells
+	Stickiness float32  // Probability of sticking on contact in (0, 1], default 1
+	Seeds      [][2]int // Cells the aggregate grows from, default the center of the grid
+}
+
+// withDefaults returns the options with the zero fields replaced by defaults
+func (o DLA) withDefaults(w, h int) DLA {
+	if o.Particles == 0 {
+		o.Particles = w * h / 10
+	}
+	if o.Stickiness <= 0 || o.Stickiness > 1 {
+		o.Stickiness = 1
+	}
+	if len(o.Seeds) == 0 {
+		o.Seeds = [][2]int{{w / 2, h / 2}}
+	}
+	return o
+}
+
+// Aggregate is a branching cluster grown by diffusion-limited aggregation
+type Aggregate struct {
+	Width, Height int           // Size of the grid in cells
+	Cells         bitmap.Bitmap // Occupied cells, the bit y*Width+x is set for (x, y)
+	Points        [][2]int      // Occupied cells in the order they were attached
+	Parents       []int32       // Index in Points of the cell each one stuck to, -1 for seeds
+	index         []int32       // Index in Points of every cell, -1 if empty
+}
+
+// NewAggregate grows a w×h cluster by diffusion-limited aggregation: particles are
+// released one at a time on a circle around the cluster and random walk until they
+// touch it, where they stick. Particles rarely reach deep inside, so the cluster
+// grows fractal branches like coral, lichen or frost. Parents links every cell to
+// the one it stuck to, forming a tree which can be drawn as a river delta.
+// Deterministic for a given seed, the walkers use the package hash.
+//
+// Example:
+//
+//	a := NewAggregate(12345, 256, 256, DLA{Particles: 4000})
+//	for i, p := range a.Points {
+//	    if j := a.Parents[i]; j >= 0 {
+//	        // draw a segment from a.Points[j] to p
+//	    }
+//	}
+func NewAggregate(seed uint32, w, h int, opts DLA) *Aggregate {
+	if w <= 0 || h <= 0 {
+		return &Aggregate{}
+	}
+
+	o := opts.withDefaults(w, h)
+	a := &Aggregate{Width: w, Height: h, index: make([]int32, w*h)}
+	a.Cells.Grow(uint32(w*h - 1))
+	for i := range a.index {
+		a.index[i] = -1
+	}
+
+	// Launch particles around the centroid of the seeds
+	var cx, cy float64
+	for _, p := range o.Seeds {
+		if a.in(p[0], p[1]) && !a.Cells.Contains(uint32(p[1]*w+p[0])) {
+			a.attach(p[0], p[1], -1)
+			cx += float64(p[0])
+			cy += float64(p[1])
+		}
+	}
+	if len(a.Points) == 0 {
+		return a
+	}
+	cx /= float64(len(a.Points))
+	cy /= float64(len(a.Points))
+
+	var radius float64
+	for _, p := range a.Points {
+		radius = max(radius, math.Hypot(float64(p[0])-cx, float64(p[1])-cy))
+	}
+
+	rng := uint64(0) // counter of random draws
+	next := func() uint64 {
+		rng++
+		return xxhash64(rng, uint64(seed))
+	}
+
+	target := len(a.Points) + min(o.Particles, w*h-len(a.Points))
+	for len(a.Points) < target {
+		x, y := a.launch(cx, cy, radius+2, next)
+		for {
+			if parent := a.touches(x, y); parent >= 0 && float32(next()>>40)/float32(1<<24) < o.Stickiness {
+				a.attach(x, y, parent)
+				radius = max(radius, math.Hypot(float64(x)-cx, float64(y)-cy))
+				break
+			}
+
+			// Take a step, relaunching walkers that wander off too far
+			nx, ny := x, y
+			switch next() >> 62 {
+			case 0:
+				nx++
+			case 1:
+				nx--
+			case 2:
+				ny++
+			default:
+				ny--
+			}
+
+			far := math.Hypot(float64(nx)-cx, float64(ny)-cy) > 2*radius+8
+			switch {
+			case !a.in(nx, ny) || far:
+				x, y = a.launch(cx, cy, radius+2, next)
+			case !a.Cells.Contains(uint32(ny*w + nx)):
+				x, y = nx, ny
+			}
+		}
+	}
+	return a
+}
+
+// At returns whether the cell (x, y) is part of the aggregate
+func (a *Aggregate) At(x, y int) bool {
+	return a.in(x, y) && a.Cells.Contains(uint32(y*a.Width+x))
+}
+
+// in returns whether the cell is inside the grid
+func (a *Aggregate) in(x, y int) bool {
+	return x >= 0 && y >= 0 && x < a.Width && y < a.Height
+}
+
+// attach adds a cell to the aggregate
+func (a *Aggregate) attach(x, y int, parent int32) {
+	a.Cells.Set(uint32(y*a.Width + x))
+	a.index[y*a.Width+x] = int32(len(a.Points))
+	a.Points = append(a.Points, [2]int{x, y})
+	a.Parents = append(a.Parents, parent)
+}
+
+// touches returns the index of the first occupied neighbour of a cell, or -1
+func (a *Aggregate) touches(x, y int) int32 {
+	for _, nb := range d8 {
+		if nx, ny := x+nb.dx, y+nb.dy; a.in(nx, ny) && a.index[ny*a.Width+nx] >= 0 {
+			return a.index[ny*a.Width+nx]
+		}
+	}
+	return -1
+}
+
+// launch returns an empty cell on the circle around the aggregate, or anywhere on
+// the grid once the circle no longer fits
+func (a *Aggregate) launch(cx, cy, r float64, next func() uint64) (int, int) {
+	for {
+		angle := float64(next()>>11) / (1 << 53) * 2 * math.Pi
+		x := int(math.Round(cx + r*math.Cos(angle)))
+		y := int(math.Round(cy + r*math.Sin(angle)))
+		if !a.in(x, y) {
+			h := next()
+			x, y = int(h%uint64(a.Width)), int(h>>32%uint64(a.Height))
+		}
+
+		if !a.Cells.Contains(uint32(y*a.Width + x)) {
+			return x, y
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregate(t *testing.T) {
+	a := NewAggregate(1, 128, 128, DLA{Particles: 1000})
+	assert.Len(t, a.Points, 1001)
+	assert.Len(t, a.Parents, 1001)
+	assert.Equal(t, 1001, a.Cells.Count())
+	assert.Equal(t, [2]int{64, 64}, a.Points[0])
+	assert.Equal(t, int32(-1), a.Parents[0])
+	assert.Equal(t, a.Points, NewAggregate(1, 128, 128, DLA{Particles: 1000}).Points)
+	assert.NotEqual(t, a.Points, NewAggregate(2, 128, 128, DLA{Particles: 1000}).Points)
+
+	// Every cell is adjacent to the one it stuck to, which was attached before it
+	for i, p := range a.Points[1:] {
+		j := a.Parents[i+1]
+		assert.Less(t, int(j), i+1)
+		q := a.Points[j]
+		assert.LessOrEqual(t, max(abs(float32(p[0]-q[0])), abs(float32(p[1]-q[1]))), float32(1))
+		assert.True(t, a.At(p[0], p[1]))
+	}
+
+	// The cluster branches out rather than filling a disk
+	var r2 float64
+	for _, p := range a.Points {
+		dx, dy := float64(p[0]-64), float64(p[1]-64)
+		r2 = max(r2, dx*dx+dy*dy)
+	}
+	assert.Greater(t, 3.14*r2, 3*float64(len(a.Points)))
+}
+
+func TestAggregateOptions(t *testing.T) {
+	a := NewAggregate(1, 64, 32, DLA{
+		Particles:  300,
+		Stickiness: 0.3,
+		Seeds:      [][2]int{{0, 31}, {63, 31}, {63, 31}, {-1, 0}},
+	})
+	assert.Len(t, a.Points, 302)
+	assert.Equal(t, []int32{-1, -1}, a.Parents[:2])
+	assert.False(t, a.At(-1, 0))
+
+	// Defaults to a tenth of the grid
+	assert.Len(t, NewAggregate(1, 32, 32, DLA{}).Points, 103)
+
+	// Stops when the grid is full
+	assert.Len(t, NewAggregate(1, 4, 4, DLA{Particles: 100}).Points, 16)
+	assert.Empty(t, NewAggregate(1, 0, 4, DLA{}).Points)
+}
@@ -0,0 +1,233 @@
+package noise
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// ---------------------------------- Drainage ----------------------------------
+
+// d8 are the offsets and distances of the 8 neighbours of a cell
+var d8 = [8]struct {
+	dx, dy int
+	dist   float32
+}{
+	{1, 0, 1}, {1, 1, math.Sqrt2}, {0, 1, 1}, {-1, 1, math.Sqrt2},
+	{-1, 0, 1}, {-1, -1, math.Sqrt2}, {0, -1, 1}, {1, -1, math.Sqrt2},
+}
+
+// Drainage is the D8 flow network of a heightmap, where each cell drains into its
+// steepest downhill neighbour. Depressions are filled beforehand, so that all of the
+// water eventually reaches the border of the map.
+type Drainage struct {
+	Width, Height int
+	Downstream    []int32   // Index of the cell each cell drains into, -1 for outlets
+	Accumulation  []float32 // Number of cells draining through each cell, itself included
+	seed          uint32
+}
+
+// NewDrainage computes the drainage network of a w×h heightmap in row-major order.
+// The seed breaks ties on flat areas, so that they drain in a natural-looking but
+// deterministic way instead of following the grid axes.
+// Complexity: O(n log n) for n = w·h.
+//
+// Example:
+//
+//	d := NewDrainage(12345, heights, 512, 512)
+//	for _, river := range d.Rivers(200) {
+//	    // draw the polyline
+//	}
+func NewDrainage(seed uint32, heights []float32, w, h int) *Drainage {
+	d := &Drainage{Width: w, Height: h, seed: seed}
+	if w <= 0 || h <= 0 || len(heights) < w*h {
+		d.Width, d.Height = 0, 0
+		return d
+	}
+
+	n := w * h
+	filled := fillDepressions(seed, heights, w, h)
+
+	// Drain every cell into its steepest downhill neighbour
+	d.Downstream = make([]int32, n)
+	for i := range d.Downstream {
+		x, y := i%w, i/w
+		best, slope := int32(-1), float32(0)
+		for _, nb := range d8 {
+			nx, ny := x+nb.dx, y+nb.dy
+			if nx < 0 || ny < 0 || nx >= w || ny >= h {
+				continue
+			}
+
+			j := ny*w + nx
+			if s := (filled[i] - filled[j]) / nb.dist; s > slope {
+				best, slope = int32(j), s
+			}
+		}
+		d.Downstream[i] = best
+	}
+
+	// Accumulate the flow from the highest to the lowest cell
+	order := make([]int32, n)
+	for i := range order {
+		order[i] = int32(i)
+	}
+	sort.Slice(order, func(a, b int) bool {
+		ha, hb := filled[order[a]], filled[order[b]]
+		return ha > hb || (ha == hb && order[a] < order[b])
+	})
+
+	d.Accumulation = make([]float32, n)
+	for _, i := range order {
+		d.Accumulation[i]++
+		if j := d.Downstream[i]; j >= 0 {
+			d.Accumulation[j] += d.Accumulation[i]
+		}
+	}
+	return d
+}
+
+// Rivers extracts the river network as polylines, a river being any cell where at
+// least threshold cells drain through. Each polyline starts at the source of a river
+// and follows it downstream until it reaches the border of the map or joins another
+// river, in which case the confluence is its last point. Points are jittered within
+// their cell, deterministically, so rivers do not look aligned to the grid.
+func (d *Drainage) Rivers(threshold float32) [][][2]float32 {
+	n := d.Width * d.Height
+	fed := make([]bool, n) // whether a river flows into the cell
+	for i := 0; i < n; i++ {
+		if j := d.Downstream[i]; j >= 0 && d.Accumulation[i] >= threshold {
+			fed[j] = true
+		}
+	}
+
+	var rivers [][][2]float32
+	visited := make([]bool, n)
+	for head := 0; head < n; head++ {
+		if d.Accumulation[head] < threshold || fed[head] {
+			continue
+		}
+
+		var line [][2]float32
+		for i := int32(head); i >= 0; i = d.Downstream[i] {
+			line = append(line, d.point(i))
+			if visited[i] {
+				break
+			}
+			visited[i] = true
+		}
+		rivers = append(rivers, line)
+	}
+	return rivers
+}
+
+// point returns the jittered center of a cell
+func (d *Drainage) point(i int32) [2]float32 {
+	x := float32(int(i)%d.Width) + 0.25 + Float32(d.seed, uint64(i))/2
+	y := float32(int(i)/d.Width) + 0.25 + Float32(d.seed^1, uint64(i))/2
+	return [2]float32{x, y}
+}
+
+// Carve lowers the river cells of a heightmap in place, by up to depth for the
+// largest river and proportionally to the logarithm of the flow for smaller ones.
+// Heights never increase along a river, so carved channels do not create pits.
+func (d *Drainage) Carve(heights []float32, threshold, depth float32) {
+	n := d.Width * d.Height
+	if len(heights) < n || threshold <= 0 {
+		return
+	}
+
+	var largest float32
+	var rivers []int32
+	for i := 0; i < n; i++ {
+		if a := d.Accumulation[i]; a >= threshold {
+			largest = max(largest, a)
+			rivers = append(rivers, int32(i))
+		}
+	}
+
+	// Visit river cells upstream first, so that their downstream cells come later
+	sort.Slice(rivers, func(a, b int) bool {
+		fa, fb := d.Accumulation[rivers[a]], d.Accumulation[rivers[b]]
+		return fa < fb || (fa == fb && rivers[a] < rivers[b])
+	})
+
+	scale := math.Log1p(float64(largest / threshold))
+	for _, i := range rivers {
+		heights[i] -= float32(float64(depth) * math.Log1p(float64(d.Accumulation[i]/threshold)) / scale)
+	}
+
+	for _, i := range rivers {
+		if j := d.Downstream[i]; j >= 0 && d.Accumulation[j] >= threshold {
+			heights[j] = min(heights[j], heights[i])
+		}
+	}
+}
+
+// fillDepressions returns a copy of the heightmap where every depression is raised
+// just enough to drain towards the border, using the priority-flood algorithm.
+func fillDepressions(seed uint32, heights []float32, w, h int) []float32 {
+	n := w * h
+	filled := make([]float32, n)
+	copy(filled, heights[:n])
+
+	// Flood from the border inwards, always expanding the lowest cell first
+	queue := &floodQueue{seed: uint64(seed)}
+	closed := make([]bool, n)
+	for i := 0; i < n; i++ {
+		if x, y := i%w, i/w; x == 0 || y == 0 || x == w-1 || y == h-1 {
+			closed[i] = true
+			queue.push(i, filled[i])
+		}
+	}
+
+	for queue.Len() > 0 {
+		c := heap.Pop(queue).(floodCell)
+		x, y := int(c.index)%w, int(c.index)/w
+		for _, nb := range d8 {
+			nx, ny := x+nb.dx, y+nb.dy
+			if nx < 0 || ny < 0 || nx >= w || ny >= h || closed[ny*w+nx] {
+				continue
+			}
+
+			// Raise the neighbour slightly above the cell, so it can drain into it
+			j := ny*w + nx
+			closed[j] = true
+			filled[j] = max(filled[j], math.Nextafter32(c.height, math.MaxFloat32))
+			queue.push(j, filled[j])
+		}
+	}
+	return filled
+}
+
+// floodCell is a cell in the priority-flood queue
+type floodCell struct {
+	index  int32
+	height float32
+	order  uint64 // random tie-breaker between cells of equal height
+}
+
+// floodQueue is a min-heap of cells by height
+type floodQueue struct {
+	seed  uint64
+	cells []floodCell
+}
+
+func (q *floodQueue) push(i int, height float32) {
+	heap.Push(q, floodCell{index: int32(i), height: height, order: xxhash64(uint64(i), q.seed)})
+}
+
+func (q *floodQueue) Len() int      { return len(q.cells) }
+func (q *floodQueue) Swap(i, j int) { q.cells[i], q.cells[j] = q.cells[j], q.cells[i] }
+func (q *floodQueue) Push(x any)    { q.cells = append(q.cells, x.(floodCell)) }
+func (q *floodQueue) Less(i, j int) bool {
+	a, b := q.cells[i], q.cells[j]
+	return a.height < b.height || (a.height == b.height && a.order < b.order)
+}
+
+func (q *floodQueue) Pop() any {
+	n := len(q.cells) - 1
+	c := q.cells[n]
+	q.cells = q.cells[:n]
+	return c
+}
@@ -0,0 +1,86 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrainage(t *testing.T) {
+	const w, h = 64, 64
+	heights := fbmGrid(42, w, h, 0.05)
+	d := NewDrainage(42, heights, w, h)
+	assert.Equal(t, d, NewDrainage(42, heights, w, h))
+
+	// Every cell eventually drains to an outlet on the border
+	var outflow float32
+	for i := 0; i < w*h; i++ {
+		steps := 0
+		j := int32(i)
+		for d.Downstream[j] >= 0 {
+			j = d.Downstream[j]
+			steps++
+			assert.Less(t, steps, w*h)
+		}
+
+		x, y := int(j)%w, int(j)/w
+		assert.True(t, x == 0 || y == 0 || x == w-1 || y == h-1)
+		if int(j) == i {
+			outflow += d.Accumulation[i]
+		}
+	}
+
+	// All of the water leaves the map
+	assert.Equal(t, float32(w*h), outflow)
+}
+
+func TestDrainageDepression(t *testing.T) {
+	// A bowl with a notch on the left border, the whole map drains through it
+	const w, h = 9, 9
+	heights := make([]float32, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			heights[y*w+x] = abs(float32(x-4)) + abs(float32(y-4)) - 10
+		}
+	}
+	heights[4*w] = -20
+
+	d := NewDrainage(1, heights, w, h)
+	assert.Equal(t, int32(-1), d.Downstream[4*w])
+	assert.Equal(t, float32(w*h), d.Accumulation[4*w])
+}
+
+func TestRivers(t *testing.T) {
+	const w, h = 64, 64
+	heights := fbmGrid(42, w, h, 0.05)
+	d := NewDrainage(42, heights, w, h)
+	rivers := d.Rivers(50)
+	assert.NotEmpty(t, rivers)
+
+	for _, river := range rivers {
+		assert.GreaterOrEqual(t, len(river), 1)
+		for k := 1; k < len(river); k++ {
+			a, b := river[k-1], river[k]
+			assert.Less(t, abs(a[0]-b[0]), float32(2))
+			assert.Less(t, abs(a[1]-b[1]), float32(2))
+		}
+	}
+
+	// Carving deepens the rivers without creating pits along them
+	carved := append([]float32(nil), heights...)
+	d.Carve(carved, 50, 0.2)
+	for i := range carved {
+		assert.LessOrEqual(t, carved[i], heights[i])
+		if d.Accumulation[i] >= 50 {
+			if j := d.Downstream[i]; j >= 0 && d.Accumulation[j] >= 50 {
+				assert.LessOrEqual(t, carved[j], carved[i])
+			}
+		}
+	}
+}
+
+func TestDrainageEmpty(t *testing.T) {
+	d := NewDrainage(1, nil, 4, 4)
+	assert.Empty(t, d.Rivers(1))
+	d.Carve(nil, 1, 1)
+}
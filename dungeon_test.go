@@ -0,0 +1,74 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDungeon(t *testing.T) {
+	d := NewDungeon(42, 80, 50, Rooms{})
+	assert.Equal(t, d, NewDungeon(42, 80, 50, Rooms{}))
+	assert.Len(t, d.Tiles, 80*50)
+	assert.Greater(t, len(d.Rooms), 3)
+	assert.Len(t, d.Corridors, len(d.Rooms)-1)
+
+	// Rooms are within bounds, sized and spaced as requested
+	for i, r := range d.Rooms {
+		assert.True(t, r.X >= 1 && r.Y >= 1 && r.X+r.W < 80 && r.Y+r.H < 50)
+		assert.True(t, r.W >= 4 && r.W <= 10 && r.H >= 4 && r.H <= 10)
+		for _, o := range d.Rooms[i+1:] {
+			assert.False(t, r.overlaps(o, 2))
+		}
+	}
+
+	// Every room is reachable from the first one
+	assert.Equal(t, len(d.Rooms), reachableRooms(d))
+	assert.Equal(t, TileWall, d.At(-1, 0))
+	assert.Equal(t, TileWall, d.At(0, 0))
+}
+
+func TestDungeonLoops(t *testing.T) {
+	tree := NewDungeon(7, 120, 80, Rooms{})
+	loops := NewDungeon(7, 120, 80, Rooms{Loops: 1})
+	assert.Equal(t, tree.Rooms, loops.Rooms)
+	assert.Greater(t, len(loops.Corridors), len(tree.Corridors))
+	assert.Equal(t, len(loops.Rooms), reachableRooms(loops))
+}
+
+func TestDungeonEmpty(t *testing.T) {
+	assert.Empty(t, NewDungeon(1, 0, 10, Rooms{}).Tiles)
+	assert.Empty(t, NewDungeon(1, 5, 5, Rooms{}).Rooms)
+}
+
+// reachableRooms flood fills the walkable tiles from the first room and counts
+// the rooms that were reached.
+func reachableRooms(d *Dungeon) int {
+	if len(d.Rooms) == 0 {
+		return 0
+	}
+
+	seen := make([]bool, len(d.Tiles))
+	x, y := d.Rooms[0].Center()
+	stack := []int{y*d.Width + x}
+	seen[stack[0]] = true
+	for len(stack) > 0 {
+		i := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, n := range [4][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+			nx, ny := i%d.Width+n[0], i/d.Width+n[1]
+			if j := ny*d.Width + nx; d.At(nx, ny) != TileWall && !seen[j] {
+				seen[j] = true
+				stack = append(stack, j)
+			}
+		}
+	}
+
+	count := 0
+	for _, r := range d.Rooms {
+		if cx, cy := r.Center(); seen[cy*d.Width+cx] {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,264 @@
+package noise
+
+import "math"
+
+// ---------------------------------- Hydraulic Erosion ----------------------------------
+
+// Hydraulic configures droplet-based hydraulic erosion. Zero fields take the
+// default values listed below, so Hydraulic{} is a sensible starting point.
+type Hydraulic struct {
+	Droplets    int     // Number of droplets to simulate, default w*h/4
+	Lifetime    int     // Maximum number of steps of a droplet, default 30
+	Radius      int     // Radius of the erosion brush in cells, default 3
+	Inertia     float32 // How much a droplet keeps its direction in [0, 1], default 0.05
+	Capacity    float32 // Sediment capacity multiplier, default 4
+	MinCapacity float32 // Minimum sediment capacity on flat terrain, default 0.01
+	Erosion     float32 // Fraction of the free capacity eroded per step, default 0.3
+	Deposition  float32 // Fraction of the excess sediment deposited per step, default 0.3
+	Evaporation float32 // Fraction of water evaporated per step, default 0.01
+	Gravity     float32 // Acceleration of droplets going downhill, default 4
+}
+
+// withDefaults returns the options with the zero fields replaced by defaults
+func (o Hydraulic) withDefaults(w, h int) Hydraulic {
+	defaults := func(v *float32, d float32) {
+		if *v == 0 {
+			*v = d
+		}
+	}
+
+	if o.Droplets == 0 {
+		o.Droplets = w * h / 4
+	}
+	if o.Lifetime == 0 {
+		o.Lifetime = 30
+	}
+	if o.Radius == 0 {
+		o.Radius = 3
+	}
+
+	defaults(&o.Inertia, 0.05)
+	defaults(&o.Capacity, 4)
+	defaults(&o.MinCapacity, 0.01)
+	defaults(&o.Erosion, 0.3)
+	defaults(&o.Deposition, 0.3)
+	defaults(&o.Evaporation, 0.01)
+	defaults(&o.Gravity, 4)
+	return o
+}
+
+// HydraulicErode simulates rain droplets flowing over a w×h heightmap in row-major
+// order, modifying it in place. Each droplet picks up sediment while it flows
+// downhill and accelerates, then deposits it where it slows down or the slope
+// flattens, which carves gullies and valleys and fills basins.
+// Droplets are spawned at positions derived from the seed and simulated one after
+// another, so the result is deterministic for a given seed.
+// Complexity: O(droplets · lifetime · radius²).
+//
+// Example:
+//
+//	heights := make([]float32, 512*512)
+//	NewSimplex(12345).Fill2D(heights, 512, 512, 0, 0, 0.01)
+//	HydraulicErode(12345, heights, 512, 512, Hydraulic{Droplets: 100000})
+func HydraulicErode(seed uint32, heights []float32, w, h int, opts Hydraulic) {
+	if w < 2 || h < 2 || len(heights) < w*h {
+		return
+	}
+
+	o := opts.withDefaults(w, h)
+	for i := 0; i < o.Droplets; i++ {
+		hi := xxhash64(uint64(i), uint64(seed))
+		x := Float32(seed, hi) * float32(w-1)
+		y := Float32(seed^1, hi) * float32(h-1)
+		erodeDroplet(seed, hi, heights, w, h, x, y, &o)
+	}
+}
+
+// erodeDroplet simulates a single droplet starting at (x, y)
+func erodeDroplet(seed uint32, hi uint64, heights []float32, w, h int, x, y float32, o *Hydraulic) {
+	var dx, dy, sediment float32
+	speed, water := float32(1), float32(1)
+	for step := 0; step < o.Lifetime; step++ {
+		cx, cy := int(x), int(y)
+		u, v := x-float32(cx), y-float32(cy)
+		height, gx, gy := gradientAt(heights, w, x, y)
+
+		// Update the direction, blending the previous one with the downhill gradient
+		dx = float32(dx*o.Inertia) - float32(gx*(1-o.Inertia))
+		dy = float32(dy*o.Inertia) - float32(gy*(1-o.Inertia))
+		length := float32(math.Sqrt(float64(float32(dx*dx) + float32(dy*dy))))
+		if length == 0 {
+			// On perfectly flat terrain, pick a random direction
+			angle := Float64(seed^2, xxhash64(uint64(step), hi)) * 2 * math.Pi
+			sin, cos := math.Sincos(angle)
+			dx, dy, length = float32(cos), float32(sin), 1
+		}
+
+		dx /= length
+		dy /= length
+		x += dx
+		y += dy
+		if x < 0 || y < 0 || x >= float32(w-1) || y >= float32(h-1) {
+			return
+		}
+
+		// The capacity is higher when going fast, downhill and with lots of water
+		next, _, _ := gradientAt(heights, w, x, y)
+		delta := next - height
+		capacity := max(float32(float32(float32(-delta*speed)*water)*o.Capacity), o.MinCapacity)
+
+		switch {
+		case delta > 0 || sediment > capacity:
+			// Fill the pit when going uphill, otherwise drop the excess sediment
+			amount := float32((sediment - capacity) * o.Deposition)
+			if delta > 0 {
+				amount = min(delta, sediment)
+			}
+
+			sediment -= amount
+			deposit(heights, w, cx, cy, u, v, amount)
+		default:
+			// Erode no more than the height difference, so no pits are dug
+			amount := min(float32((capacity-sediment)*o.Erosion), -delta)
+			sediment += erodeBrush(heights, w, h, cx, cy, o.Radius, amount)
+		}
+
+		speed = float32(math.Sqrt(float64(max(float32(speed*speed)+float32(delta*o.Gravity), 0))))
+		water *= 1 - o.Evaporation
+	}
+}
+
+// gradientAt returns the bilinearly interpolated height and gradient at (x, y)
+func gradientAt(heights []float32, w int, x, y float32) (height, gx, gy float32) {
+	cx, cy := int(x), int(y)
+	u, v := x-float32(cx), y-float32(cy)
+
+	i := cy*w + cx
+	h00, h10 := heights[i], heights[i+1]
+	h01, h11 := heights[i+w], heights[i+w+1]
+
+	gx = lerp(h10-h00, h11-h01, v)
+	gy = lerp(h01-h00, h11-h10, u)
+	height = lerp(lerp(h00, h10, u), lerp(h01, h11, u), v)
+	return
+}
+
+// deposit adds sediment to the four corners of a cell, weighted by the offset
+// (u, v) of the droplet within the cell
+func deposit(heights []float32, w, cx, cy int, u, v, amount float32) {
+	i := cy*w + cx
+	heights[i] += float32(amount * float32((1-u)*(1-v)))
+	heights[i+1] += float32(amount * float32(u*(1-v)))
+	heights[i+w] += float32(amount * float32((1-u)*v))
+	heights[i+w+1] += float32(amount * float32(u*v))
+}
+
+// erodeBrush removes up to amount of material around a cell, weighted by distance
+// within the radius, and returns the amount actually removed
+func erodeBrush(heights []float32, w, h, cx, cy, radius int, amount float32) float32 {
+	var total float32
+	r := float32(radius)
+	for y := max(cy-radius, 0); y <= min(cy+radius, h-1); y++ {
+		for x := max(cx-radius, 0); x <= min(cx+radius, w-1); x++ {
+			dx, dy := float32(x-cx), float32(y-cy)
+			if d := float32(math.Sqrt(float64(dx*dx + dy*dy))); d < r {
+				total += r - d
+			}
+		}
+	}
+
+	var removed float32
+	for y := max(cy-radius, 0); y <= min(cy+radius, h-1); y++ {
+		for x := max(cx-radius, 0); x <= min(cx+radius, w-1); x++ {
+			dx, dy := float32(x-cx), float32(y-cy)
+			d := float32(math.Sqrt(float64(dx*dx + dy*dy)))
+			if d >= r {
+				continue
+			}
+
+			take := float32(amount * ((r - d) / total))
+			heights[y*w+x] -= take
+			removed += take
+		}
+	}
+	return removed
+}
+
+// ---------------------------------- Thermal Erosion ----------------------------------
+
+// ThermalErode relaxes a w×h heightmap in row-major order in place, moving material
+// down every slope steeper than the talus angle (in degrees) until it settles at that
+// angle, as loose rock does. This forms scree fields and smooths foothills, which
+// complements hydraulic erosion. Heights are expressed in the same unit as the
+// spacing between cells. Each iteration updates all cells at once, so the result
+// does not depend on the traversal order.
+// Complexity: O(w·h·iterations).
+//
+// Example:
+//
+//	ThermalErode(heights, 512, 512, 35, 50)
+func ThermalErode(heights []float32, w, h int, talusAngle float32, iterations int) {
+	if w < 2 || h < 2 || len(heights) < w*h {
+		return
+	}
+
+	// Neighbours in the 8-neighbourhood and their distances
+	type neighbour struct {
+		dx, dy int
+		talus  float32 // maximum stable height difference
+	}
+
+	tan := float32(math.Tan(float64(talusAngle) * math.Pi / 180))
+	diag := float32(math.Sqrt2 * float64(tan))
+	neighbours := [8]neighbour{
+		{-1, 0, tan}, {1, 0, tan}, {0, -1, tan}, {0, 1, tan},
+		{-1, -1, diag}, {1, -1, diag}, {-1, 1, diag}, {1, 1, diag},
+	}
+
+	delta := make([]float32, w*h)
+	for it := 0; it < iterations; it++ {
+		moved := false
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				i := y*w + x
+				var excess [8]float32
+				var total, largest float32
+				for n, nb := range neighbours {
+					nx, ny := x+nb.dx, y+nb.dy
+					if nx < 0 || ny < 0 || nx >= w || ny >= h {
+						continue
+					}
+
+					if d := heights[i] - heights[ny*w+nx] - nb.talus; d > 0 {
+						excess[n] = d
+						total += d
+						largest = max(largest, d)
+					}
+				}
+
+				if total == 0 {
+					continue
+				}
+
+				// Move half of the largest excess, shared in proportion to the excesses
+				moved = true
+				amount := largest / 2
+				delta[i] -= amount
+				for n, nb := range neighbours {
+					if excess[n] > 0 {
+						delta[(y+nb.dy)*w+x+nb.dx] += float32(amount * (excess[n] / total))
+					}
+				}
+			}
+		}
+
+		if !moved {
+			return
+		}
+
+		for i, d := range delta {
+			heights[i] += d
+			delta[i] = 0
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHydraulicErode(t *testing.T) {
+	const w, h = 64, 64
+	terrain := func() []float32 {
+		return fbmGrid(42, w, h, 0.05)
+	}
+
+	before := terrain()
+	a, b := terrain(), terrain()
+	HydraulicErode(42, a, w, h, Hydraulic{})
+	HydraulicErode(42, b, w, h, Hydraulic{})
+	assert.Equal(t, a, b, "deterministic")
+	assert.NotEqual(t, before, a)
+
+	// Erosion smooths the terrain, reducing its total variation
+	assert.Less(t, variation(a, w, h), variation(before, w, h))
+
+	// Mass is roughly conserved, sediment is moved rather than destroyed
+	assert.InDelta(t, sum(before), sum(a), float64(w*h)*0.05)
+
+	// A different seed gives a different result
+	c := terrain()
+	HydraulicErode(43, c, w, h, Hydraulic{})
+	assert.NotEqual(t, a, c)
+}
+
+func TestHydraulicErodeFlat(t *testing.T) {
+	heights := make([]float32, 16*16)
+	HydraulicErode(42, heights, 16, 16, Hydraulic{})
+	for _, v := range heights {
+		assert.InDelta(t, 0, v, 1e-3)
+	}
+
+	// Degenerate sizes are ignored
+	HydraulicErode(42, heights, 1, 16, Hydraulic{})
+	HydraulicErode(42, heights, 32, 32, Hydraulic{})
+}
+
+// variation returns the sum of absolute differences between neighbouring cells
+func variation(heights []float32, w, h int) (out float32) {
+	for y := 0; y < h; y++ {
+		for x := 1; x < w; x++ {
+			out += abs(heights[y*w+x] - heights[y*w+x-1])
+		}
+	}
+	return
+}
+
+// sum returns the sum of the values
+func sum(values []float32) (out float64) {
+	for _, v := range values {
+		out += float64(v)
+	}
+	return
+}
+
+// fbmGrid returns a w×h heightmap of fBM noise
+func fbmGrid(seed uint32, w, h int, step float32) []float32 {
+	f := NewFBM(seed)
+	heights := make([]float32, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			heights[y*w+x] = f.Eval2(float32(x)*step, float32(y)*step)
+		}
+	}
+	return heights
+}
+
+func TestThermalErode(t *testing.T) {
+	const w, h = 16, 16
+
+	// A single spike collapses into a mound
+	heights := make([]float32, w*h)
+	heights[8*w+8] = 10
+	ThermalErode(heights, w, h, 45, 500)
+	assert.InDelta(t, 10, sum(heights), 1e-3, "mass is conserved")
+	assert.Less(t, heights[8*w+8], float32(10))
+
+	// No slope is left steeper than the talus angle (tan 45° = 1), up to a tolerance
+	for y := 0; y < h; y++ {
+		for x := 1; x < w; x++ {
+			assert.LessOrEqual(t, abs(heights[y*w+x]-heights[y*w+x-1]), float32(1.01))
+		}
+	}
+}
+
+func TestThermalErodeStable(t *testing.T) {
+	const w, h = 8, 8
+
+	// A gentle ramp is below the talus angle and stays untouched
+	heights := make([]float32, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			heights[y*w+x] = float32(x) * 0.1
+		}
+	}
+
+	before := append([]float32(nil), heights...)
+	ThermalErode(heights, w, h, 30, 10)
+	assert.Equal(t, before, heights)
+
+	// Degenerate sizes are ignored
+	ThermalErode(heights, 1, 8, 30, 10)
+	ThermalErode(heights, 16, 16, 30, 10)
+}
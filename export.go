@@ -0,0 +1,168 @@
+package noise
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ---------------------------------- Heightmap Export ----------------------------------
+
+// Format is a file format for exported heightmaps
+type Format int
+
+const (
+	PNG16      Format = iota // 16-bit greyscale PNG, normalized to the full range
+	RawFloat32               // Headerless little-endian float32 (.r32), values as-is
+	PFM                      // Portable float map, greyscale float32, values as-is
+	EXR                      // OpenEXR, uncompressed float32 "Y" channel, values as-is
+	NPY                      // NumPy array of shape (h, w) and dtype float32, values as-is
+	CSV                      // Comma-separated values, one row per line, values as-is
+)
+
+// Ext returns the conventional file extension of the format
+func (f Format) Ext() string {
+	switch f {
+	case PNG16:
+		return ".png"
+	case RawFloat32:
+		return ".r32"
+	case PFM:
+		return ".pfm"
+	case EXR:
+		return ".exr"
+	case NPY:
+		return ".npy"
+	case CSV:
+		return ".csv"
+	default:
+		return ""
+	}
+}
+
+// Export writes a w×h heightmap in row-major order in the given format. These are
+// the formats ingested by Unity, Unreal and World Machine. For PNG16, values are
+// linearly normalized so the lowest sample maps to 0 and the highest to 65535. The
+// floating-point formats keep the values at full precision, for VFX and offline
+// rendering pipelines which read PFM or EXR.
+//
+// Example:
+//
+//	heights := make([]float32, 1025*1025)
+//	NewSimplex(12345).Fill2D(heights, 1025, 1025, 0, 0, 0.01)
+//	err := Export(file, PNG16, heights, 1025, 1025)
+func Export(dst io.Writer, format Format, heights []float32, w, h int) error {
+	if w <= 0 || h <= 0 || len(heights) < w*h {
+		return fmt.Errorf("noise: heightmap of %d values is smaller than %dx%d", len(heights), w, h)
+	}
+
+	lo, hi := bounds(heights[:w*h])
+	return export(dst, format, heights, w, 0, 0, w, h, lo, hi)
+}
+
+// ExportTiles splits a w×h heightmap into square tiles of the given size and writes
+// each of them to dir, named "{name}_x{col}_y{row}" with the extension of the format.
+// Adjacent tiles share their border samples, which is what terrain engines expect
+// (e.g. a 2049×2049 map with tiles of 512 yields 4×4 tiles of 513×513). All tiles
+// are normalized with the range of the whole map, so they stitch seamlessly.
+// It returns the paths of the files written, in row-major order.
+func ExportTiles(dir, name string, format Format, heights []float32, w, h, tile int) ([]string, error) {
+	switch {
+	case w <= 0 || h <= 0 || len(heights) < w*h:
+		return nil, fmt.Errorf("noise: heightmap of %d values is smaller than %dx%d", len(heights), w, h)
+	case tile <= 0:
+		return nil, fmt.Errorf("noise: invalid tile size %d", tile)
+	}
+
+	lo, hi := bounds(heights[:w*h])
+	cols := max((w-2)/tile+1, 1)
+	rows := max((h-2)/tile+1, 1)
+
+	var paths []string
+	for ty := 0; ty < rows; ty++ {
+		for tx := 0; tx < cols; tx++ {
+			x0, y0 := tx*tile, ty*tile
+			x1, y1 := min(x0+tile+1, w), min(y0+tile+1, h)
+			path := filepath.Join(dir, fmt.Sprintf("%s_x%d_y%d%s", name, tx, ty, format.Ext()))
+			if err := exportFile(path, format, heights, w, x0, y0, x1, y1, lo, hi); err != nil {
+				return paths, err
+			}
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+// exportFile writes a region of a heightmap to a new file
+func exportFile(path string, format Format, heights []float32, stride, x0, y0, x1, y1 int, lo, hi float32) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := export(file, format, heights, stride, x0, y0, x1, y1, lo, hi); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// export writes the region [x0, x1) × [y0, y1) of a heightmap
+func export(dst io.Writer, format Format, heights []float32, stride, x0, y0, x1, y1 int, lo, hi float32) error {
+	switch format {
+	case PNG16:
+		scale := float32(0)
+		if hi > lo {
+			scale = 65535 / (hi - lo)
+		}
+
+		img := image.NewGray16(image.Rect(0, 0, x1-x0, y1-y0))
+		for y := y0; y < y1; y++ {
+			for x := x0; x < x1; x++ {
+				v := (heights[y*stride+x] - lo) * scale
+				i := img.PixOffset(x-x0, y-y0)
+				u := uint16(min(max(v+0.5, 0), 65535))
+				img.Pix[i], img.Pix[i+1] = uint8(u>>8), uint8(u)
+			}
+		}
+		return png.Encode(dst, img)
+
+	case RawFloat32:
+		buf := bufio.NewWriter(dst)
+		for y := y0; y < y1; y++ {
+			if err := binary.Write(buf, binary.LittleEndian, heights[y*stride+x0:y*stride+x1]); err != nil {
+				return err
+			}
+		}
+		return buf.Flush()
+
+	case PFM:
+		return writePFM(dst, heights, stride, x0, y0, x1, y1)
+
+	case EXR:
+		return writeEXR(dst, heights, stride, x0, y0, x1, y1)
+
+	case NPY:
+		return writeNPY(dst, heights, stride, x0, y0, x1, y1)
+
+	case CSV:
+		return writeCSV(dst, heights, stride, x0, y0, x1, y1)
+
+	default:
+		return fmt.Errorf("noise: unsupported export format %d", format)
+	}
+}
+
+// bounds returns the minimum and maximum of the values
+func bounds(values []float32) (lo, hi float32) {
+	lo, hi = values[0], values[0]
+	for _, v := range values[1:] {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	return
+}
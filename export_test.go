@@ -0,0 +1,95 @@
+package noise
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportPNG16(t *testing.T) {
+	heights := []float32{-1, 0, 1, 0.5, -0.5, 0}
+
+	var buf bytes.Buffer
+	assert.NoError(t, Export(&buf, PNG16, heights, 3, 2))
+
+	img, err := png.Decode(&buf)
+	assert.NoError(t, err)
+	assert.IsType(t, &image.Gray16{}, img)
+
+	gray := img.(*image.Gray16)
+	assert.Equal(t, uint16(0), gray.Gray16At(0, 0).Y)
+	assert.Equal(t, uint16(32768), gray.Gray16At(1, 0).Y)
+	assert.Equal(t, uint16(65535), gray.Gray16At(2, 0).Y)
+	assert.Equal(t, uint16(49151), gray.Gray16At(0, 1).Y)
+}
+
+func TestExportRaw(t *testing.T) {
+	heights := []float32{-1, 0, 1, 0.5, -0.5, 0}
+
+	var buf bytes.Buffer
+	assert.NoError(t, Export(&buf, RawFloat32, heights, 3, 2))
+	assert.Equal(t, 24, buf.Len())
+
+	out := make([]float32, 6)
+	assert.NoError(t, binary.Read(&buf, binary.LittleEndian, out))
+	assert.Equal(t, heights, out)
+}
+
+func TestExportErrors(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Error(t, Export(&buf, PNG16, []float32{1, 2}, 2, 2))
+	assert.Error(t, Export(&buf, Format(99), []float32{1, 2}, 2, 1))
+
+	_, err := ExportTiles(t.TempDir(), "map", PNG16, []float32{1, 2, 3, 4}, 2, 2, 0)
+	assert.Error(t, err)
+
+	_, err = ExportTiles(filepath.Join(t.TempDir(), "missing"), "map", PNG16, []float32{1, 2, 3, 4}, 2, 2, 1)
+	assert.Error(t, err)
+}
+
+func TestExportTiles(t *testing.T) {
+	const size = 9
+	heights := make([]float32, size*size)
+	for i := range heights {
+		heights[i] = float32(i)
+	}
+
+	dir := t.TempDir()
+	paths, err := ExportTiles(dir, "map", RawFloat32, heights, size, size, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "map_x0_y0.r32"),
+		filepath.Join(dir, "map_x1_y0.r32"),
+		filepath.Join(dir, "map_x0_y1.r32"),
+		filepath.Join(dir, "map_x1_y1.r32"),
+	}, paths)
+
+	// Each tile is 5×5 and shares its border with its neighbours
+	data, err := os.ReadFile(paths[3])
+	assert.NoError(t, err)
+	assert.Equal(t, 5*5*4, len(data))
+
+	tile := make([]float32, 25)
+	assert.NoError(t, binary.Read(bytes.NewReader(data), binary.LittleEndian, tile))
+	assert.Equal(t, float32(4*size+4), tile[0])
+	assert.Equal(t, float32(size*size-1), tile[24])
+
+	// PNG tiles are normalized over the whole map
+	paths, err = ExportTiles(dir, "map", PNG16, heights, size, size, 4)
+	assert.NoError(t, err)
+
+	file, err := os.Open(paths[0])
+	assert.NoError(t, err)
+	defer file.Close()
+
+	img, err := png.Decode(file)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(0), img.(*image.Gray16).Gray16At(0, 0).Y)
+	assert.Less(t, img.(*image.Gray16).Gray16At(4, 4).Y, uint16(65535))
+}
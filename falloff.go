@@ -0,0 +1,62 @@
+package noise
+
+import "math"
+
+// ---------------------------------- Falloff Masks ----------------------------------
+
+// FalloffRadial returns a w×h mask in row-major order that is 1 at the center of the
+// map and falls off with the distance to it, reaching 0 at the edge midpoints and
+// staying 0 in the corners. A larger power widens the plateau and sharpens the
+// falloff. Multiplying or averaging a heightmap with it yields a circular island.
+//
+// Example:
+//
+//	mask := FalloffRadial(512, 512, 1.5)
+//	for i := range heights {
+//	    heights[i] = (heights[i] + mask[i]) / 2
+//	}
+func FalloffRadial(w, h int, power float32) []float32 {
+	return falloff(w, h, power, func(dx, dy float64) float64 {
+		return math.Sqrt(dx*dx + dy*dy)
+	})
+}
+
+// FalloffSquare returns a w×h mask in row-major order that is 1 at the center of the
+// map and falls off towards its edges, reaching 0 on all four sides. Unlike the
+// radial mask, it uses the whole map, which suits square islands and map borders.
+func FalloffSquare(w, h int, power float32) []float32 {
+	return falloff(w, h, power, func(dx, dy float64) float64 {
+		return max(math.Abs(dx), math.Abs(dy))
+	})
+}
+
+// FalloffCoast returns a radial w×h mask whose distance is perturbed by fBM noise
+// derived from the seed, which produces an irregular coastline with bays and capes
+// instead of a perfect circle. Roughness scales the perturbation, 0.3 is a good
+// starting point.
+func FalloffCoast(seed uint32, w, h int, power, roughness float32) []float32 {
+	f := NewFBM(seed)
+	return falloff(w, h, power, func(dx, dy float64) float64 {
+		d := math.Sqrt(dx*dx + dy*dy)
+		n := f.Eval2(float32(dx*2), float32(dy*2))
+		return max(d+float64(roughness)*float64(n), 0)
+	})
+}
+
+// falloff builds a mask from a distance function over [-1, 1] × [-1, 1]
+func falloff(w, h int, power float32, dist func(dx, dy float64) float64) []float32 {
+	if w <= 0 || h <= 0 {
+		return nil
+	}
+
+	out := make([]float32, w*h)
+	for y := 0; y < h; y++ {
+		dy := (float64(y)/float64(h) - 0.5) * 2
+		for x := 0; x < w; x++ {
+			dx := (float64(x)/float64(w) - 0.5) * 2
+			d := math.Pow(dist(dx, dy), float64(power))
+			out[y*w+x] = float32(min(max(1-d, 0), 1))
+		}
+	}
+	return out
+}
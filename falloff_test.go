@@ -0,0 +1,38 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFalloff(t *testing.T) {
+	const w, h = 64, 64
+	for name, mask := range map[string][]float32{
+		"radial": FalloffRadial(w, h, 1.5),
+		"square": FalloffSquare(w, h, 2),
+		"coast":  FalloffCoast(42, w, h, 1.5, 0.3),
+	} {
+		assert.Len(t, mask, w*h, name)
+		for _, v := range mask {
+			assert.True(t, v >= 0 && v <= 1, name)
+		}
+
+		// High in the center, zero at the edges
+		assert.Greater(t, mask[h/2*w+w/2], float32(0.8), name)
+		assert.Equal(t, float32(0), mask[0], name)
+	}
+
+	// The radial mask is symmetric, the square one reaches the corners last
+	radial := FalloffRadial(w, h, 1)
+	assert.Equal(t, radial[10*w+20], radial[20*w+10])
+
+	square := FalloffSquare(w, h, 1)
+	assert.Greater(t, square[4*w+32], float32(0))
+	assert.Equal(t, float32(0), radial[4*w+4])
+
+	// The coast is irregular but deterministic
+	assert.Equal(t, FalloffCoast(42, w, h, 1.5, 0.3), FalloffCoast(42, w, h, 1.5, 0.3))
+	assert.NotEqual(t, FalloffCoast(42, w, h, 1.5, 0.3), FalloffRadial(w, h, 1.5))
+	assert.Nil(t, FalloffRadial(0, 10, 1))
+}
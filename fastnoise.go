@@ -0,0 +1,502 @@
+package noise
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ---------------------------------- FastNoiseLite ----------------------------------
+
+// FastNoiseLite reproduces the OpenSimplex2 and Cellular noise of FastNoiseLite for
+// the same seed and settings, so that worlds authored with it can be generated in Go.
+// Either noise type is supported alone or as an FBm fractal with no domain rotation.
+// Cellular noise jitters its feature points with the RandVecs2D and RandVecs3D
+// tables of the library, which are loaded from its source with
+// ParseFastNoiseLiteVectors.
+type FastNoiseLite struct {
+	Seed             int32              // Seed of the noise, 1337 by default in FastNoiseLite
+	Frequency        float32            // Frequency of the noise, default 0.01
+	Octaves          int                // Number of FBm octaves, 1 for no fractal
+	Lacunarity       float32            // Frequency multiplier between octaves, default 2
+	Gain             float32            // Amplitude multiplier between octaves, default 0.5
+	WeightedStrength float32            // Octave weighting by the previous octave, default 0
+	Cellular         *FastNoiseCellular // Cellular settings, or nil for OpenSimplex2 noise
+}
+
+// NewFastNoiseLite creates a generator with the defaults of FastNoiseLite, which
+// matches a FastNoiseLite instance after SetSeed(seed) and SetNoiseType(OpenSimplex2).
+//
+// Example:
+//
+//	fnl := NewFastNoiseLite(1337)
+//	fnl.Octaves = 5 // SetFractalType(FBm) and SetFractalOctaves(5)
+//	v := fnl.Eval2(x, y)
+func NewFastNoiseLite(seed int32) *FastNoiseLite {
+	return &FastNoiseLite{
+		Seed:       seed,
+		Frequency:  0.01,
+		Octaves:    1,
+		Lacunarity: 2,
+		Gain:       0.5,
+	}
+}
+
+// Eval2 evaluates the noise at (x, y), like GetNoise(x, y)
+func (f *FastNoiseLite) Eval2(x, y float32) float32 {
+	x = float32(x * f.Frequency)
+	y = float32(y * f.Frequency)
+
+	// Skew the input space onto the simplex lattice, which cellular noise does not use
+	single := fnlSimplex2
+	if f.Cellular != nil {
+		single = f.Cellular.eval2
+	} else {
+		t := float32((x + y) * fnlF2)
+		x += t
+		y += t
+	}
+
+	if f.Octaves <= 1 {
+		return single(f.Seed, x, y)
+	}
+
+	seed, sum, amp := f.Seed, float32(0), f.bounding()
+	for i := 0; i < f.Octaves; i++ {
+		v := single(seed, x, y)
+		seed++
+		sum += float32(v * amp)
+		amp *= f.weight(v)
+		x *= f.Lacunarity
+		y *= f.Lacunarity
+		amp *= f.Gain
+	}
+	return sum
+}
+
+// Eval3 evaluates the noise at (x, y, z), like GetNoise(x, y, z)
+func (f *FastNoiseLite) Eval3(x, y, z float32) float32 {
+	x = float32(x * f.Frequency)
+	y = float32(y * f.Frequency)
+	z = float32(z * f.Frequency)
+
+	// Rotate the input space so the lattice is not aligned with the axes, as only
+	// OpenSimplex2 does by default
+	single := fnlSimplex3
+	if f.Cellular != nil {
+		single = f.Cellular.eval3
+	} else {
+		r := float32((x + y + z) * fnlR3)
+		x, y, z = r-x, r-y, r-z
+	}
+
+	if f.Octaves <= 1 {
+		return single(f.Seed, x, y, z)
+	}
+
+	seed, sum, amp := f.Seed, float32(0), f.bounding()
+	for i := 0; i < f.Octaves; i++ {
+		v := single(seed, x, y, z)
+		seed++
+		sum += float32(v * amp)
+		amp *= f.weight(v)
+		x *= f.Lacunarity
+		y *= f.Lacunarity
+		z *= f.Lacunarity
+		amp *= f.Gain
+	}
+	return sum
+}
+
+// bounding returns the amplitude of the first octave, such that the amplitudes of
+// all octaves sum to 1
+func (f *FastNoiseLite) bounding() float32 {
+	gain := float32(math.Abs(float64(f.Gain)))
+	amp, total := gain, float32(1)
+	for i := 1; i < f.Octaves; i++ {
+		total += amp
+		amp *= gain
+	}
+	return 1 / total
+}
+
+// weight returns the factor applied to the amplitude of the next octave, which
+// lowers it in the valleys of the current one when weighting is enabled
+func (f *FastNoiseLite) weight(v float32) float32 {
+	return 1 + float32(f.WeightedStrength*(float32(min(v+1, 2)*0.5)-1))
+}
+
+// Constants of FastNoiseLite, computed in single precision as the library does
+var (
+	fnlSqrt3 = float32(1.7320508075688772935274463415059)
+	fnlF2    = 0.5 * (fnlSqrt3 - 1)
+	fnlG2    = (3 - fnlSqrt3) / 6
+	fnlR3    = float32(2.0 / 3.0)
+	fnlC2    = float32(2 * (1 - 2*fnlG2) * (1/fnlG2 - 2))
+	fnlC2a   = float32(-2 * (1 - 2*fnlG2) * (1 - 2*fnlG2))
+	fnlGrad2 = func() (g [256]float32) {
+		for i := 0; i < 128; i++ {
+			a := (82.5 - 15*float64(i%24)) * math.Pi / 180
+			g[2*i], g[2*i+1] = float32(math.Cos(a)), float32(math.Sin(a))
+		}
+		return
+	}()
+)
+
+// fnlGrad3 holds the 3D gradients of FastNoiseLite, the 12 edges of a cube padded to 4 components
+var fnlGrad3 = func() (g [256]float32) {
+	edges := [][4]float32{
+		{0, 1, 1, 0}, {0, -1, 1, 0}, {0, 1, -1, 0}, {0, -1, -1, 0},
+		{1, 0, 1, 0}, {-1, 0, 1, 0}, {1, 0, -1, 0}, {-1, 0, -1, 0},
+		{1, 1, 0, 0}, {-1, 1, 0, 0}, {1, -1, 0, 0}, {-1, -1, 0, 0},
+	}
+	for i := 0; i < 60; i++ {
+		copy(g[4*i:], edges[i%12][:])
+	}
+	for i, e := range [][4]float32{{1, 1, 0, 0}, {0, -1, 1, 0}, {-1, 1, 0, 0}, {0, -1, -1, 0}} {
+		copy(g[4*(60+i):], e[:])
+	}
+	return
+}()
+
+// Primes used to hash lattice coordinates
+const (
+	fnlPrimeX int32 = 501125321
+	fnlPrimeY int32 = 1136930381
+	fnlPrimeZ int32 = 1720413743
+)
+
+// fnlFloor matches FastFloor, which rounds negative integers down by one more
+func fnlFloor(f float32) int32 {
+	if f >= 0 {
+		return int32(f)
+	}
+	return int32(f) - 1
+}
+
+// fnlRound matches FastRound, rounding halves away from zero
+func fnlRound(f float32) int32 {
+	if f >= 0 {
+		return int32(f + 0.5)
+	}
+	return int32(f - 0.5)
+}
+
+// fnlGradCoord2 returns the dot product of a hashed gradient and the offset
+func fnlGradCoord2(seed, x, y int32, dx, dy float32) float32 {
+	hash := (seed ^ x ^ y) * 0x27d4eb2d
+	hash ^= hash >> 15
+	hash &= 127 << 1
+	return float32(dx*fnlGrad2[hash]) + float32(dy*fnlGrad2[hash|1])
+}
+
+// fnlGradCoord3 returns the dot product of a hashed gradient and the offset
+func fnlGradCoord3(seed, x, y, z int32, dx, dy, dz float32) float32 {
+	hash := (seed ^ x ^ y ^ z) * 0x27d4eb2d
+	hash ^= hash >> 15
+	hash &= 63 << 2
+	return float32(dx*fnlGrad3[hash]) + float32(dy*fnlGrad3[hash|1]) + float32(dz*fnlGrad3[hash|2])
+}
+
+// fnlSimplex2 evaluates 2D OpenSimplex2 at skewed coordinates
+func fnlSimplex2(seed int32, x, y float32) float32 {
+	i, j := fnlFloor(x), fnlFloor(y)
+	xi, yi := x-float32(i), y-float32(j)
+
+	t := float32((xi + yi) * fnlG2)
+	x0, y0 := xi-t, yi-t
+	i *= fnlPrimeX
+	j *= fnlPrimeY
+
+	var n0, n1, n2 float32
+	a := 0.5 - float32(x0*x0) - float32(y0*y0)
+	if a > 0 {
+		n0 = (a * a) * (a * a) * fnlGradCoord2(seed, i, j, x0, y0)
+	}
+
+	if c := float32(fnlC2*t) + (fnlC2a + a); c > 0 {
+		x2, y2 := x0+(2*fnlG2-1), y0+(2*fnlG2-1)
+		n2 = (c * c) * (c * c) * fnlGradCoord2(seed, i+fnlPrimeX, j+fnlPrimeY, x2, y2)
+	}
+
+	if y0 > x0 {
+		x1, y1 := x0+fnlG2, y0+(fnlG2-1)
+		if b := 0.5 - float32(x1*x1) - float32(y1*y1); b > 0 {
+			n1 = (b * b) * (b * b) * fnlGradCoord2(seed, i, j+fnlPrimeY, x1, y1)
+		}
+	} else {
+		x1, y1 := x0+(fnlG2-1), y0+fnlG2
+		if b := 0.5 - float32(x1*x1) - float32(y1*y1); b > 0 {
+			n1 = (b * b) * (b * b) * fnlGradCoord2(seed, i+fnlPrimeX, j, x1, y1)
+		}
+	}
+
+	return (n0 + n1 + n2) * 99.83685446303647
+}
+
+// fnlSimplex3 evaluates 3D OpenSimplex2 at rotated coordinates, over two offset
+// cubic lattices
+func fnlSimplex3(seed int32, x, y, z float32) float32 {
+	i, j, k := fnlRound(x), fnlRound(y), fnlRound(z)
+	x0, y0, z0 := x-float32(i), y-float32(j), z-float32(k)
+
+	xs, ys, zs := int32(-1-x0)|1, int32(-1-y0)|1, int32(-1-z0)|1
+	ax0, ay0, az0 := float32(xs)*-x0, float32(ys)*-y0, float32(zs)*-z0
+	i *= fnlPrimeX
+	j *= fnlPrimeY
+	k *= fnlPrimeZ
+
+	var value float32
+	a := (0.6 - float32(x0*x0)) - (float32(y0*y0) + float32(z0*z0))
+	for l := 0; ; l++ {
+		if a > 0 {
+			value += float32((a * a) * (a * a) * fnlGradCoord3(seed, i, j, k, x0, y0, z0))
+		}
+
+		switch {
+		case ax0 >= ay0 && ax0 >= az0:
+			if b := a + ax0 + ax0; b > 1 {
+				b -= 1
+				value += float32((b * b) * (b * b) * fnlGradCoord3(seed, i-xs*fnlPrimeX, j, k, x0+float32(xs), y0, z0))
+			}
+		case ay0 > ax0 && ay0 >= az0:
+			if b := a + ay0 + ay0; b > 1 {
+				b -= 1
+				value += float32((b * b) * (b * b) * fnlGradCoord3(seed, i, j-ys*fnlPrimeY, k, x0, y0+float32(ys), z0))
+			}
+		default:
+			if b := a + az0 + az0; b > 1 {
+				b -= 1
+				value += float32((b * b) * (b * b) * fnlGradCoord3(seed, i, j, k-zs*fnlPrimeZ, x0, y0, z0+float32(zs)))
+			}
+		}
+
+		if l == 1 {
+			break
+		}
+
+		// Move to the second lattice, offset by half a cell
+		ax0, ay0, az0 = 0.5-ax0, 0.5-ay0, 0.5-az0
+		x0, y0, z0 = float32(xs)*ax0, float32(ys)*ay0, float32(zs)*az0
+		a += (0.75 - ax0) - (ay0 + az0)
+		i += (xs >> 1) & fnlPrimeX
+		j += (ys >> 1) & fnlPrimeY
+		k += (zs >> 1) & fnlPrimeZ
+		xs, ys, zs = -xs, -ys, -zs
+		seed = ^seed
+	}
+
+	return value * 32.69428253173828125
+}
+
+// ---------------------------------- Cellular ----------------------------------
+
+// FastNoiseDistanceFunc is the distance function of FastNoiseLite cellular noise,
+// like CellularDistanceFunction
+type FastNoiseDistanceFunc int
+
+const (
+	FastNoiseEuclidean   FastNoiseDistanceFunc = iota // Euclidean distance
+	FastNoiseEuclideanSq                              // Squared Euclidean distance, the default
+	FastNoiseManhattan                                // Sum of the absolute differences
+	FastNoiseHybrid                                   // Sum of Manhattan and squared Euclidean
+)
+
+// FastNoiseReturnType is the value returned by FastNoiseLite cellular noise, like
+// CellularReturnType
+type FastNoiseReturnType int
+
+const (
+	FastNoiseCellValue    FastNoiseReturnType = iota // Random value of the closest cell
+	FastNoiseDistance                                // Distance to the closest point, the default
+	FastNoiseDistance2                               // Distance to the second closest point
+	FastNoiseDistance2Add                            // Mean of both distances
+	FastNoiseDistance2Sub                            // Difference of both distances
+	FastNoiseDistance2Mul                            // Half the product of both distances
+	FastNoiseDistance2Div                            // Ratio of both distances
+)
+
+// FastNoiseCellular holds the settings of the Cellular noise type of FastNoiseLite,
+// along with its random vector tables
+type FastNoiseCellular struct {
+	Distance FastNoiseDistanceFunc // Distance function, default FastNoiseEuclideanSq
+	Return   FastNoiseReturnType   // Returned value, default FastNoiseDistance
+	Jitter   float32               // Displacement of the points within their cells, default 1
+	vectors2 []float32             // RandVecs2D, 2 components each
+	vectors3 []float32             // RandVecs3D, 4 components each
+}
+
+// NewFastNoiseCellular creates cellular settings with the defaults of FastNoiseLite,
+// using the 256 vectors of its RandVecs2D and RandVecs3D tables. It panics if the
+// tables do not hold 512 and 1024 values.
+//
+// Example:
+//
+//	src, _ := os.ReadFile("FastNoiseLite/C/FastNoiseLite.h")
+//	vec2, vec3, _ := ParseFastNoiseLiteVectors(string(src))
+//	fnl := NewFastNoiseLite(1337)
+//	fnl.Cellular = NewFastNoiseCellular(vec2, vec3) // SetNoiseType(Cellular)
+//	v := fnl.Eval2(x, y)
+func NewFastNoiseCellular(vectors2, vectors3 []float32) *FastNoiseCellular {
+	if len(vectors2) != 512 || len(vectors3) != 1024 {
+		panic("noise: cellular noise needs the 512 values of RandVecs2D and 1024 of RandVecs3D")
+	}
+
+	return &FastNoiseCellular{
+		Distance: FastNoiseEuclideanSq,
+		Return:   FastNoiseDistance,
+		Jitter:   1,
+		vectors2: vectors2,
+		vectors3: vectors3,
+	}
+}
+
+// fnlVectors matches the initializers of the random vector tables in any port of
+// FastNoiseLite, named RAND_VECS_2D in C and RandVecs2D elsewhere
+var fnlVectors = regexp.MustCompile(`(?:RandVecs|RAND_VECS_)([23])D[^{;]*\{([^}]*)\}`)
+
+// ParseFastNoiseLiteVectors extracts the RandVecs2D and RandVecs3D tables from the
+// source of FastNoiseLite, such as FastNoiseLite.h
+func ParseFastNoiseLiteVectors(src string) (vectors2, vectors3 []float32, err error) {
+	for _, match := range fnlVectors.FindAllStringSubmatch(src, -1) {
+		var out []float32
+		for _, field := range strings.Split(match[2], ",") {
+			if field = strings.TrimSuffix(strings.TrimSpace(field), "f"); field == "" {
+				continue
+			}
+
+			v, err := strconv.ParseFloat(field, 32)
+			if err != nil {
+				return nil, nil, err
+			}
+			out = append(out, float32(v))
+		}
+
+		switch {
+		case match[1] == "2" && vectors2 == nil:
+			vectors2 = out
+		case match[1] == "3" && vectors3 == nil:
+			vectors3 = out
+		}
+	}
+
+	switch {
+	case len(vectors2) != 512:
+		return nil, nil, fmt.Errorf("noise: RandVecs2D has %d values, expected 512", len(vectors2))
+	case len(vectors3) != 1024:
+		return nil, nil, fmt.Errorf("noise: RandVecs3D has %d values, expected 1024", len(vectors3))
+	default:
+		return vectors2, vectors3, nil
+	}
+}
+
+// eval2 evaluates 2D cellular noise over the 3×3 cells around (x, y), like
+// SingleCellular
+func (c *FastNoiseCellular) eval2(seed int32, x, y float32) float32 {
+	xr, yr := fnlRound(x), fnlRound(y)
+	d0, d1 := float32(math.MaxFloat32), float32(math.MaxFloat32)
+	closest := int32(0)
+	jitter := 0.43701595 * c.Jitter
+
+	xPrimed := (xr - 1) * fnlPrimeX
+	for xi := xr - 1; xi <= xr+1; xi++ {
+		yPrimed := (yr - 1) * fnlPrimeY
+		for yi := yr - 1; yi <= yr+1; yi++ {
+			hash := (seed ^ xPrimed ^ yPrimed) * 0x27d4eb2d
+			idx := hash & (255 << 1)
+
+			vx := (float32(xi) - x) + float32(c.vectors2[idx]*jitter)
+			vy := (float32(yi) - y) + float32(c.vectors2[idx|1]*jitter)
+			d := c.distance(vx, vy, 0)
+
+			d1 = max(min(d1, d), d0)
+			if d < d0 {
+				d0, closest = d, hash
+			}
+			yPrimed += fnlPrimeY
+		}
+		xPrimed += fnlPrimeX
+	}
+
+	return c.result(d0, d1, closest)
+}
+
+// eval3 evaluates 3D cellular noise over the 3×3×3 cells around (x, y, z), like
+// SingleCellular
+func (c *FastNoiseCellular) eval3(seed int32, x, y, z float32) float32 {
+	xr, yr, zr := fnlRound(x), fnlRound(y), fnlRound(z)
+	d0, d1 := float32(math.MaxFloat32), float32(math.MaxFloat32)
+	closest := int32(0)
+	jitter := 0.39614353 * c.Jitter
+
+	xPrimed := (xr - 1) * fnlPrimeX
+	for xi := xr - 1; xi <= xr+1; xi++ {
+		yPrimed := (yr - 1) * fnlPrimeY
+		for yi := yr - 1; yi <= yr+1; yi++ {
+			zPrimed := (zr - 1) * fnlPrimeZ
+			for zi := zr - 1; zi <= zr+1; zi++ {
+				hash := (seed ^ xPrimed ^ yPrimed ^ zPrimed) * 0x27d4eb2d
+				idx := hash & (255 << 2)
+
+				vx := (float32(xi) - x) + float32(c.vectors3[idx]*jitter)
+				vy := (float32(yi) - y) + float32(c.vectors3[idx|1]*jitter)
+				vz := (float32(zi) - z) + float32(c.vectors3[idx|2]*jitter)
+				d := c.distance(vx, vy, vz)
+
+				d1 = max(min(d1, d), d0)
+				if d < d0 {
+					d0, closest = d, hash
+				}
+				zPrimed += fnlPrimeZ
+			}
+			yPrimed += fnlPrimeY
+		}
+		xPrimed += fnlPrimeX
+	}
+
+	return c.result(d0, d1, closest)
+}
+
+// distance returns the distance of a feature point at the offset, with the square
+// root of the Euclidean distance deferred to result
+func (c *FastNoiseCellular) distance(vx, vy, vz float32) float32 {
+	switch c.Distance {
+	case FastNoiseManhattan:
+		return abs(vx) + abs(vy) + abs(vz)
+	case FastNoiseHybrid:
+		return (abs(vx) + abs(vy) + abs(vz)) + (float32(vx*vx) + float32(vy*vy) + float32(vz*vz))
+	default:
+		return float32(vx*vx) + float32(vy*vy) + float32(vz*vz)
+	}
+}
+
+// result combines the distances to the two closest points into the return type
+func (c *FastNoiseCellular) result(d0, d1 float32, closest int32) float32 {
+	if c.Distance == FastNoiseEuclidean && c.Return >= FastNoiseDistance {
+		d0 = float32(math.Sqrt(float64(d0)))
+		if c.Return >= FastNoiseDistance2 {
+			d1 = float32(math.Sqrt(float64(d1)))
+		}
+	}
+
+	switch c.Return {
+	case FastNoiseCellValue:
+		return float32(closest) * (1 / 2147483648.0)
+	case FastNoiseDistance:
+		return d0 - 1
+	case FastNoiseDistance2:
+		return d1 - 1
+	case FastNoiseDistance2Add:
+		return float32((d1+d0)*0.5) - 1
+	case FastNoiseDistance2Sub:
+		return d1 - d0 - 1
+	case FastNoiseDistance2Mul:
+		return float32(d1*d0*0.5) - 1
+	case FastNoiseDistance2Div:
+		return d0/d1 - 1
+	default:
+		return 0
+	}
+}
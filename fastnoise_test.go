@@ -0,0 +1,227 @@
+package noise
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFastNoiseLite(t *testing.T) {
+	f := NewFastNoiseLite(1337)
+	assert.Equal(t, float32(0), f.Eval2(0, 0))
+	assert.Equal(t, float32(0), f.Eval3(0, 0, 0))
+
+	for _, eval := range []func(x, y float32) float32{
+		f.Eval2,
+		func(x, y float32) float32 { return f.Eval3(x, y, 17) },
+	} {
+		lo, hi := float32(1), float32(-1)
+		for y := 0; y < 128; y++ {
+			for x := 0; x < 128; x++ {
+				v := eval(float32(x)*3.7, float32(y)*3.7)
+				lo, hi = min(lo, v), max(hi, v)
+
+				// Continuous at a scale far below the frequency
+				assert.InDelta(t, v, eval(float32(x)*3.7+0.01, float32(y)*3.7), 0.01)
+			}
+		}
+
+		assert.GreaterOrEqual(t, lo, float32(-1))
+		assert.LessOrEqual(t, hi, float32(1))
+		assert.Less(t, lo, float32(-0.6))
+		assert.Greater(t, hi, float32(0.6))
+	}
+
+	// Seeds pick among 128 gradients only, so distinct seeds rarely match at a point
+	same, other := NewFastNoiseLite(1337), NewFastNoiseLite(1338)
+	matches := 0
+	for i := 0; i < 100; i++ {
+		x, y := float32(i)*7.3, float32(i)*-3.1
+		assert.Equal(t, f.Eval2(x, y), same.Eval2(x, y))
+		if f.Eval2(x, y) == other.Eval2(x, y) || f.Eval3(x, y, 3) == other.Eval3(x, y, 3) {
+			matches++
+		}
+	}
+	assert.Less(t, matches, 5)
+}
+
+func TestFastNoiseLiteFractal(t *testing.T) {
+	single := NewFastNoiseLite(42)
+	fbm := NewFastNoiseLite(42)
+	fbm.Octaves = 5
+
+	// A single octave of FBm is the noise itself, and octaves use successive seeds
+	one := NewFastNoiseLite(42)
+	one.Octaves = 1
+	assert.Equal(t, single.Eval2(31, 7), one.Eval2(31, 7))
+	assert.NotEqual(t, single.Eval2(31, 7), fbm.Eval2(31, 7))
+
+	two := NewFastNoiseLite(42)
+	two.Octaves, two.Gain = 2, 0
+	assert.Equal(t, single.Eval3(31, 7, 2), two.Eval3(31, 7, 2))
+
+	for i := 0; i < 1000; i++ {
+		v := fbm.Eval2(float32(i)*13.1, float32(i)*-7.3)
+		assert.GreaterOrEqual(t, v, float32(-1))
+		assert.LessOrEqual(t, v, float32(1))
+	}
+
+	// Weighting dampens the octaves above the valleys of the previous ones
+	weighted := NewFastNoiseLite(42)
+	weighted.Octaves, weighted.WeightedStrength = 5, 1
+	assert.NotEqual(t, fbm.Eval2(31, 7), weighted.Eval2(31, 7))
+}
+
+func TestFastNoiseLiteGolden(t *testing.T) {
+	single := NewFastNoiseLite(1337)
+	fbm := NewFastNoiseLite(1337)
+	fbm.Octaves = 5
+
+	// Output bits of the port on every architecture, pinned so that any drift of the
+	// noise is caught. They are not captured from the reference library.
+	for _, tc := range []struct {
+		x, y, z                  float32
+		eval2, eval3, fbm2, fbm3 uint32
+	}{
+		{0.5, 0.25, 0, 0x3d007437, 0x3c2d84cb, 0x3d2d0f27, 0xbba477b7},
+		{123.4, -56.7, 8.9, 0xbf3a1d4c, 0x3ed9790e, 0xbf15ba44, 0x3e8b4e5a},
+		{-1000, 2000, -300, 0x3f379ea7, 0x3f630606, 0x3f07eb7a, 0x3e907abd},
+	} {
+		assert.Equal(t, tc.eval2, math.Float32bits(single.Eval2(tc.x, tc.y)))
+		assert.Equal(t, tc.eval3, math.Float32bits(single.Eval3(tc.x, tc.y, tc.z)))
+		assert.Equal(t, tc.fbm2, math.Float32bits(fbm.Eval2(tc.x, tc.y)))
+		assert.Equal(t, tc.fbm3, math.Float32bits(fbm.Eval3(tc.x, tc.y, tc.z)))
+	}
+}
+
+func TestFastNoiseLiteTables(t *testing.T) {
+	assert.Equal(t, int32(-2), fnlFloor(-1))
+	assert.Equal(t, int32(1), fnlFloor(1.5))
+	assert.Equal(t, int32(-2), fnlRound(-1.5))
+	assert.Equal(t, int32(2), fnlRound(1.5))
+
+	assert.InDelta(t, 0.130526192220052, fnlGrad2[0], 1e-7)
+	assert.InDelta(t, 0.99144486137381, fnlGrad2[1], 1e-7)
+	for i := 0; i < 128; i++ {
+		assert.InDelta(t, 1, math.Hypot(float64(fnlGrad2[2*i]), float64(fnlGrad2[2*i+1])), 1e-6)
+		assert.Equal(t, fnlGrad2[2*(i%24)], fnlGrad2[2*i])
+	}
+
+	for i := 0; i < 64; i++ {
+		g := fnlGrad3[4*i : 4*i+4]
+		assert.Equal(t, float32(2), g[0]*g[0]+g[1]*g[1]+g[2]*g[2])
+		assert.Equal(t, float32(0), g[3])
+	}
+}
+
+// fnlVectorsSource returns a stand-in for the random vector tables, made of
+// deterministic unit vectors, formatted like the C++ header of FastNoiseLite
+func fnlVectorsSource() string {
+	var sb strings.Builder
+	sb.WriteString("static const T RandVecs2D[];\nstatic const T RandVecs3D[];\n")
+	sb.WriteString("const T Lookup<T>::RandVecs2D[] =\n{\n")
+	for i := 0; i < 256; i++ {
+		a := 2 * math.Pi * Float64(1, uint64(i))
+		fmt.Fprintf(&sb, "    %vf, %vf,\n", float32(math.Cos(a)), float32(math.Sin(a)))
+	}
+	sb.WriteString("};\nconst T Lookup<T>::RandVecs3D[] =\n{\n")
+	for i := 0; i < 256; i++ {
+		theta := 2 * math.Pi * Float64(1, uint64(i))
+		z := float64(2*Float64(2, uint64(i))) - 1
+		r := math.Sqrt(1 - float64(z*z))
+		fmt.Fprintf(&sb, "    %vf, %vf, %vf, 0,\n", float32(r*math.Cos(theta)), float32(r*math.Sin(theta)), float32(z))
+	}
+	sb.WriteString("};\n")
+	return sb.String()
+}
+
+// fnlCellular returns cellular noise at a unit frequency over the stand-in tables
+func fnlCellular(t *testing.T, seed int32) *FastNoiseLite {
+	vec2, vec3, err := ParseFastNoiseLiteVectors(fnlVectorsSource())
+	assert.NoError(t, err)
+
+	f := NewFastNoiseLite(seed)
+	f.Frequency = 1
+	f.Cellular = NewFastNoiseCellular(vec2, vec3)
+	return f
+}
+
+func TestFastNoiseLiteCellular(t *testing.T) {
+	f := fnlCellular(t, 1337)
+	assert.Equal(t, FastNoiseEuclideanSq, f.Cellular.Distance)
+	assert.Equal(t, FastNoiseDistance, f.Cellular.Return)
+	assert.Equal(t, float32(1), f.Cellular.Jitter)
+
+	// Without jitter, the feature points are the lattice points, so the closest ones
+	// to (0.25, 0.4) are (0, 0) then (0, 1)
+	f.Cellular.Jitter = 0
+	for _, tc := range []struct {
+		dist   FastNoiseDistanceFunc
+		ret    FastNoiseReturnType
+		expect float64
+	}{
+		{FastNoiseEuclideanSq, FastNoiseDistance, 0.2225 - 1},
+		{FastNoiseEuclideanSq, FastNoiseDistance2, 0.4225 - 1},
+		{FastNoiseEuclideanSq, FastNoiseDistance2Add, (0.2225+0.4225)/2 - 1},
+		{FastNoiseEuclideanSq, FastNoiseDistance2Sub, 0.4225 - 0.2225 - 1},
+		{FastNoiseEuclideanSq, FastNoiseDistance2Mul, 0.4225*0.2225/2 - 1},
+		{FastNoiseEuclideanSq, FastNoiseDistance2Div, 0.2225/0.4225 - 1},
+		{FastNoiseEuclidean, FastNoiseDistance, math.Sqrt(0.2225) - 1},
+		{FastNoiseEuclidean, FastNoiseDistance2, math.Sqrt(0.4225) - 1},
+		{FastNoiseManhattan, FastNoiseDistance, 0.65 - 1},
+		{FastNoiseManhattan, FastNoiseDistance2, 0.85 - 1},
+		{FastNoiseHybrid, FastNoiseDistance, 0.65 + 0.2225 - 1},
+		{FastNoiseEuclideanSq, FastNoiseCellValue, float64(float32(117456389)) / (1 << 31)},
+	} {
+		f.Cellular.Distance, f.Cellular.Return = tc.dist, tc.ret
+		assert.InDelta(t, tc.expect, f.Eval2(0.25, 0.4), 1e-6)
+	}
+
+	f.Cellular.Distance, f.Cellular.Return = FastNoiseEuclideanSq, FastNoiseDistance
+	assert.InDelta(t, 0.2325-1, f.Eval3(0.25, 0.4, 0.1), 1e-6)
+
+	// Jittered points stay within range and vary with the seed
+	f.Cellular.Jitter = 1
+	other := fnlCellular(t, 1338)
+	for i := 0; i < 1000; i++ {
+		x, y := float32(i)*0.173, float32(i)*-0.291
+		for _, v := range []float32{f.Eval2(x, y), f.Eval3(x, y, 0.5)} {
+			assert.GreaterOrEqual(t, v, float32(-1))
+			assert.LessOrEqual(t, v, float32(1))
+		}
+	}
+	assert.NotEqual(t, f.Eval2(3.3, 4.4), other.Eval2(3.3, 4.4))
+	assert.NotEqual(t, f.Eval3(3.3, 4.4, 5.5), other.Eval3(3.3, 4.4, 5.5))
+
+	// A fractal of cellular noise uses successive seeds for its octaves
+	two := fnlCellular(t, 1337)
+	two.Octaves, two.Gain = 2, 0
+	assert.Equal(t, f.Eval2(3.3, 4.4), two.Eval2(3.3, 4.4))
+	assert.Equal(t, f.Eval3(3.3, 4.4, 5.5), two.Eval3(3.3, 4.4, 5.5))
+}
+
+func TestParseFastNoiseLiteVectors(t *testing.T) {
+	vec2, vec3, err := ParseFastNoiseLiteVectors(fnlVectorsSource())
+	assert.NoError(t, err)
+	assert.Len(t, vec2, 512)
+	assert.Len(t, vec3, 1024)
+	assert.InDelta(t, 1, math.Hypot(float64(vec2[0]), float64(vec2[1])), 1e-6)
+
+	// The C port names the tables differently
+	src := strings.ReplaceAll(fnlVectorsSource(), "RandVecs", "RAND_VECS_")
+	c2, c3, err := ParseFastNoiseLiteVectors(src)
+	assert.NoError(t, err)
+	assert.Equal(t, vec2, c2)
+	assert.Equal(t, vec3, c3)
+
+	_, _, err = ParseFastNoiseLiteVectors("")
+	assert.Error(t, err)
+	_, _, err = ParseFastNoiseLiteVectors("RandVecs2D[] = { 1, 2 }; RandVecs3D[] = { 1 };")
+	assert.Error(t, err)
+	_, _, err = ParseFastNoiseLiteVectors("RandVecs2D[] = { x };")
+	assert.Error(t, err)
+	assert.Panics(t, func() { NewFastNoiseCellular(vec2[:10], vec3) })
+}
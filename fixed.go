@@ -0,0 +1,201 @@
+package noise
+
+// Fixed is a signed Q16.16 fixed-point number, covering [-32768, 32768) with a
+// resolution of 1/65536.
+type Fixed int32
+
+// FixedOne is the value 1.0 in Q16.16
+const FixedOne Fixed = 1 << 16
+
+// ToFixed converts a float32 into Q16.16, rounding towards zero
+func ToFixed(v float32) Fixed {
+	return Fixed(v * float32(FixedOne))
+}
+
+// Float32 converts the Q16.16 value back into a float32
+func (f Fixed) Float32() float32 {
+	return float32(f) / float32(FixedOne)
+}
+
+// Integer gradients, mirroring grad2 and grad3
+var grad2i, grad3i = fixedGradients()
+
+// fixedGradients converts the shared gradient tables into integers
+func fixedGradients() (out2 [256][2]int64, out3 [256][3]int64) {
+	for i := 0; i < 256; i++ {
+		out2[i] = [2]int64{int64(grad2[i][0]), int64(grad2[i][1])}
+		out3[i] = [3]int64{int64(grad3[i][0]), int64(grad3[i][1]), int64(grad3[i][2])}
+	}
+	return
+}
+
+// ---------------------------------- Simplex Noise (fixed point) ----------------------------------
+
+// EvalFixed evaluates simplex noise at the given Q16.16 coordinates using integer
+// arithmetic only, returning a Q16.16 value in [-1, 1]. Supports 1D, 2D, and 3D
+// noise based on number of arguments. Unlike Eval, the result is bit-identical on
+// every architecture and compiler since no floating-point operation is involved,
+// which makes it suitable for lockstep simulations.
+//
+// Example:
+//
+//	x, y := noise.ToFixed(10.5), noise.ToFixed(20.25)
+//	v := s.EvalFixed(x, y) // Q16.16
+func (s *Simplex) EvalFixed(coords ...Fixed) Fixed {
+	switch len(coords) {
+	case 1:
+		return s.fixed2D(coords[0], 0)
+	case 2:
+		return s.fixed2D(coords[0], coords[1])
+	case 3:
+		return s.fixed3D(coords[0], coords[1], coords[2])
+	default:
+		panic("noise: simplex requires 1, 2, or 3 coordinates")
+	}
+}
+
+// Internally, offsets and contributions are carried in Q24 for extra precision
+const (
+	q24       = 24
+	q24Half   = 1 << (q24 - 1)      // 0.5
+	q24Radius = 10066330            // 0.6
+	q24G2     = (g2q + 1<<39) >> 40 // (3 - sqrt(3)) / 6
+	q24G3     = (g3q + 1<<39) >> 40 // 1 / 6
+)
+
+// fixed2D computes 2D simplex noise with integer arithmetic
+func (s *Simplex) fixed2D(fx, fy Fixed) Fixed {
+	x, y := int64(fx), int64(fy)
+
+	// Skew the input space to determine which simplex cell we're in
+	sk := mulFloor(x+y, f2q)
+	i := (x + sk) >> 16
+	j := (y + sk) >> 16
+
+	// Unskew the cell origin back to (x,y) space, in Q24
+	t := mulQ24(i+j, g2q)
+	x0 := (x-i<<16)<<8 + t
+	y0 := (y-j<<16)<<8 + t
+
+	// Determine which simplex (upper or lower triangle) we are in
+	i1, j1 := int64(0), int64(1)
+	if x0 > y0 {
+		i1, j1 = 1, 0
+	}
+
+	// Offsets for the middle and last corners
+	x1 := x0 - i1<<q24 + q24G2
+	y1 := y0 - j1<<q24 + q24G2
+	x2 := x0 - 1<<q24 + 2*q24G2
+	y2 := y0 - 1<<q24 + 2*q24G2
+
+	// Work out the hashed gradient indices of the three simplex corners
+	ii, jj := int(i&255), int(j&255)
+	g0 := grad2i[s.perm[ii+int(s.perm[jj])]]
+	g1 := grad2i[s.perm[ii+int(i1)+int(s.perm[jj+int(j1)])]]
+	g2 := grad2i[s.perm[ii+1+int(s.perm[jj+1])]]
+
+	// Calculate the contribution from the three corners
+	n := contribFixed(q24Half-sq24(x0)-sq24(y0), g0[0]*x0+g0[1]*y0)
+	n += contribFixed(q24Half-sq24(x1)-sq24(y1), g1[0]*x1+g1[1]*y1)
+	n += contribFixed(q24Half-sq24(x2)-sq24(y2), g2[0]*x2+g2[1]*y2)
+
+	// Scale to [-1,1] and convert from Q24 to Q16
+	return Fixed((70 * n) >> 8)
+}
+
+// fixed3D computes 3D simplex noise with integer arithmetic
+func (s *Simplex) fixed3D(fx, fy, fz Fixed) Fixed {
+	x, y, z := int64(fx), int64(fy), int64(fz)
+
+	// Skew the input space to determine which simplex cell we're in
+	sk := mulFloor(x+y+z, f3q)
+	i := (x + sk) >> 16
+	j := (y + sk) >> 16
+	k := (z + sk) >> 16
+
+	// Unskew the cell origin back to (x,y,z) space, in Q24
+	t := mulQ24(i+j+k, g3q)
+	x0 := (x-i<<16)<<8 + t
+	y0 := (y-j<<16)<<8 + t
+	z0 := (z-k<<16)<<8 + t
+
+	// Determine which of the six tetrahedra we are in
+	var i1, j1, k1, i2, j2, k2 int64
+	if x0 >= y0 {
+		switch {
+		case y0 >= z0:
+			i1, j1, k1, i2, j2, k2 = 1, 0, 0, 1, 1, 0
+		case x0 >= z0:
+			i1, j1, k1, i2, j2, k2 = 1, 0, 0, 1, 0, 1
+		default:
+			i1, j1, k1, i2, j2, k2 = 0, 0, 1, 1, 0, 1
+		}
+	} else {
+		switch {
+		case y0 < z0:
+			i1, j1, k1, i2, j2, k2 = 0, 0, 1, 0, 1, 1
+		case x0 < z0:
+			i1, j1, k1, i2, j2, k2 = 0, 1, 0, 0, 1, 1
+		default:
+			i1, j1, k1, i2, j2, k2 = 0, 1, 0, 1, 1, 0
+		}
+	}
+
+	// Offsets of the remaining corners
+	x1 := x0 - i1<<q24 + q24G3
+	y1 := y0 - j1<<q24 + q24G3
+	z1 := z0 - k1<<q24 + q24G3
+	x2 := x0 - i2<<q24 + 2*q24G3
+	y2 := y0 - j2<<q24 + 2*q24G3
+	z2 := z0 - k2<<q24 + 2*q24G3
+	x3 := x0 - 1<<q24 + 3*q24G3
+	y3 := y0 - 1<<q24 + 3*q24G3
+	z3 := z0 - 1<<q24 + 3*q24G3
+
+	// Work out the hashed gradient indices of the four simplex corners
+	ii, jj, kk := int(i&255), int(j&255), int(k&255)
+	gi0 := s.perm[ii+int(s.perm[jj+int(s.perm[kk])])] % 12
+	gi1 := s.perm[ii+int(i1)+int(s.perm[jj+int(j1)+int(s.perm[kk+int(k1)])])] % 12
+	gi2 := s.perm[ii+int(i2)+int(s.perm[jj+int(j2)+int(s.perm[kk+int(k2)])])] % 12
+	gi3 := s.perm[ii+1+int(s.perm[jj+1+int(s.perm[kk+1])])] % 12
+	g0, g1 := grad3i[s.grad3Of(gi0)], grad3i[s.grad3Of(gi1)]
+	g2, g3 := grad3i[s.grad3Of(gi2)], grad3i[s.grad3Of(gi3)]
+
+	// Calculate the contribution from the four corners
+	n := contribFixed(q24Radius-sq24(x0)-sq24(y0)-sq24(z0), g0[0]*x0+g0[1]*y0+g0[2]*z0)
+	n += contribFixed(q24Radius-sq24(x1)-sq24(y1)-sq24(z1), g1[0]*x1+g1[1]*y1+g1[2]*z1)
+	n += contribFixed(q24Radius-sq24(x2)-sq24(y2)-sq24(z2), g2[0]*x2+g2[1]*y2+g2[2]*z2)
+	n += contribFixed(q24Radius-sq24(x3)-sq24(y3)-sq24(z3), g3[0]*x3+g3[1]*y3+g3[2]*z3)
+
+	// Scale to [-1,1] and convert from Q24 to Q16
+	return Fixed((32 * n) >> 8)
+}
+
+// contribFixed returns t^4 * dot in Q24, or zero if the corner is out of reach
+func contribFixed(t, dot int64) int64 {
+	if t <= 0 {
+		return 0
+	}
+
+	t = sq24(t)
+	t = sq24(t)
+	return (t * dot) >> q24
+}
+
+// sq24 squares a Q24 value
+func sq24(v int64) int64 {
+	return (v * v) >> q24
+}
+
+// mulFloor multiplies n by a 0.64 fixed point factor, rounding towards -Inf
+func mulFloor(n int64, q uint64) int64 {
+	v, _ := mulFixedBits(n, q)
+	return v
+}
+
+// mulQ24 multiplies an integer by a 0.64 fixed point factor, returning a Q24 value
+func mulQ24(n int64, q uint64) int64 {
+	v, frac := mulFixedBits(n, q)
+	return v<<q24 + int64(frac>>(64-q24))
+}
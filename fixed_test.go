@@ -0,0 +1,49 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvalFixed(t *testing.T) {
+	s := NewSimplex(42)
+	for i := 0; i < 2000; i++ {
+		x := ToFixed(White(1, i) * 1000)
+		y := ToFixed(White(2, i) * 1000)
+		z := ToFixed(White(3, i) * 1000)
+
+		// Should closely track the floating-point implementation
+		assert.InDelta(t, s.Eval(x.Float32()), s.EvalFixed(x).Float32(), 2e-3)
+		assert.InDelta(t, s.Eval(x.Float32(), y.Float32()), s.EvalFixed(x, y).Float32(), 2e-3)
+		assert.InDelta(t, s.Eval(x.Float32(), y.Float32(), z.Float32()), s.EvalFixed(x, y, z).Float32(), 2e-3)
+	}
+
+	assert.Panics(t, func() { s.EvalFixed() })
+	assert.Panics(t, func() { s.EvalFixed(1, 2, 3, 4) })
+}
+
+func TestEvalFixedRange(t *testing.T) {
+	s := NewSimplex(7)
+	for i := 0; i < 10000; i++ {
+		x, y, z := Fixed(Int32(1, uint64(i))), Fixed(Int32(2, uint64(i))), Fixed(Int32(3, uint64(i)))
+		v2, v3 := s.EvalFixed(x, y), s.EvalFixed(x, y, z)
+		assert.True(t, v2 >= -FixedOne && v2 <= FixedOne, "got %v", v2)
+		assert.True(t, v3 >= -FixedOne && v3 <= FixedOne, "got %v", v3)
+	}
+}
+
+func TestEvalFixedGolden(t *testing.T) {
+	s := NewSimplex(42)
+
+	// Integer arithmetic only, so these must match on every platform
+	assert.Equal(t, Fixed(0), s.EvalFixed(0, 0))
+	assert.Equal(t, Fixed(34456), s.EvalFixed(ToFixed(10.5), ToFixed(-20.25)))
+	assert.Equal(t, Fixed(-39542), s.EvalFixed(ToFixed(10.5), ToFixed(-20.25), ToFixed(3.125)))
+}
+
+func TestFixedConversion(t *testing.T) {
+	assert.Equal(t, FixedOne, ToFixed(1))
+	assert.Equal(t, Fixed(-3<<15), ToFixed(-1.5))
+	assert.Equal(t, float32(2.25), ToFixed(2.25).Float32())
+}
@@ -0,0 +1,62 @@
+package noise
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGaussianField(t *testing.T) {
+	const size = 256
+	field := GaussianField(1, size, GaussianSpectrum(4))
+	assert.Len(t, field, size*size)
+	assert.Equal(t, field, GaussianField(1, size, GaussianSpectrum(4)))
+	assert.NotEqual(t, field, GaussianField(2, size, GaussianSpectrum(4)))
+
+	mean, variance := fieldMoments(field)
+	assert.InDelta(t, 0, mean, 1e-6)
+	assert.InDelta(t, 1, variance, 0.15)
+
+	// The correlation at the correlation length is exp(-1/2)
+	var corr float64
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			corr += float64(field[y*size+x]) * float64(field[y*size+(x+4)%size])
+		}
+	}
+	assert.InDelta(t, math.Exp(-0.5), corr/size/size/variance, 0.05)
+}
+
+func TestGaussianFieldPowerLaw(t *testing.T) {
+	const size = 128
+	field := GaussianField(1, size, PowerLawSpectrum(2))
+	_, variance := fieldMoments(field)
+	assert.InDelta(t, 1, variance, 0.5)
+
+	// Doubling the frequency quarters the power
+	radial := RadialSpectrum(Periodogram(field, size), size)
+	lo := (radial[8] + radial[9] + radial[10]) / 3
+	hi := (radial[16] + radial[18] + radial[20]) / 3
+	assert.InDelta(t, 4, lo/hi, 1)
+}
+
+func TestGaussianFieldWhite(t *testing.T) {
+	field := GaussianField(1, 64, PowerLawSpectrum(0))
+	_, variance := fieldMoments(field)
+	assert.InDelta(t, 1, variance, 0.05)
+
+	assert.Equal(t, make([]float32, 16), GaussianField(1, 4, func(float64) float64 { return 0 }))
+	assert.Panics(t, func() { GaussianField(1, 100, PowerLawSpectrum(0)) })
+}
+
+func fieldMoments(field []float32) (mean, variance float64) {
+	for _, v := range field {
+		mean += float64(v)
+	}
+	mean /= float64(len(field))
+	for _, v := range field {
+		variance += (float64(v) - mean) * (float64(v) - mean)
+	}
+	return mean, variance / float64(len(field))
+}
@@ -6,13 +6,13 @@ toolchain go1.24.2
 
 require (
 	github.com/kelindar/bitmap v1.5.3
+	github.com/klauspost/cpuid/v2 v2.2.4
 	github.com/stretchr/testify v1.10.0
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/kelindar/simd v1.1.2 // indirect
-	github.com/klauspost/cpuid/v2 v2.2.4 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	golang.org/x/sys v0.0.0-20220704084225-05e143d24a9e // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
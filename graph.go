@@ -0,0 +1,286 @@
+package noise
+
+import (
+	"iter"
+	"math"
+	"slices"
+	"sort"
+)
+
+// ---------------------------------- Graphs ----------------------------------
+
+// Edge connects two points of a graph by their index, the lower index first
+type Edge [2]int
+
+// Graph is an undirected graph over a set of 2D points, for generating the
+// connectivity of roads, dungeons or tech trees
+type Graph struct {
+	Points [][2]float32 // Positions of the nodes
+	Edges  []Edge       // Edges in ascending order, without duplicates
+}
+
+// Delaunay returns the Delaunay triangulation of the points as a graph, in which no
+// point lies inside the circumcircle of any triangle. It connects every point to its
+// natural neighbours without crossing edges. Complexity: O(n²) in the worst case.
+//
+// Example:
+//
+//	g := Delaunay(Sparse2(12345, 512, 512, 32))
+//	roads := g.Prune(12345, 0.2) // a spanning tree with a few loops
+func Delaunay[T Number](seq iter.Seq[[2]T]) *Graph {
+	points, triangles := Triangulate(seq)
+	edges := make([]Edge, 0, len(triangles)*3)
+	for _, t := range triangles {
+		for k := 0; k < 3; k++ {
+			edges = append(edges, edgeOf(t[k], t[(k+1)%3]))
+		}
+	}
+	return newGraph(points, edges)
+}
+
+// Gabriel returns the Gabriel graph of the points, where two points are connected if
+// no other point lies inside the circle whose diameter is the segment between them.
+// It is a subgraph of the Delaunay triangulation with fewer, more local edges.
+func Gabriel[T Number](seq iter.Seq[[2]T]) *Graph {
+	points, triangles := Triangulate(seq)
+
+	// An edge is Gabriel unless the opposite corner of an adjacent triangle sees it
+	// at an obtuse angle, which places that corner inside the diametral circle
+	blocked := make(map[Edge]bool)
+	var edges []Edge
+	for _, t := range triangles {
+		for k := 0; k < 3; k++ {
+			e := edgeOf(t[k], t[(k+1)%3])
+			if obtuse(points[t[k]], points[t[(k+1)%3]], points[t[(k+2)%3]]) {
+				blocked[e] = true
+			}
+			edges = append(edges, e)
+		}
+	}
+
+	edges = slices.DeleteFunc(edges, func(e Edge) bool { return blocked[e] })
+	return newGraph(points, edges)
+}
+
+// RelativeNeighborhood returns the relative neighbourhood graph of the points, where
+// two points are connected if no other point is closer to both of them than they are
+// to each other. It is a subgraph of the Gabriel graph which still contains the
+// minimum spanning tree, and looks like a network of paths between settlements.
+func RelativeNeighborhood[T Number](seq iter.Seq[[2]T]) *Graph {
+	g := Gabriel(seq)
+	index := NewPointSet(slices.Values(g.Points))
+	g.Edges = slices.DeleteFunc(g.Edges, func(e Edge) bool {
+		p, q := g.Points[e[0]], g.Points[e[1]]
+		d := dist64(p, q)
+		for r := range index.Within(p[0], p[1], float32(math.Sqrt(d))) {
+			if max(dist64(p, r), dist64(q, r)) < d {
+				return true
+			}
+		}
+		return false
+	})
+	return g
+}
+
+// KNearest returns the graph connecting each point to its k nearest neighbours, so
+// every point has at least k edges. Ties are broken by the lowest index.
+// Complexity: O(n² log n).
+func KNearest[T Number](seq iter.Seq[[2]T], k int) *Graph {
+	var points [][2]float32
+	for p := range seq {
+		points = append(points, [2]float32{float32(p[0]), float32(p[1])})
+	}
+
+	var edges []Edge
+	order := make([]int, 0, len(points))
+	for i, p := range points {
+		order = order[:0]
+		for j := range points {
+			if j != i {
+				order = append(order, j)
+			}
+		}
+
+		sort.SliceStable(order, func(a, b int) bool {
+			return dist64(p, points[order[a]]) < dist64(p, points[order[b]])
+		})
+		for _, j := range order[:min(k, len(order))] {
+			edges = append(edges, edgeOf(i, j))
+		}
+	}
+	return newGraph(points, edges)
+}
+
+// Prune returns a copy of the graph keeping its minimum spanning tree, so that it
+// stays connected, and each other edge with the probability keep. A keep of 0 gives
+// a tree, and small values add a few loops. The same seed removes the same edges.
+func (g *Graph) Prune(seed uint32, keep float32) *Graph {
+	byLength := slices.Clone(g.Edges)
+	sort.SliceStable(byLength, func(a, b int) bool {
+		return g.length(byLength[a]) < g.length(byLength[b])
+	})
+
+	// Kruskal's algorithm over a union-find of the points
+	parent := make([]int, len(g.Points))
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	var edges []Edge
+	for _, e := range byLength {
+		a, b := find(e[0]), find(e[1])
+		switch {
+		case a != b:
+			parent[a] = b
+			edges = append(edges, e)
+		case Roll32(seed, keep, uint64(e[0])<<32|uint64(e[1])):
+			edges = append(edges, e)
+		}
+	}
+	return newGraph(g.Points, edges)
+}
+
+// Neighbors returns the indices of the points connected to each point
+func (g *Graph) Neighbors() [][]int {
+	out := make([][]int, len(g.Points))
+	for _, e := range g.Edges {
+		out[e[0]] = append(out[e[0]], e[1])
+		out[e[1]] = append(out[e[1]], e[0])
+	}
+	return out
+}
+
+// length returns the squared length of an edge
+func (g *Graph) length(e Edge) float64 {
+	return dist64(g.Points[e[0]], g.Points[e[1]])
+}
+
+// newGraph sorts and deduplicates the edges
+func newGraph(points [][2]float32, edges []Edge) *Graph {
+	slices.SortFunc(edges, func(a, b Edge) int {
+		if a[0] != b[0] {
+			return a[0] - b[0]
+		}
+		return a[1] - b[1]
+	})
+	return &Graph{Points: points, Edges: slices.Compact(edges)}
+}
+
+// edgeOf returns the edge between two points, the lower index first
+func edgeOf(a, b int) Edge {
+	return Edge{min(a, b), max(a, b)}
+}
+
+// dist64 returns the squared distance between two points in double precision
+func dist64(a, b [2]float32) float64 {
+	dx, dy := float64(a[0])-float64(b[0]), float64(a[1])-float64(b[1])
+	return dx*dx + dy*dy
+}
+
+// obtuse returns whether the angle at r of the triangle pqr is strictly obtuse
+func obtuse(p, q, r [2]float32) bool {
+	ax, ay := float64(p[0])-float64(r[0]), float64(p[1])-float64(r[1])
+	bx, by := float64(q[0])-float64(r[0]), float64(q[1])-float64(r[1])
+	return ax*bx+ay*by < 0
+}
+
+// ---------------------------------- Triangulation ----------------------------------
+
+// Triangulate returns the points and their Delaunay triangles, as counter-clockwise
+// triples of indices, using the Bowyer-Watson algorithm. Duplicate points are left
+// out of the triangles. Complexity: O(n²) in the worst case.
+func Triangulate[T Number](seq iter.Seq[[2]T]) ([][2]float32, [][3]int) {
+	var points [][2]float32
+	for p := range seq {
+		points = append(points, [2]float32{float32(p[0]), float32(p[1])})
+	}
+	if len(points) < 3 {
+		return points, nil
+	}
+
+	// Enclose all of the points in a large super triangle, whose corners are
+	// appended after the points and removed at the end
+	minX, minY, maxX, maxY := bounds2(points)
+	cx, cy := float64(minX+maxX)/2, float64(minY+maxY)/2
+	size := float64(max(maxX-minX, maxY-minY, 1)) * 1000
+	n := len(points)
+	verts := make([][2]float64, n, n+3)
+	for i, p := range points {
+		verts[i] = [2]float64{float64(p[0]), float64(p[1])}
+	}
+	verts = append(verts,
+		[2]float64{cx - 2*size, cy - size},
+		[2]float64{cx + 2*size, cy - size},
+		[2]float64{cx, cy + 2*size},
+	)
+
+	triangles := [][3]int{{n, n + 1, n + 2}}
+	count := make(map[Edge]int)
+	for i := 0; i < n; i++ {
+		p := verts[i]
+
+		// Remove the triangles whose circumcircle contains the point
+		var bad, keep [][3]int
+		for _, t := range triangles {
+			if incircle(verts[t[0]], verts[t[1]], verts[t[2]], p) > 0 {
+				bad = append(bad, t)
+			} else {
+				keep = append(keep, t)
+			}
+		}
+
+		// Connect the point to the boundary of the cavity, which are the edges
+		// not shared by two removed triangles
+		clear(count)
+		for _, t := range bad {
+			for k := 0; k < 3; k++ {
+				count[edgeOf(t[k], t[(k+1)%3])]++
+			}
+		}
+
+		for _, t := range bad {
+			for k := 0; k < 3; k++ {
+				if a, b := t[k], t[(k+1)%3]; count[edgeOf(a, b)] == 1 {
+					keep = append(keep, [3]int{a, b, i})
+				}
+			}
+		}
+		triangles = keep
+	}
+
+	triangles = slices.DeleteFunc(triangles, func(t [3]int) bool {
+		return t[0] >= n || t[1] >= n || t[2] >= n
+	})
+	return points, triangles
+}
+
+// incircle returns a positive value if d lies inside the circumcircle of the
+// counter-clockwise triangle abc, negative outside and zero on it
+func incircle(a, b, c, d [2]float64) float64 {
+	adx, ady := a[0]-d[0], a[1]-d[1]
+	bdx, bdy := b[0]-d[0], b[1]-d[1]
+	cdx, cdy := c[0]-d[0], c[1]-d[1]
+	ad := adx*adx + ady*ady
+	bd := bdx*bdx + bdy*bdy
+	cd := cdx*cdx + cdy*cdy
+	return adx*(bdy*cd-bd*cdy) - ady*(bdx*cd-bd*cdx) + ad*(bdx*cdy-bdy*cdx)
+}
+
+// bounds2 returns the bounding box of a non-empty set of points
+func bounds2(points [][2]float32) (minX, minY, maxX, maxY float32) {
+	minX, minY = points[0][0], points[0][1]
+	maxX, maxY = minX, minY
+	for _, p := range points[1:] {
+		minX, maxX = min(minX, p[0]), max(maxX, p[0])
+		minY, maxY = min(minY, p[1]), max(maxY, p[1])
+	}
+	return
+}
@@ -0,0 +1,119 @@
+package noise
+
+import (
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTriangulate(t *testing.T) {
+	points, triangles := Triangulate(randomPoints(1, 200))
+	assert.NotEmpty(t, triangles)
+
+	// No point lies inside the circumcircle of any triangle
+	inside := 0
+	for _, tri := range triangles {
+		a, b, c := to64(points[tri[0]]), to64(points[tri[1]]), to64(points[tri[2]])
+		assert.Greater(t, (b[0]-a[0])*(c[1]-a[1])-(b[1]-a[1])*(c[0]-a[0]), 0.0)
+		for _, p := range points {
+			if incircle(a, b, c, to64(p)) > 1e-6 {
+				inside++
+			}
+		}
+	}
+	assert.Equal(t, 0, inside)
+
+	// A square and its center form four triangles
+	square := [][2]int{{0, 0}, {2, 0}, {2, 2}, {0, 2}, {1, 1}}
+	_, triangles = Triangulate(slices.Values(square))
+	assert.Len(t, triangles, 4)
+	_, triangles = Triangulate(slices.Values(square[:2]))
+	assert.Empty(t, triangles)
+}
+
+func TestTriangulateGrid(t *testing.T) {
+	var grid [][2]int
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			grid = append(grid, [2]int{x, y})
+		}
+	}
+
+	// Cocircular points still give two triangles per square
+	_, triangles := Triangulate(slices.Values(grid))
+	assert.Len(t, triangles, 2*7*7)
+}
+
+func TestGraphs(t *testing.T) {
+	points := Sparse2(1, 256, 256, 16)
+	delaunay := Delaunay(points)
+	gabriel := Gabriel(points)
+	rng := RelativeNeighborhood(points)
+	assert.NotEmpty(t, rng.Edges)
+
+	// Each graph is a subgraph of the previous one
+	assert.Subset(t, delaunay.Edges, gabriel.Edges)
+	assert.Subset(t, gabriel.Edges, rng.Edges)
+	assert.Less(t, len(gabriel.Edges), len(delaunay.Edges))
+	assert.Less(t, len(rng.Edges), len(gabriel.Edges))
+
+	// The relative neighbourhood graph is connected, so it contains a spanning tree
+	tree := rng.Prune(1, 0)
+	assert.Equal(t, tree.Edges, delaunay.Prune(1, 0).Edges)
+	assert.Len(t, tree.Edges, len(tree.Points)-1)
+	assert.True(t, connected(tree))
+}
+
+func TestKNearest(t *testing.T) {
+	points := randomPoints(1, 100)
+	g := KNearest(points, 3)
+	for _, nb := range g.Neighbors() {
+		assert.GreaterOrEqual(t, len(nb), 3)
+	}
+
+	line := [][2]int{{0, 0}, {1, 0}, {3, 0}, {7, 0}}
+	assert.Equal(t, []Edge{{0, 1}, {1, 2}, {2, 3}}, KNearest(slices.Values(line), 1).Edges)
+}
+
+func TestPrune(t *testing.T) {
+	g := Delaunay(randomPoints(1, 100))
+	pruned := g.Prune(1, 0.3)
+	assert.True(t, connected(pruned))
+	assert.Subset(t, g.Edges, pruned.Edges)
+	assert.Equal(t, pruned.Edges, g.Prune(1, 0.3).Edges)
+	assert.Greater(t, len(pruned.Edges), len(g.Points)-1)
+	assert.Less(t, len(pruned.Edges), len(g.Edges))
+	assert.Equal(t, g.Edges, g.Prune(1, 1).Edges)
+}
+
+func randomPoints(seed uint32, n int) iter.Seq[[2]float32] {
+	points := make([][2]float32, n)
+	for i := range points {
+		points[i] = [2]float32{Float32(seed, uint64(i)) * 100, Float32(seed^1, uint64(i)) * 100}
+	}
+	return slices.Values(points)
+}
+
+func to64(p [2]float32) [2]float64 {
+	return [2]float64{float64(p[0]), float64(p[1])}
+}
+
+func connected(g *Graph) bool {
+	nb := g.Neighbors()
+	seen := make([]bool, len(g.Points))
+	queue := []int{0}
+	seen[0] = true
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		for _, j := range nb[i] {
+			if !seen[j] {
+				seen[j] = true
+				queue = append(queue, j)
+			}
+		}
+	}
+	return !slices.Contains(seen, false)
+}
@@ -0,0 +1,180 @@
+// Package grid provides common operations over float32 grids stored in row-major
+// order, such as heightmaps filled by Fill2D or eroded by HydraulicErode, so that
+// they can be combined, masked, normalized and resampled without writing the same
+// loops every time. Operations that combine two grids modify the first one in place.
+package grid
+
+import "math"
+
+// ---------------------------------- Combine ----------------------------------
+
+// Add adds src to dst, cell by cell
+func Add(dst, src []float32) {
+	check(dst, src)
+	for i, v := range src {
+		dst[i] += v
+	}
+}
+
+// Multiply multiplies dst by src, cell by cell
+func Multiply(dst, src []float32) {
+	check(dst, src)
+	for i, v := range src {
+		dst[i] *= v
+	}
+}
+
+// Scale multiplies every cell of dst by k and adds offset
+func Scale(dst []float32, k, offset float32) {
+	for i, v := range dst {
+		dst[i] = float32(v*k) + offset
+	}
+}
+
+// Min keeps the lowest of dst and src in every cell
+func Min(dst, src []float32) {
+	check(dst, src)
+	for i, v := range src {
+		dst[i] = min(dst[i], v)
+	}
+}
+
+// Max keeps the highest of dst and src in every cell
+func Max(dst, src []float32) {
+	check(dst, src)
+	for i, v := range src {
+		dst[i] = max(dst[i], v)
+	}
+}
+
+// Lerp blends src into dst by the mask, keeping dst where the mask is 0 and taking
+// src where it is 1. Masks such as FalloffRadial carve islands out of a heightmap.
+//
+// Example:
+//
+//	grid.Lerp(ocean, land, noise.FalloffRadial(512, 512, 2)) // land in the middle
+func Lerp(dst, src, mask []float32) {
+	check(dst, src)
+	check(dst, mask)
+	for i, v := range src {
+		dst[i] += float32((v - dst[i]) * mask[i])
+	}
+}
+
+// Clamp limits every cell of dst to [lo, hi]
+func Clamp(dst []float32, lo, hi float32) {
+	for i, v := range dst {
+		dst[i] = min(max(v, lo), hi)
+	}
+}
+
+// Normalize linearly remaps dst so that its lowest cell becomes lo and its highest
+// becomes hi. A flat grid is set to lo entirely.
+func Normalize(dst []float32, lo, hi float32) {
+	if len(dst) == 0 {
+		return
+	}
+
+	vmin, vmax := dst[0], dst[0]
+	for _, v := range dst[1:] {
+		vmin, vmax = min(vmin, v), max(vmax, v)
+	}
+
+	if vmax == vmin {
+		for i := range dst {
+			dst[i] = lo
+		}
+		return
+	}
+
+	k := (hi - lo) / (vmax - vmin)
+	for i, v := range dst {
+		dst[i] = lo + float32((v-vmin)*k)
+	}
+}
+
+// check panics if two grids do not have the same number of cells
+func check(dst, src []float32) {
+	if len(dst) != len(src) {
+		panic("grid: size mismatch")
+	}
+}
+
+// ---------------------------------- Resample ----------------------------------
+
+// Filter is the interpolation used when resampling a grid
+type Filter int
+
+// Supported filters
+const (
+	Nearest  Filter = iota // Value of the closest cell, blocky
+	Bilinear               // Linear blend of the 4 closest cells, smooth but creased
+	Bicubic                // Catmull-Rom blend of the 16 closest cells, smooth
+)
+
+// Resample returns the w×h grid src resized to nw×nh with the given filter. The
+// corner cells of both grids are aligned, so the edges of a heightmap are kept and
+// resizing a 513×513 grid to 1025×1025 doubles its resolution exactly.
+//
+// Example:
+//
+//	detail := grid.Resample(heights, 256, 256, 1024, 1024, grid.Bicubic)
+func Resample(src []float32, w, h, nw, nh int, filter Filter) []float32 {
+	if w <= 0 || h <= 0 || nw < 0 || nh < 0 || len(src) < w*h {
+		panic("grid: invalid size")
+	}
+
+	at := func(x, y int) float32 {
+		return src[min(max(y, 0), h-1)*w+min(max(x, 0), w-1)]
+	}
+
+	out := make([]float32, nw*nh)
+	sx, sy := ratio(w, nw), ratio(h, nh)
+	for y := 0; y < nh; y++ {
+		fy := float64(y) * sy
+		iy := int(math.Floor(fy))
+		ty := float32(fy - float64(iy))
+		for x := 0; x < nw; x++ {
+			fx := float64(x) * sx
+			ix := int(math.Floor(fx))
+			tx := float32(fx - float64(ix))
+
+			var v float32
+			switch filter {
+			case Nearest:
+				v = at(int(math.Round(fx)), int(math.Round(fy)))
+			case Bilinear:
+				a := at(ix, iy) + float32((at(ix+1, iy)-at(ix, iy))*tx)
+				b := at(ix, iy+1) + float32((at(ix+1, iy+1)-at(ix, iy+1))*tx)
+				v = a + float32((b-a)*ty)
+			case Bicubic:
+				var rows [4]float32
+				for k := range rows {
+					j := iy - 1 + k
+					rows[k] = cubic(at(ix-1, j), at(ix, j), at(ix+1, j), at(ix+2, j), tx)
+				}
+				v = cubic(rows[0], rows[1], rows[2], rows[3], ty)
+			default:
+				panic("grid: unknown filter")
+			}
+			out[y*nw+x] = v
+		}
+	}
+	return out
+}
+
+// ratio returns the step in source cells between two destination cells
+func ratio(n, nn int) float64 {
+	if nn <= 1 {
+		return 0
+	}
+	return float64(n-1) / float64(nn-1)
+}
+
+// cubic interpolates between p1 and p2 with the Catmull-Rom spline through p0..p3
+func cubic(p0, p1, p2, p3, t float32) float32 {
+	a := -0.5*p0 + 1.5*p1 - 1.5*p2 + 0.5*p3
+	b := p0 - 2.5*p1 + 2*p2 - 0.5*p3
+	c := -0.5*p0 + 0.5*p2
+	return float32(float32(float32(a*t)+b)*t+c)*t + p1
+}
@@ -0,0 +1,85 @@
+package grid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCombine(t *testing.T) {
+	a := []float32{1, 2, 3, 4}
+	Add(a, []float32{1, 1, 1, 1})
+	assert.Equal(t, []float32{2, 3, 4, 5}, a)
+
+	Multiply(a, []float32{2, 0, 1, 0.5})
+	assert.Equal(t, []float32{4, 0, 4, 2.5}, a)
+
+	Min(a, []float32{3, 3, 3, 3})
+	assert.Equal(t, []float32{3, 0, 3, 2.5}, a)
+
+	Max(a, []float32{1, 1, 1, 1})
+	assert.Equal(t, []float32{3, 1, 3, 2.5}, a)
+
+	Scale(a, 2, -1)
+	assert.Equal(t, []float32{5, 1, 5, 4}, a)
+
+	Clamp(a, 2, 4)
+	assert.Equal(t, []float32{4, 2, 4, 4}, a)
+
+	Lerp(a, []float32{0, 0, 0, 0}, []float32{0, 1, 0.5, 0.25})
+	assert.Equal(t, []float32{4, 0, 2, 3}, a)
+
+	assert.Panics(t, func() { Add(a, []float32{1}) })
+	assert.Panics(t, func() { Lerp(a, a, []float32{1}) })
+}
+
+func TestNormalize(t *testing.T) {
+	a := []float32{-2, 0, 2, 6}
+	Normalize(a, 0, 1)
+	assert.Equal(t, []float32{0, 0.25, 0.5, 1}, a)
+
+	flat := []float32{3, 3}
+	Normalize(flat, -1, 1)
+	assert.Equal(t, []float32{-1, -1}, flat)
+	assert.NotPanics(t, func() { Normalize(nil, 0, 1) })
+}
+
+func TestResample(t *testing.T) {
+	src := []float32{
+		0, 1,
+		2, 3,
+	}
+
+	// Corners are kept and the midpoints are interpolated
+	for _, f := range []Filter{Bilinear, Bicubic} {
+		out := Resample(src, 2, 2, 3, 3, f)
+		assert.Equal(t, []float32{
+			0, 0.5, 1,
+			1, 1.5, 2,
+			2, 2.5, 3,
+		}, out)
+	}
+
+	assert.Equal(t, []float32{0, 1, 1, 2, 3, 3, 2, 3, 3}, Resample(src, 2, 2, 3, 3, Nearest))
+	assert.Equal(t, src, Resample(Resample(src, 2, 2, 5, 5, Bicubic), 5, 5, 2, 2, Nearest))
+	assert.Equal(t, []float32{0}, Resample(src, 2, 2, 1, 1, Bilinear))
+	assert.Panics(t, func() { Resample(src, 3, 3, 2, 2, Bilinear) })
+	assert.Panics(t, func() { Resample(src, 2, 2, 2, 2, Filter(9)) })
+}
+
+func TestResampleSmooth(t *testing.T) {
+	// A linear ramp stays linear, away from the clamped edges for the cubic
+	src := make([]float32, 16)
+	for i := range src {
+		src[i] = float32(i % 4)
+	}
+
+	for _, f := range []Filter{Bilinear, Bicubic} {
+		out := Resample(src, 4, 4, 7, 7, f)
+		for i, v := range out {
+			if x := i % 7; f == Bilinear || (x >= 2 && x <= 4) {
+				assert.InDelta(t, float64(x)/2, float64(v), 1e-6)
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package grid
+
+// ---------------------------------- Mipmaps ----------------------------------
+
+// Mip is a level of a mip chain
+type Mip struct {
+	Width, Height int       // Size of the level
+	Values        []float32 // Values in row-major order
+}
+
+// Mipmaps returns the mip chain of a w×h grid, from the grid itself down to a
+// single cell. Each level halves the size of the previous one, rounding up, and
+// averages the 2×2 block of cells it covers, so distant terrain can be sampled from
+// a coarser, alias-free version of the same grid.
+//
+// Example:
+//
+//	chain := grid.Mipmaps(heights, 512, 512)
+//	far := chain[3] // 64×64
+func Mipmaps(src []float32, w, h int) []Mip {
+	if w <= 0 || h <= 0 || len(src) < w*h {
+		panic("grid: invalid size")
+	}
+
+	chain := []Mip{{Width: w, Height: h, Values: src[:w*h]}}
+	for w > 1 || h > 1 {
+		nw, nh := (w+1)/2, (h+1)/2
+		prev := chain[len(chain)-1].Values
+		next := make([]float32, nw*nh)
+		for y := 0; y < nh; y++ {
+			for x := 0; x < nw; x++ {
+
+				// Average the cells of the block, which is cut short on odd edges
+				var sum float32
+				var n int
+				for dy := 0; dy < 2 && 2*y+dy < h; dy++ {
+					for dx := 0; dx < 2 && 2*x+dx < w; dx++ {
+						sum += prev[(2*y+dy)*w+2*x+dx]
+						n++
+					}
+				}
+				next[y*nw+x] = sum / float32(n)
+			}
+		}
+
+		chain = append(chain, Mip{Width: nw, Height: nh, Values: next})
+		w, h = nw, nh
+	}
+	return chain
+}
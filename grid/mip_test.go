@@ -0,0 +1,31 @@
+package grid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMipmaps(t *testing.T) {
+	src := []float32{
+		0, 2, 4, 6,
+		2, 4, 6, 8,
+		1, 1, 3, 3,
+		1, 1, 3, 3,
+	}
+
+	chain := Mipmaps(src, 4, 4)
+	assert.Len(t, chain, 3)
+	assert.Equal(t, src, chain[0].Values)
+	assert.Equal(t, Mip{Width: 2, Height: 2, Values: []float32{2, 6, 1, 3}}, chain[1])
+	assert.Equal(t, Mip{Width: 1, Height: 1, Values: []float32{3}}, chain[2])
+}
+
+func TestMipmapsOdd(t *testing.T) {
+	chain := Mipmaps([]float32{1, 2, 3, 4, 5, 6}, 3, 2)
+	assert.Len(t, chain, 3)
+	assert.Equal(t, Mip{Width: 2, Height: 1, Values: []float32{3, 4.5}}, chain[1])
+	assert.Equal(t, Mip{Width: 1, Height: 1, Values: []float32{3.75}}, chain[2])
+	assert.Len(t, Mipmaps([]float32{7}, 1, 1), 1)
+	assert.Panics(t, func() { Mipmaps(nil, 2, 2) })
+}
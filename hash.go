@@ -0,0 +1,143 @@
+package noise
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// ---------------------------------- Key Hashing ----------------------------------
+
+// HashString returns a deterministic 64-bit hash of the string, which can be used as
+// the x key of the random functions to derive named sub-streams.
+//
+// Example:
+//
+//	density := Float64(seed, HashString(seed, "forest/density"))
+func HashString(seed uint32, s string) uint64 {
+	h := uint64(seed) ^ uint64(len(s))*0x9e3779b97f4a7c15
+	for ; len(s) >= 8; s = s[8:] {
+		h = xxhash64(uint64(s[0])|uint64(s[1])<<8|uint64(s[2])<<16|uint64(s[3])<<24|
+			uint64(s[4])<<32|uint64(s[5])<<40|uint64(s[6])<<48|uint64(s[7])<<56, h)
+	}
+
+	// Pack the remaining bytes, terminated by a marker so that trailing zeros count
+	var tail uint64
+	for i := 0; i < len(s); i++ {
+		tail |= uint64(s[i]) << (8 * i)
+	}
+	return xxhash64(tail|0x80<<(8*len(s)), h)
+}
+
+// HashBytes returns a deterministic 64-bit hash of the bytes, equal to the hash
+// of the same bytes as a string
+func HashBytes(seed uint32, b []byte) uint64 {
+	h := uint64(seed) ^ uint64(len(b))*0x9e3779b97f4a7c15
+	for ; len(b) >= 8; b = b[8:] {
+		h = xxhash64(binary.LittleEndian.Uint64(b), h)
+	}
+
+	var tail uint64
+	for i := 0; i < len(b); i++ {
+		tail |= uint64(b[i]) << (8 * i)
+	}
+	return xxhash64(tail|0x80<<(8*len(b)), h)
+}
+
+// Float32Key returns a deterministic float32 in [0.0, 1.0) based on a string key
+func Float32Key(seed uint32, key string) float32 {
+	return Float32(seed, HashString(seed, key))
+}
+
+// Float64Key returns a deterministic float64 in [0.0, 1.0) based on a string key
+func Float64Key(seed uint32, key string) float64 {
+	return Float64(seed, HashString(seed, key))
+}
+
+// Uint128 returns a deterministic 128-bit value based on x, as two 64-bit halves
+func Uint128(seed uint32, x uint64) [2]uint64 {
+	return [2]uint64{
+		xxhash64(x, uint64(seed)),
+		xxhash64(x, uint64(seed)^0xc2b2ae3d27d4eb4f),
+	}
+}
+
+// Hash128 returns a deterministic 128-bit hash of the coordinates, as two 64-bit
+// halves computed by independent hash chains. It is meant for persistent IDs of
+// world entities derived from their coordinates, where 64-bit collisions become
+// likely across billions of objects. The result is comparable and can be used as
+// a map key.
+//
+// Example:
+//
+//	id := Hash128(seed, chunkX, chunkY, index)
+func Hash128[T Number](seed uint32, coords ...T) [2]uint64 {
+	if len(coords) == 0 {
+		panic("noise: requires at least 1 coordinate")
+	}
+
+	const mix uint64 = 0x9e3779b97f4a7c15
+	lo := uint64(seed)
+	hi := uint64(seed) ^ 0xc2b2ae3d27d4eb4f
+	for i, coord := range coords {
+		v := coordToUint64(coord)
+		lo = xxhash64(v, lo+uint64(i)*mix)
+		hi = xxhash64(v^hi, hi+uint64(i+1)*0x165667b19e3779f9)
+	}
+
+	// Fold in the number of coordinates so that prefixes hash differently
+	n := uint64(len(coords))
+	return [2]uint64{xxhash64(n, lo), xxhash64(n, hi^lo)}
+}
+
+// ---------------------------------- Hash Backends ----------------------------------
+
+// Hasher is a 64-bit mixing function of a value and a seed, used as the backend of
+// a Stream. The package uses XXHash everywhere by default; the alternatives trade
+// avalanche quality against speed, or match the hashes used elsewhere in an engine.
+type Hasher interface {
+	Hash64(v, seed uint64) uint64
+}
+
+// XXHash is the default hash backend, the xxh3 mix of a 64-bit value
+type XXHash struct{}
+
+// Hash64 hashes v with the seed
+func (XXHash) Hash64(v, seed uint64) uint64 {
+	return xxhash64(v, seed)
+}
+
+// WyHash is a hash backend using the wyhash multiply-mix of an 8-byte key
+type WyHash struct{}
+
+// Hash64 hashes v with the seed
+func (WyHash) Hash64(v, seed uint64) uint64 {
+	const (
+		p0 = 0x2d358dccaa6c78a5
+		p1 = 0x8bb84b93962eacc9
+	)
+
+	seed ^= wymix(seed^p0, p1)
+	a := v<<32 | v>>32 ^ p1
+	b := v ^ seed
+	hi, lo := bits.Mul64(a, b)
+	return wymix(lo^p0^8, hi^p1)
+}
+
+// wymix multiplies a and b into 128 bits and folds the halves together
+func wymix(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return hi ^ lo
+}
+
+// SplitMix64 is a hash backend using the SplitMix64 finalizer. Hashing v with a
+// seed returns the (v+1)-th output of a SplitMix64 generator seeded with it, so a
+// Stream using it reproduces the standard SplitMix64 sequence.
+type SplitMix64 struct{}
+
+// Hash64 hashes v with the seed
+func (SplitMix64) Hash64(v, seed uint64) uint64 {
+	z := seed + (v+1)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
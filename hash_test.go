@@ -0,0 +1,69 @@
+package noise
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashString(t *testing.T) {
+	assert.Equal(t, HashString(42, "forest/density"), HashString(42, "forest/density"))
+	assert.NotEqual(t, HashString(42, "forest/density"), HashString(43, "forest/density"))
+	assert.NotEqual(t, HashString(42, "forest/density"), HashString(42, "forest/densitY"))
+
+	// Lengths and trailing zeros are part of the key
+	seen := make(map[uint64]string)
+	for _, s := range []string{"", "\x00", "\x00\x00", "a", "a\x00", "abcdefgh", "abcdefgh\x00", "abcdefghi"} {
+		h := HashString(1, s)
+		_, dup := seen[h]
+		assert.False(t, dup, "collision for %q", s)
+		seen[h] = s
+
+		assert.Equal(t, h, HashBytes(1, []byte(s)))
+	}
+
+	// No collisions among many similar keys
+	hashes := make(map[uint64]bool)
+	for i := 0; i < 10000; i++ {
+		hashes[HashString(7, fmt.Sprintf("chunk/%d", i))] = true
+	}
+	assert.Len(t, hashes, 10000)
+}
+
+func TestFloatKey(t *testing.T) {
+	v := Float64Key(42, "forest/density")
+	assert.True(t, v >= 0 && v < 1)
+	assert.Equal(t, Float64(42, HashString(42, "forest/density")), v)
+	assert.Equal(t, Float32(42, HashString(42, "river/width")), Float32Key(42, "river/width"))
+}
+
+func TestUint128(t *testing.T) {
+	v := Uint128(42, 7)
+	assert.Equal(t, v, Uint128(42, 7))
+	assert.NotEqual(t, v[0], v[1])
+	assert.Equal(t, Uint64(42, 7), v[0])
+	assert.NotEqual(t, v, Uint128(42, 8))
+}
+
+func TestHash128(t *testing.T) {
+	id := Hash128(42, 1, 2, 3)
+	assert.Equal(t, id, Hash128(42, 1, 2, 3))
+	assert.NotEqual(t, id, Hash128(42, 1, 3, 2))
+	assert.NotEqual(t, id, Hash128(43, 1, 2, 3))
+	assert.NotEqual(t, Hash128(42, 1, 2), Hash128(42, 1, 2, 0))
+	assert.NotEqual(t, id[0], id[1])
+	assert.Panics(t, func() { Hash128[int](42) })
+
+	// No collisions in either half over a grid of coordinates
+	lo, hi := make(map[uint64]bool), make(map[uint64]bool)
+	for x := 0; x < 100; x++ {
+		for y := 0; y < 100; y++ {
+			h := Hash128(7, x, y)
+			lo[h[0]], hi[h[1]] = true, true
+		}
+	}
+	assert.Len(t, lo, 10000)
+	assert.Len(t, hi, 10000)
+	assert.Equal(t, Hash128(7, float32(1.5)), Hash128(7, float32(1.5)))
+}
@@ -0,0 +1,75 @@
+package noise
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// ---------------------------------- HDR Export ----------------------------------
+
+// writePFM writes the region [x0, x1) × [y0, y1) of a grid as a greyscale portable
+// float map. A negative scale in the header marks little-endian samples, and rows
+// are stored from the bottom of the image to the top.
+func writePFM(dst io.Writer, values []float32, stride, x0, y0, x1, y1 int) error {
+	buf := bufio.NewWriter(dst)
+	fmt.Fprintf(buf, "Pf\n%d %d\n-1.0\n", x1-x0, y1-y0)
+	for y := y1 - 1; y >= y0; y-- {
+		if err := binary.Write(buf, binary.LittleEndian, values[y*stride+x0:y*stride+x1]); err != nil {
+			return err
+		}
+	}
+	return buf.Flush()
+}
+
+// writeEXR writes the region [x0, x1) × [y0, y1) of a grid as a single-part scanline
+// OpenEXR image, with one uncompressed float32 luminance channel named "Y" and one
+// scanline per chunk.
+func writeEXR(dst io.Writer, values []float32, stride, x0, y0, x1, y1 int) error {
+	w, h := x1-x0, y1-y0
+	le := binary.LittleEndian
+
+	// Header, made of the magic number, the version and the required attributes
+	var head bytes.Buffer
+	head.Write([]byte{0x76, 0x2f, 0x31, 0x01, 2, 0, 0, 0})
+	attr := func(name, kind string, value ...any) {
+		var data bytes.Buffer
+		for _, v := range value {
+			binary.Write(&data, le, v)
+		}
+
+		head.WriteString(name + "\x00" + kind + "\x00")
+		binary.Write(&head, le, int32(data.Len()))
+		head.Write(data.Bytes())
+	}
+
+	window := []int32{0, 0, int32(w - 1), int32(h - 1)}
+	attr("channels", "chlist", []byte("Y\x00"), int32(2), []byte{0, 0, 0, 0}, int32(1), int32(1), uint8(0))
+	attr("compression", "compression", uint8(0))
+	attr("dataWindow", "box2i", window)
+	attr("displayWindow", "box2i", window)
+	attr("lineOrder", "lineOrder", uint8(0))
+	attr("pixelAspectRatio", "float", float32(1))
+	attr("screenWindowCenter", "v2f", []float32{0, 0})
+	attr("screenWindowWidth", "float", float32(1))
+	head.WriteByte(0)
+
+	// Offset table, pointing at each scanline chunk from the start of the file
+	chunk := 8 + 4*w
+	offset := uint64(head.Len() + 8*h)
+	for y := 0; y < h; y++ {
+		binary.Write(&head, le, offset+uint64(y*chunk))
+	}
+
+	buf := bufio.NewWriter(dst)
+	buf.Write(head.Bytes())
+	for y := y0; y < y1; y++ {
+		binary.Write(buf, le, [2]int32{int32(y - y0), int32(4 * w)})
+		if err := binary.Write(buf, le, values[y*stride+x0:y*stride+x1]); err != nil {
+			return err
+		}
+	}
+	return buf.Flush()
+}
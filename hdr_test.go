@@ -0,0 +1,89 @@
+package noise
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportPFM(t *testing.T) {
+	heights := []float32{-1.5, 0, 1, 0.5, -0.5, 2.25}
+
+	var buf bytes.Buffer
+	assert.NoError(t, Export(&buf, PFM, heights, 3, 2))
+
+	r := bufio.NewReader(&buf)
+	var w, h int
+	var scale float32
+	_, err := fmt.Fscanf(r, "Pf\n%d %d\n%f\n", &w, &h, &scale)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, w)
+	assert.Equal(t, 2, h)
+	assert.Equal(t, float32(-1), scale)
+
+	// Rows are stored bottom-up
+	out := make([]float32, 6)
+	assert.NoError(t, binary.Read(r, binary.LittleEndian, out))
+	assert.Equal(t, []float32{0.5, -0.5, 2.25, -1.5, 0, 1}, out)
+}
+
+func TestExportEXR(t *testing.T) {
+	heights := []float32{-1.5, 0, 1, 0.5, -0.5, 2.25}
+
+	var buf bytes.Buffer
+	assert.NoError(t, Export(&buf, EXR, heights, 3, 2))
+	data := buf.Bytes()
+	assert.Equal(t, []byte{0x76, 0x2f, 0x31, 0x01, 2, 0, 0, 0}, data[:8])
+
+	// Parse the attributes of the header
+	r := bufio.NewReader(bytes.NewReader(data[8:]))
+	attrs := map[string][]byte{}
+	for {
+		name, _ := r.ReadString(0)
+		if name == "\x00" {
+			break
+		}
+
+		kind, _ := r.ReadString(0)
+		var size int32
+		assert.NoError(t, binary.Read(r, binary.LittleEndian, &size))
+		value := make([]byte, size)
+		_, err := io.ReadFull(r, value)
+		assert.NoError(t, err)
+		attrs[name[:len(name)-1]] = value
+		assert.NotEmpty(t, kind)
+	}
+
+	assert.Len(t, attrs, 8)
+	assert.Equal(t, []byte{0}, attrs["compression"])
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 1, 0, 0, 0}, attrs["dataWindow"])
+	assert.Equal(t, "Y\x00", string(attrs["channels"][:2]))
+
+	// Each scanline chunk is found through the offset table
+	offsets := make([]uint64, 2)
+	assert.NoError(t, binary.Read(r, binary.LittleEndian, offsets))
+	for y, off := range offsets {
+		var line [2]int32
+		row := make([]float32, 3)
+		chunk := bytes.NewReader(data[off:])
+		assert.NoError(t, binary.Read(chunk, binary.LittleEndian, &line))
+		assert.NoError(t, binary.Read(chunk, binary.LittleEndian, row))
+		assert.Equal(t, [2]int32{int32(y), 12}, line)
+		assert.Equal(t, heights[y*3:y*3+3], row)
+	}
+	assert.Equal(t, int(offsets[1])+20, len(data))
+}
+
+func TestExportTilesEXR(t *testing.T) {
+	heights := make([]float32, 9*9)
+	paths, err := ExportTiles(t.TempDir(), "map", EXR, heights, 9, 9, 4)
+	assert.NoError(t, err)
+	assert.Len(t, paths, 4)
+	assert.Equal(t, ".exr", EXR.Ext())
+	assert.Equal(t, ".pfm", PFM.Ext())
+}
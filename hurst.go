@@ -0,0 +1,90 @@
+package noise
+
+import "math"
+
+// ---------------------------------- Hurst fBM ----------------------------------
+
+// hurstOffset shifts every octave so that they are not all zero at the origin,
+// which would otherwise pin the surface there
+var hurstOffset = [3]float32{17.31, 41.73, 29.17}
+
+// FBMH is fractal Brownian motion parameterized by its Hurst exponent H rather than
+// a gain. The amplitude of each octave is lacunarity^-H times the previous one, so
+// the power spectrum falls off as 1/f^(2H+d) in d dimensions and the mean squared
+// difference between two points grows as r^2H with their distance r, within the
+// range of scales covered by the octaves. H near 0 gives rough, anti-persistent
+// surfaces and H near 1 smooth, persistent ones; 0.5 is classic Brownian motion.
+type FBMH struct {
+	Hurst      float32 // Hurst exponent in [0, 1], default given at construction
+	Lacunarity float32 // Frequency multiplier between octaves, default 2
+	Octaves    int     // Number of octaves, default 8
+	basis      Source  // Noise summed at every octave
+}
+
+// NewFBMH creates a Hurst fBM generator over simplex noise with the given seed
+//
+// Example:
+//
+//	rock := NewFBMH(12345, 0.8)
+//	height := rock.Eval2(x, y)
+func NewFBMH(seed uint32, hurst float32) *FBMH {
+	return NewFBMHWith(NewSimplex(seed), hurst)
+}
+
+// NewFBMHWith creates a Hurst fBM generator that sums octaves of any basis noise
+func NewFBMHWith(basis Source, hurst float32) *FBMH {
+	if hurst < 0 || hurst > 1 {
+		panic("invalid argument to NewFBMH")
+	}
+
+	return &FBMH{
+		Hurst:      hurst,
+		Lacunarity: 2,
+		Octaves:    8,
+		basis:      basis,
+	}
+}
+
+// Eval2 evaluates 2D fBM, implementing Source2
+func (f *FBMH) Eval2(x, y float32) float32 {
+	gain := f.gain()
+	var sum, total float32
+	var amp, freq float32 = 1, 1
+	for o := 0; o < f.Octaves; o++ {
+		dx := float32(float32(o) * hurstOffset[0])
+		dy := float32(float32(o) * hurstOffset[1])
+		sum += float32(amp * f.basis.Eval2(float32(x*freq)+dx, float32(y*freq)+dy))
+		total += amp
+		freq *= f.Lacunarity
+		amp *= gain
+	}
+	if total > 0 {
+		return sum / total
+	}
+	return 0
+}
+
+// Eval3 evaluates 3D fBM, implementing Source3
+func (f *FBMH) Eval3(x, y, z float32) float32 {
+	gain := f.gain()
+	var sum, total float32
+	var amp, freq float32 = 1, 1
+	for o := 0; o < f.Octaves; o++ {
+		dx := float32(float32(o) * hurstOffset[0])
+		dy := float32(float32(o) * hurstOffset[1])
+		dz := float32(float32(o) * hurstOffset[2])
+		sum += float32(amp * f.basis.Eval3(float32(x*freq)+dx, float32(y*freq)+dy, float32(z*freq)+dz))
+		total += amp
+		freq *= f.Lacunarity
+		amp *= gain
+	}
+	if total > 0 {
+		return sum / total
+	}
+	return 0
+}
+
+// gain returns the amplitude multiplier between octaves, lacunarity^-H
+func (f *FBMH) gain() float32 {
+	return float32(math.Pow(float64(f.Lacunarity), -float64(f.Hurst)))
+}
@@ -0,0 +1,47 @@
+package noise
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFBMH(t *testing.T) {
+	f := NewFBMH(1, 0.5)
+	assert.Equal(t, float32(2), f.Lacunarity)
+	assert.Equal(t, 8, f.Octaves)
+	assert.Equal(t, f.Eval2(1.5, 2.5), NewFBMH(1, 0.5).Eval2(1.5, 2.5))
+	assert.NotEqual(t, float32(0), f.Eval2(0, 0))
+	assert.NotEqual(t, float32(0), f.Eval3(0, 0, 0))
+
+	for i := 0; i < 1000; i++ {
+		x, y := float32(i)*0.37, float32(i)*0.11
+		assert.True(t, abs(f.Eval2(x, y)) <= 1)
+		assert.True(t, abs(f.Eval3(x, y, 0.5)) <= 1)
+	}
+
+	f.Octaves = 0
+	assert.Equal(t, float32(0), f.Eval2(1, 2))
+	assert.Panics(t, func() { NewFBMH(1, 1.5) })
+}
+
+func TestFBMHStructure(t *testing.T) {
+	for _, h := range []float32{0.2, 0.5, 0.8} {
+		f := NewFBMH(1, h)
+		f.Octaves = 12
+
+		// The mean squared increment grows as r^2H
+		increment := func(r float32) (sum float64) {
+			for i := 0; i < 20000; i++ {
+				x, y := Float32(1, uint64(i))*100, Float32(2, uint64(i))*100
+				d := float64(f.Eval2(x+r, y) - f.Eval2(x, y))
+				sum += d * d
+			}
+			return sum
+		}
+
+		slope := math.Log2(increment(0.02)/increment(0.01)) / 2
+		assert.InDelta(t, float64(h), slope, 0.1)
+	}
+}
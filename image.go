@@ -0,0 +1,47 @@
+package noise
+
+import (
+	"image"
+	"image/color"
+)
+
+// ---------------------------------- Image ----------------------------------
+
+// lazyImage is an image whose pixels are evaluated from a source on demand
+type lazyImage struct {
+	src     Source2
+	bounds  image.Rectangle
+	palette func(float32) color.Color
+}
+
+// Image returns an image whose pixels are evaluated lazily from the source at their
+// integer coordinates, so that the standard image pipelines can draw, resize or
+// encode noise without an intermediate buffer. Values are mapped to colors by the
+// palette, and a nil palette maps [-1, 1] to grayscale. Every call to At evaluates
+// the source again, so draw the image into a buffer first when it is read often.
+//
+// Example:
+//
+//	img := Image(Scale(NewFBM(12345), 0.01, 0.01, 1), image.Rect(0, 0, 512, 512), nil)
+//	png.Encode(file, img)
+func Image(src Source2, bounds image.Rectangle, palette func(float32) color.Color) image.Image {
+	return &lazyImage{src: src, bounds: bounds, palette: palette}
+}
+
+// ColorModel returns the color model of the image
+func (img *lazyImage) ColorModel() color.Model {
+	return color.RGBAModel
+}
+
+// Bounds returns the domain of the image
+func (img *lazyImage) Bounds() image.Rectangle {
+	return img.bounds
+}
+
+// At evaluates the color of the pixel (x, y), transparent outside of the bounds
+func (img *lazyImage) At(x, y int) color.Color {
+	if !(image.Point{x, y}.In(img.bounds)) {
+		return color.RGBA{}
+	}
+	return shadeOf(img.src.Eval2(float32(x), float32(y)), img.palette)
+}
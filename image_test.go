@@ -0,0 +1,47 @@
+package noise
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImage(t *testing.T) {
+	src := Scale(NewFBM(1), 0.05, 0.05, 1)
+	img := Image(src, image.Rect(-8, -8, 24, 24), nil)
+	assert.Equal(t, image.Rect(-8, -8, 24, 24), img.Bounds())
+	assert.Equal(t, color.RGBAModel, img.ColorModel())
+	assert.Equal(t, shadeOf(src.Eval2(-3, 5), nil), img.At(-3, 5))
+	assert.Equal(t, color.RGBA{}, img.At(24, 0))
+
+	// Standard pipelines consume it directly
+	dst := image.NewRGBA(image.Rect(0, 0, 32, 32))
+	draw.Draw(dst, dst.Bounds(), img, image.Pt(-8, -8), draw.Src)
+	assert.Equal(t, img.At(-8, -8), dst.At(0, 0))
+	assert.Equal(t, img.At(10, 12), dst.At(18, 20))
+
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, img))
+	decoded, err := png.Decode(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, 32, decoded.Bounds().Dx())
+}
+
+func TestImagePalette(t *testing.T) {
+	palette := func(v float32) color.Color {
+		if v < 0 {
+			return color.RGBA{0, 0, 255, 255}
+		}
+		return color.RGBA{0, 255, 0, 255}
+	}
+
+	flat := SourceFunc(func(x, y, z float32) float32 { return x - 2 })
+	img := Image(flat, image.Rect(0, 0, 4, 1), palette)
+	assert.Equal(t, color.RGBA{0, 0, 255, 255}, img.At(1, 0))
+	assert.Equal(t, color.RGBA{0, 255, 0, 255}, img.At(3, 0))
+}
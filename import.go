@@ -0,0 +1,190 @@
+package noise
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ---------------------------------- Grid Import ----------------------------------
+
+// Import reads a grid written in a self-describing format, NPY or CSV, and returns
+// its values in row-major order along with its width and height. This brings fields
+// processed elsewhere, for example with NumPy, back as heightmaps. NPY arrays must
+// be 2D and of a float dtype, and float64 values are narrowed to float32.
+//
+// Example:
+//
+//	heights, w, h, err := Import(file, NPY) // from np.save("eroded.npy", field)
+func Import(src io.Reader, format Format) ([]float32, int, int, error) {
+	switch format {
+	case NPY:
+		return readNPY(src)
+	case CSV:
+		return readCSV(src)
+	default:
+		return nil, 0, 0, fmt.Errorf("noise: unsupported import format %d", format)
+	}
+}
+
+// ---------------------------------- NumPy ----------------------------------
+
+// npyMagic is the magic string that starts every .npy file
+const npyMagic = "\x93NUMPY"
+
+// writeNPY writes the region [x0, x1) × [y0, y1) of a grid as a version 1.0 .npy
+// file, whose header is padded so that the data starts on a 64-byte boundary
+func writeNPY(dst io.Writer, values []float32, stride, x0, y0, x1, y1 int) error {
+	header := fmt.Sprintf("{'descr': '<f4', 'fortran_order': False, 'shape': (%d, %d), }", y1-y0, x1-x0)
+	pad := 63 - (len(npyMagic)+4+len(header))%64
+	header += strings.Repeat(" ", pad) + "\n"
+
+	buf := bufio.NewWriter(dst)
+	buf.WriteString(npyMagic + "\x01\x00")
+	binary.Write(buf, binary.LittleEndian, uint16(len(header)))
+	buf.WriteString(header)
+	for y := y0; y < y1; y++ {
+		if err := binary.Write(buf, binary.LittleEndian, values[y*stride+x0:y*stride+x1]); err != nil {
+			return err
+		}
+	}
+	return buf.Flush()
+}
+
+// npyHeader matches the fields of a .npy header dictionary
+var npyHeader = struct {
+	descr, order, shape *regexp.Regexp
+}{
+	descr: regexp.MustCompile(`'descr':\s*'([^']*)'`),
+	order: regexp.MustCompile(`'fortran_order':\s*(True|False)`),
+	shape: regexp.MustCompile(`'shape':\s*\(\s*(\d+)\s*,\s*(\d+)\s*,?\s*\)`),
+}
+
+// readNPY reads a 2D float32 or float64 array from a .npy file
+func readNPY(src io.Reader) ([]float32, int, int, error) {
+	r := bufio.NewReader(src)
+	prefix := make([]byte, len(npyMagic)+2)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return nil, 0, 0, err
+	}
+	if string(prefix[:len(npyMagic)]) != npyMagic {
+		return nil, 0, 0, errors.New("noise: not a npy file")
+	}
+
+	// Version 1 has a 16-bit header length, later versions a 32-bit one
+	var size uint32
+	switch prefix[len(npyMagic)] {
+	case 1:
+		var n uint16
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return nil, 0, 0, err
+		}
+		size = uint32(n)
+	default:
+		if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+			return nil, 0, 0, err
+		}
+	}
+
+	header := make([]byte, size)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, 0, 0, err
+	}
+
+	descr := npyHeader.descr.FindSubmatch(header)
+	order := npyHeader.order.FindSubmatch(header)
+	shape := npyHeader.shape.FindSubmatch(header)
+	if descr == nil || order == nil || shape == nil {
+		return nil, 0, 0, fmt.Errorf("noise: unsupported npy header %q", bytes.TrimSpace(header))
+	}
+
+	h, _ := strconv.Atoi(string(shape[1]))
+	w, _ := strconv.Atoi(string(shape[2]))
+	values := make([]float32, w*h)
+	switch string(descr[1]) {
+	case "<f4":
+		if err := binary.Read(r, binary.LittleEndian, values); err != nil {
+			return nil, 0, 0, err
+		}
+	case "<f8":
+		wide := make([]float64, w*h)
+		if err := binary.Read(r, binary.LittleEndian, wide); err != nil {
+			return nil, 0, 0, err
+		}
+		for i, v := range wide {
+			values[i] = float32(v)
+		}
+	default:
+		return nil, 0, 0, fmt.Errorf("noise: unsupported npy dtype %q", descr[1])
+	}
+
+	// Column-major arrays are transposed back to row-major order
+	if string(order[1]) == "True" {
+		rows := make([]float32, len(values))
+		for x := 0; x < w; x++ {
+			for y := 0; y < h; y++ {
+				rows[y*w+x] = values[x*h+y]
+			}
+		}
+		values = rows
+	}
+	return values, w, h, nil
+}
+
+// ---------------------------------- CSV ----------------------------------
+
+// writeCSV writes the region [x0, x1) × [y0, y1) of a grid as comma-separated rows,
+// with the shortest decimal representation that reads back to the same float32
+func writeCSV(dst io.Writer, values []float32, stride, x0, y0, x1, y1 int) error {
+	buf := bufio.NewWriter(dst)
+	line := make([]byte, 0, 16*(x1-x0))
+	for y := y0; y < y1; y++ {
+		line = line[:0]
+		for x := x0; x < x1; x++ {
+			if x > x0 {
+				line = append(line, ',')
+			}
+			line = strconv.AppendFloat(line, float64(values[y*stride+x]), 'g', -1, 32)
+		}
+
+		line = append(line, '\n')
+		if _, err := buf.Write(line); err != nil {
+			return err
+		}
+	}
+	return buf.Flush()
+}
+
+// readCSV reads a grid of comma-separated rows, which must all have the same length
+func readCSV(src io.Reader) ([]float32, int, int, error) {
+	r := csv.NewReader(src)
+	r.TrimLeadingSpace = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if len(records) == 0 {
+		return nil, 0, 0, errors.New("noise: empty csv grid")
+	}
+
+	w, h := len(records[0]), len(records)
+	values := make([]float32, 0, w*h)
+	for _, row := range records {
+		for _, field := range row {
+			v, err := strconv.ParseFloat(strings.TrimSpace(field), 32)
+			if err != nil && !math.IsInf(v, 0) {
+				return nil, 0, 0, err
+			}
+			values = append(values, float32(v))
+		}
+	}
+	return values, w, h, nil
+}
@@ -0,0 +1,95 @@
+package noise
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportNPY(t *testing.T) {
+	heights := []float32{-1.5, 0, 1, 0.5, -0.5, 2.25}
+
+	var buf bytes.Buffer
+	assert.NoError(t, Export(&buf, NPY, heights, 3, 2))
+
+	// The data starts on a 64-byte boundary, after a newline-terminated header
+	data := buf.Bytes()
+	size := int(binary.LittleEndian.Uint16(data[8:]))
+	assert.Equal(t, "\x93NUMPY\x01\x00", string(data[:8]))
+	assert.Equal(t, 0, (10+size)%64)
+	assert.Equal(t, byte('\n'), data[10+size-1])
+	assert.Contains(t, string(data[10:10+size]), "'shape': (2, 3)")
+	assert.Equal(t, 10+size+24, len(data))
+
+	values, w, h, err := Import(&buf, NPY)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, w)
+	assert.Equal(t, 2, h)
+	assert.Equal(t, heights, values)
+}
+
+func TestImportNPY(t *testing.T) {
+	npy := func(version byte, header string, data any) *bytes.Buffer {
+		var buf bytes.Buffer
+		buf.WriteString("\x93NUMPY")
+		buf.Write([]byte{version, 0})
+		if version == 1 {
+			binary.Write(&buf, binary.LittleEndian, uint16(len(header)))
+		} else {
+			binary.Write(&buf, binary.LittleEndian, uint32(len(header)))
+		}
+		buf.WriteString(header)
+		binary.Write(&buf, binary.LittleEndian, data)
+		return &buf
+	}
+
+	// Float64 arrays in column-major order, as saved by np.asfortranarray
+	src := npy(2, "{'descr': '<f8', 'fortran_order': True, 'shape': (2, 3), }\n", []float64{1, 4, 2, 5, 3, 6})
+	values, w, h, err := Import(src, NPY)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, w)
+	assert.Equal(t, 2, h)
+	assert.Equal(t, []float32{1, 2, 3, 4, 5, 6}, values)
+
+	_, _, _, err = Import(npy(1, "{'descr': '<i4', 'fortran_order': False, 'shape': (1, 1), }\n", []int32{1}), NPY)
+	assert.Error(t, err)
+	_, _, _, err = Import(npy(1, "{'descr': '<f4', 'fortran_order': False, 'shape': (4,), }\n", []float32{1, 2, 3, 4}), NPY)
+	assert.Error(t, err)
+	_, _, _, err = Import(strings.NewReader("P5 not a numpy file"), NPY)
+	assert.Error(t, err)
+	_, _, _, err = Import(strings.NewReader(""), RawFloat32)
+	assert.Error(t, err)
+}
+
+func TestExportCSV(t *testing.T) {
+	heights := []float32{-1.5, 0, 0.1, float32(math.Pi), -0.5, 1e-7}
+
+	var buf bytes.Buffer
+	assert.NoError(t, Export(&buf, CSV, heights, 3, 2))
+	assert.Equal(t, "-1.5,0,0.1\n3.1415927,-0.5,1e-07\n", buf.String())
+
+	values, w, h, err := Import(&buf, CSV)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, w)
+	assert.Equal(t, 2, h)
+	assert.Equal(t, heights, values)
+}
+
+func TestImportCSV(t *testing.T) {
+	values, w, h, err := Import(strings.NewReader("1, 2\n3, 4\n"), CSV)
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{1, 2, 3, 4}, values)
+	assert.Equal(t, 2, w)
+	assert.Equal(t, 2, h)
+
+	_, _, _, err = Import(strings.NewReader("1,2\n3\n"), CSV)
+	assert.Error(t, err)
+	_, _, _, err = Import(strings.NewReader("1,x\n"), CSV)
+	assert.Error(t, err)
+	_, _, _, err = Import(strings.NewReader(""), CSV)
+	assert.Error(t, err)
+}
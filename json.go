@@ -0,0 +1,275 @@
+package noise
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ---------------------------------- JSON Pipelines ----------------------------------
+
+// node is the JSON representation of a source and its inputs
+type node struct {
+	Type       string       `json:"type"`
+	Seed       uint32       `json:"seed,omitempty"`
+	Version    Version      `json:"version,omitempty"`
+	Value      float32      `json:"value,omitempty"`
+	Lacunarity *float32     `json:"lacunarity,omitempty"`
+	Gain       *float32     `json:"gain,omitempty"`
+	Octaves    *int         `json:"octaves,omitempty"`
+	Threshold  float32      `json:"threshold,omitempty"`
+	Falloff    float32      `json:"falloff,omitempty"`
+	Size       int          `json:"size,omitempty"`
+	Offset     *[3]float32  `json:"offset,omitempty"`
+	Factor     *[3]float32  `json:"factor,omitempty"`
+	Angles     *[3]float32  `json:"angles,omitempty"`
+	Points     [][3]float32 `json:"points,omitempty"`
+	Basis      *node        `json:"basis,omitempty"`
+	Source     *node        `json:"source,omitempty"`
+	A          *node        `json:"a,omitempty"`
+	B          *node        `json:"b,omitempty"`
+	Control    *node        `json:"control,omitempty"`
+}
+
+// operators maps the combiner operators to their JSON type names
+var operators = [...]string{
+	opAdd:      "add",
+	opMultiply: "multiply",
+	opMin:      "min",
+	opMax:      "max",
+	opPower:    "power",
+}
+
+// MarshalSource encodes a pipeline of sources, including their seeds and parameters,
+// to JSON. Every source of the package can be encoded, but a SourceFunc or a user
+// defined Source cannot, in which case an error is returned.
+//
+// Example:
+//
+//	terrain := Select(plains, mountains, continents, 0.3, 0.1)
+//	preset, err := MarshalSource(terrain)
+func MarshalSource(src Source) ([]byte, error) {
+	n, err := encodeNode(src)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(n)
+}
+
+// UnmarshalSource reconstructs a pipeline of sources from its JSON encoding, as
+// produced by MarshalSource. The reconstructed pipeline produces the same output
+// as the original one.
+func UnmarshalSource(data []byte) (Source, error) {
+	var n node
+	if err := json.Unmarshal(data, &n); err != nil {
+		return nil, err
+	}
+	return decodeNode(&n)
+}
+
+// encodeNode converts a source into its JSON representation
+func encodeNode(src Source) (*node, error) {
+	var err error
+	switch s := src.(type) {
+	case *Simplex:
+		n := &node{Type: "simplex", Seed: s.seed}
+		switch v, ok := versionOf(s); {
+		case !ok:
+			return nil, fmt.Errorf("noise: unable to marshal simplex with a custom table, use MarshalBinary")
+		case v > V1:
+			n.Version = v
+		}
+		return n, nil
+	case *Value:
+		return &node{Type: "value", Seed: s.seed}, nil
+	case *Cellular:
+		return &node{Type: "cellular", Seed: s.seed}, nil
+	case constant:
+		return &node{Type: "constant", Value: float32(s)}, nil
+	case *FBM:
+		n := &node{Type: "fbm", Lacunarity: &s.Lacunarity, Gain: &s.Gain, Octaves: &s.Octaves}
+		n.Basis, err = encodeNode(s.basis)
+		return n, err
+	case ridged:
+		n := &node{Type: "ridged"}
+		n.Source, err = encodeNode(s.src)
+		return n, err
+	case *combiner:
+		return encodeNodes(&node{Type: operators[s.op]}, s.a, s.b, nil)
+	case *blend:
+		return encodeNodes(&node{Type: "blend"}, s.a, s.b, s.control)
+	case *selector:
+		return encodeNodes(&node{Type: "select", Threshold: s.threshold, Falloff: s.falloff}, s.a, s.b, s.control)
+	case *translate:
+		n := &node{Type: "translate", Offset: &[3]float32{s.dx, s.dy, s.dz}}
+		n.Source, err = encodeNode(s.src)
+		return n, err
+	case *scale:
+		n := &node{Type: "scale", Factor: &[3]float32{s.sx, s.sy, s.sz}}
+		n.Source, err = encodeNode(s.src)
+		return n, err
+	case *rotate:
+		n := &node{Type: "rotate", Angles: &s.angles}
+		n.Source, err = encodeNode(s.src)
+		return n, err
+	case *cache:
+		n := &node{Type: "cache", Size: s.size}
+		n.Source, err = encodeNode(s.src)
+		return n, err
+	case *curve:
+		n := &node{Type: "curve", Points: make([][3]float32, 0, len(s.spline.points))}
+		for _, p := range s.spline.points {
+			n.Points = append(n.Points, [3]float32{p.x, p.y, p.slope})
+		}
+		n.Source, err = encodeNode(s.src)
+		return n, err
+	default:
+		return nil, fmt.Errorf("noise: unable to marshal source of type %T", src)
+	}
+}
+
+// encodeNodes encodes the a, b and optional control inputs of a node
+func encodeNodes(n *node, a, b, control Source) (*node, error) {
+	var err error
+	if n.A, err = encodeNode(a); err != nil {
+		return nil, err
+	}
+	if n.B, err = encodeNode(b); err != nil {
+		return nil, err
+	}
+	if control != nil {
+		n.Control, err = encodeNode(control)
+	}
+	return n, err
+}
+
+// decodeNode reconstructs a source from its JSON representation
+func decodeNode(n *node) (Source, error) {
+	if n == nil {
+		return nil, fmt.Errorf("noise: missing source")
+	}
+
+	switch n.Type {
+	case "simplex":
+		switch n.Version {
+		case 0, V1:
+			return NewSimplex(n.Seed), nil
+		case V2:
+			return NewSimplexV2(n.Seed), nil
+		default:
+			return nil, fmt.Errorf("noise: unknown simplex version %d", n.Version)
+		}
+	case "value":
+		return NewValue(n.Seed), nil
+	case "cellular":
+		return NewCellular(n.Seed), nil
+	case "constant":
+		return Constant(n.Value), nil
+	case "fbm":
+		// Without a basis, the seed is that of a simplex basis, as with NewFBM
+		basis := n.Basis
+		if basis == nil {
+			basis = &node{Type: "simplex", Seed: n.Seed, Version: n.Version}
+		}
+
+		src, err := decodeNode(basis)
+		if err != nil {
+			return nil, err
+		}
+
+		// Absent parameters keep the defaults of NewFBM
+		f := NewFBMWith(src)
+		f.Lacunarity = valueOf(n.Lacunarity, f.Lacunarity)
+		f.Gain = valueOf(n.Gain, f.Gain)
+		f.Octaves = valueOf(n.Octaves, f.Octaves)
+		return f, nil
+	case "add", "multiply", "min", "max", "power", "blend", "select":
+		return decodeNodes(n)
+	}
+
+	src, err := decodeNode(n.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.Type {
+	case "ridged":
+		return Ridged(src), nil
+	case "translate":
+		v := vector(n.Offset, 0)
+		return Translate(src, v[0], v[1], v[2]), nil
+	case "scale":
+		v := vector(n.Factor, 1)
+		return Scale(src, v[0], v[1], v[2]), nil
+	case "rotate":
+		v := vector(n.Angles, 0)
+		return Rotate(src, v[0], v[1], v[2]), nil
+	case "cache":
+		return Cache(src, n.Size), nil
+	case "curve":
+		spline := NewSpline()
+		seen := make(map[float32]bool, len(n.Points))
+		for _, p := range n.Points {
+			if seen[p[0]] {
+				return nil, fmt.Errorf("noise: duplicate spline point at %v", p[0])
+			}
+
+			seen[p[0]] = true
+			spline.Point(p[0], p[1], p[2])
+		}
+		return Curve(src, spline), nil
+	default:
+		return nil, fmt.Errorf("noise: unknown source type %q", n.Type)
+	}
+}
+
+// decodeNodes reconstructs a combiner or selector from its JSON representation
+func decodeNodes(n *node) (Source, error) {
+	a, err := decodeNode(n.A)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := decodeNode(n.B)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.Type {
+	case "add":
+		return Add(a, b), nil
+	case "multiply":
+		return Multiply(a, b), nil
+	case "min":
+		return Min(a, b), nil
+	case "max":
+		return Max(a, b), nil
+	case "power":
+		return Power(a, b), nil
+	}
+
+	control, err := decodeNode(n.Control)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.Type == "blend" {
+		return Blend(a, b, control), nil
+	}
+	return Select(a, b, control, n.Threshold, n.Falloff), nil
+}
+
+// vector returns the decoded vector, or a vector filled with the default value
+func vector(v *[3]float32, value float32) [3]float32 {
+	if v == nil {
+		return [3]float32{value, value, value}
+	}
+	return *v
+}
+
+// valueOf returns the decoded value, or the default value if it is absent
+func valueOf[T any](v *T, value T) T {
+	if v == nil {
+		return value
+	}
+	return *v
+}
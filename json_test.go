@@ -0,0 +1,96 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalSource(t *testing.T) {
+	continents := Cache(NewFBM(1), 1)
+	plains := Multiply(NewFBMWith(NewValue(2)), Constant(0.2))
+	mountains := NewFBMWith(Ridged(NewSimplex(3)))
+	mountains.Octaves = 6
+
+	pipeline := []Source{
+		Select(plains, mountains, continents, 0.3, 0.1),
+		Blend(NewCellular(4), Translate(NewSimplex(5), 1, 2, 3), continents),
+		Add(Scale(NewSimplex(6), 2, 0.5, 1), Rotate(NewSimplex(7), 10, 20, 30)),
+		Min(Max(NewSimplex(8), Constant(-0.5)), Power(Constant(0.5), NewSimplex(9))),
+		Curve(NewSimplex(10), NewSpline().Point(-1, 0, 0).Point(0, 0.2, 1).Point(1, 1, 0)),
+	}
+
+	for _, src := range pipeline {
+		data, err := MarshalSource(src)
+		assert.NoError(t, err)
+
+		out, err := UnmarshalSource(data)
+		assert.NoError(t, err)
+
+		for i := 0; i < 100; i++ {
+			x := float32(i) * 0.37
+			assert.Equal(t, src.Eval2(x, -x), out.Eval2(x, -x))
+			assert.Equal(t, src.Eval3(x, -x, x), out.Eval3(x, -x, x))
+		}
+
+		// Encoding is stable
+		again, err := MarshalSource(out)
+		assert.NoError(t, err)
+		assert.Equal(t, string(data), string(again))
+	}
+}
+
+func TestMarshalSourceFormat(t *testing.T) {
+	data, err := MarshalSource(Add(NewSimplex(42), Constant(0.5)))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"type":"add","a":{"type":"simplex","seed":42},"b":{"type":"constant","value":0.5}}`, string(data))
+
+	src, err := UnmarshalSource([]byte(`{"type":"fbm","lacunarity":2,"gain":0.5,"octaves":4,"basis":{"type":"simplex","seed":42}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, NewFBM(42).Eval2(1.3, 2.7), src.Eval2(1.3, 2.7))
+
+	// Absent parameters take the defaults of NewFBM
+	src, err = UnmarshalSource([]byte(`{"type":"fbm","seed":1}`))
+	assert.NoError(t, err)
+	assert.Equal(t, NewFBM(1).Eval2(1.3, 2.7), src.Eval2(1.3, 2.7))
+	assert.Equal(t, NewFBM(1).Lacunarity, src.(*FBM).Lacunarity)
+	assert.Equal(t, NewFBM(1).Gain, src.(*FBM).Gain)
+	assert.Equal(t, NewFBM(1).Octaves, src.(*FBM).Octaves)
+
+	src, err = UnmarshalSource([]byte(`{"type":"fbm","seed":1,"version":2,"octaves":6}`))
+	assert.NoError(t, err)
+	v2 := NewFBMWith(NewSimplexV2(1))
+	v2.Octaves = 6
+	assert.Equal(t, v2.Eval3(1.3, 2.7, 0.5), src.Eval3(1.3, 2.7, 0.5))
+
+	// Explicit zeros are kept
+	zero := NewFBM(3)
+	zero.Octaves = 0
+	data, err = MarshalSource(zero)
+	assert.NoError(t, err)
+	src, err = UnmarshalSource(data)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, src.(*FBM).Octaves)
+}
+
+func TestMarshalSourceErrors(t *testing.T) {
+	_, err := MarshalSource(SourceFunc(func(x, y, z float32) float32 { return 0 }))
+	assert.Error(t, err)
+
+	_, err = MarshalSource(Add(NewSimplex(1), SourceFunc(func(x, y, z float32) float32 { return 0 })))
+	assert.Error(t, err)
+
+	for _, data := range []string{
+		`{`,
+		`{"type":"unknown"}`,
+		`{"type":"add","a":{"type":"simplex"}}`,
+		`{"type":"blend","a":{"type":"simplex"},"b":{"type":"simplex"}}`,
+		`{"type":"fbm","basis":{"type":"unknown"}}`,
+		`{"type":"fbm","version":9}`,
+		`{"type":"ridged"}`,
+		`{"type":"curve","source":{"type":"simplex"},"points":[[0,0,0],[0,1,0]]}`,
+	} {
+		_, err := UnmarshalSource([]byte(data))
+		assert.Error(t, err, data)
+	}
+}
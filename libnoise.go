@@ -0,0 +1,206 @@
+package noise
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ---------------------------------- libnoise ----------------------------------
+
+// LibnoiseQuality is the interpolation quality of libnoise coherent noise
+type LibnoiseQuality int
+
+const (
+	LibnoiseFast     LibnoiseQuality = iota // Linear interpolation, QUALITY_FAST
+	LibnoiseStandard                        // Cubic s-curve interpolation, QUALITY_STD
+	LibnoiseBest                            // Quintic s-curve interpolation, QUALITY_BEST
+)
+
+// LibnoisePerlin reproduces the output of the Perlin module of libnoise for the same
+// seed and settings, so that terrains built with it can be migrated value for value.
+// Like libnoise, it evaluates in double precision, and products are rounded before
+// being summed so that no platform fuses them into different results. The gradients
+// of libnoise are a table of random vectors distributed under the LGPL with the
+// library, so the table is not bundled and must be loaded from vectortable.h with
+// ParseLibnoiseVectors.
+type LibnoisePerlin struct {
+	Seed        int32           // Seed of the noise, default 0
+	Frequency   float64         // Frequency of the first octave, default 1
+	Lacunarity  float64         // Frequency multiplier between octaves, default 2
+	Persistence float64         // Amplitude multiplier between octaves, default 0.5
+	Octaves     int             // Number of octaves, default 6
+	Quality     LibnoiseQuality // Interpolation quality, default LibnoiseStandard
+	vectors     []float64       // Random unit vectors, 4 components each
+}
+
+// NewLibnoisePerlin creates a generator with the defaults of the libnoise Perlin
+// module, using the 256 gradients of g_randomVectors. It panics if the table does
+// not hold 1024 values.
+//
+// Example:
+//
+//	header, _ := os.ReadFile("libnoise/src/vectortable.h")
+//	vectors, _ := ParseLibnoiseVectors(string(header))
+//	perlin := NewLibnoisePerlin(0, vectors)
+//	v := perlin.Eval64(x, y, z) // module::Perlin::GetValue(x, y, z)
+func NewLibnoisePerlin(seed int32, vectors []float64) *LibnoisePerlin {
+	if len(vectors) != 1024 {
+		panic("noise: libnoise needs the 1024 values of g_randomVectors")
+	}
+
+	return &LibnoisePerlin{
+		Seed:        seed,
+		Frequency:   1,
+		Lacunarity:  2,
+		Persistence: 0.5,
+		Octaves:     6,
+		Quality:     LibnoiseStandard,
+		vectors:     vectors,
+	}
+}
+
+// libnoiseTable matches the initializer of g_randomVectors
+var libnoiseTable = regexp.MustCompile(`g_randomVectors[^{]*\{([^}]*)\}`)
+
+// ParseLibnoiseVectors extracts the g_randomVectors table from the source of the
+// vectortable.h header of libnoise
+func ParseLibnoiseVectors(header string) ([]float64, error) {
+	match := libnoiseTable.FindStringSubmatch(header)
+	if match == nil {
+		return nil, fmt.Errorf("noise: g_randomVectors not found")
+	}
+
+	var out []float64
+	for _, field := range strings.Split(match[1], ",") {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+
+		v, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+
+	if len(out) != 1024 {
+		return nil, fmt.Errorf("noise: g_randomVectors has %d values, expected 1024", len(out))
+	}
+	return out, nil
+}
+
+// Eval2 evaluates the noise on the z = 0 plane, as planar noise maps of libnoise do
+func (p *LibnoisePerlin) Eval2(x, y float32) float32 {
+	return float32(p.Eval64(float64(x), float64(y), 0))
+}
+
+// Eval3 evaluates the noise at (x, y, z)
+func (p *LibnoisePerlin) Eval3(x, y, z float32) float32 {
+	return float32(p.Eval64(float64(x), float64(y), float64(z)))
+}
+
+// Eval64 evaluates the noise at (x, y, z) in double precision, like GetValue. The
+// output is roughly in [-1, 1], but can exceed it as it does in libnoise.
+func (p *LibnoisePerlin) Eval64(x, y, z float64) float64 {
+	x *= p.Frequency
+	y *= p.Frequency
+	z *= p.Frequency
+
+	value, amp := 0.0, 1.0
+	for i := 0; i < p.Octaves; i++ {
+		nx, ny, nz := libnoiseRange(x), libnoiseRange(y), libnoiseRange(z)
+		value += float64(p.coherent(nx, ny, nz, p.Seed+int32(i)) * amp)
+
+		x *= p.Lacunarity
+		y *= p.Lacunarity
+		z *= p.Lacunarity
+		amp *= p.Persistence
+	}
+	return value
+}
+
+// coherent evaluates one octave of gradient noise, like GradientCoherentNoise3D
+func (p *LibnoisePerlin) coherent(x, y, z float64, seed int32) float64 {
+	x0, y0, z0 := libnoiseFloor(x), libnoiseFloor(y), libnoiseFloor(z)
+	x1, y1, z1 := x0+1, y0+1, z0+1
+
+	xs, ys, zs := x-float64(x0), y-float64(y0), z-float64(z0)
+	switch p.Quality {
+	case LibnoiseStandard:
+		xs, ys, zs = libnoiseCurve3(xs), libnoiseCurve3(ys), libnoiseCurve3(zs)
+	case LibnoiseBest:
+		xs, ys, zs = libnoiseCurve5(xs), libnoiseCurve5(ys), libnoiseCurve5(zs)
+	}
+
+	n0 := p.gradient(x, y, z, x0, y0, z0, seed)
+	n1 := p.gradient(x, y, z, x1, y0, z0, seed)
+	ix0 := libnoiseLerp(n0, n1, xs)
+	n0 = p.gradient(x, y, z, x0, y1, z0, seed)
+	n1 = p.gradient(x, y, z, x1, y1, z0, seed)
+	ix1 := libnoiseLerp(n0, n1, xs)
+	iy0 := libnoiseLerp(ix0, ix1, ys)
+
+	n0 = p.gradient(x, y, z, x0, y0, z1, seed)
+	n1 = p.gradient(x, y, z, x1, y0, z1, seed)
+	ix0 = libnoiseLerp(n0, n1, xs)
+	n0 = p.gradient(x, y, z, x0, y1, z1, seed)
+	n1 = p.gradient(x, y, z, x1, y1, z1, seed)
+	ix1 = libnoiseLerp(n0, n1, xs)
+	iy1 := libnoiseLerp(ix0, ix1, ys)
+	return libnoiseLerp(iy0, iy1, zs)
+}
+
+// gradient returns the dot product of the hashed gradient of a lattice point and
+// the offset to it, like GradientNoise3D
+func (p *LibnoisePerlin) gradient(x, y, z float64, ix, iy, iz, seed int32) float64 {
+	i := 1619*ix + 31337*iy + 6971*iz + 1013*seed
+	i ^= i >> 8
+	i &= 0xff
+
+	g := p.vectors[i<<2 : i<<2+3]
+	return (float64(g[0]*(x-float64(ix))) + float64(g[1]*(y-float64(iy))) + float64(g[2]*(z-float64(iz)))) * 2.12
+}
+
+// libnoiseFloor matches the lattice rounding of libnoise, which also rounds zero
+// and negative integers down to the next integer
+func libnoiseFloor(v float64) int32 {
+	if v > 0 {
+		return int32(v)
+	}
+	return int32(v) - 1
+}
+
+// libnoiseRange wraps large coordinates into the range of 32-bit integers, like
+// MakeInt32Range
+func libnoiseRange(v float64) float64 {
+	const limit = 1073741824.0
+	switch {
+	case v >= limit:
+		return 2*math.Mod(v, limit) - limit
+	case v <= -limit:
+		return 2*math.Mod(v, limit) + limit
+	default:
+		return v
+	}
+}
+
+// libnoiseCurve3 is the cubic s-curve 3a² - 2a³
+func libnoiseCurve3(a float64) float64 {
+	return a * a * (3 - float64(2*a))
+}
+
+// libnoiseCurve5 is the quintic s-curve 6a⁵ - 15a⁴ + 10a³
+func libnoiseCurve5(a float64) float64 {
+	a3 := a * a * a
+	a4 := a3 * a
+	a5 := a4 * a
+	return float64(6*a5) - float64(15*a4) + float64(10*a3)
+}
+
+// libnoiseLerp interpolates linearly between n0 and n1
+func libnoiseLerp(n0, n1, a float64) float64 {
+	return float64((1-a)*n0) + float64(a*n1)
+}
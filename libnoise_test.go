@@ -0,0 +1,75 @@
+package noise
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// libnoiseVectors returns a stand-in for g_randomVectors, made of deterministic unit
+// vectors, formatted like vectortable.h
+func libnoiseVectors() string {
+	var sb strings.Builder
+	sb.WriteString("// version 2.1 of the License\nconst double g_randomVectors[256 * 4] =\n{\n")
+	for i := 0; i < 256; i++ {
+		theta := 2 * math.Pi * Float64(1, uint64(i))
+		z := 2*Float64(2, uint64(i)) - 1
+		r := math.Sqrt(1 - z*z)
+		fmt.Fprintf(&sb, "  %v, %v, %v, 0.0,\n", r*math.Cos(theta), r*math.Sin(theta), z)
+	}
+	sb.WriteString("};\n")
+	return sb.String()
+}
+
+func TestLibnoisePerlin(t *testing.T) {
+	vectors, err := ParseLibnoiseVectors(libnoiseVectors())
+	assert.NoError(t, err)
+	assert.Len(t, vectors, 1024)
+
+	for _, quality := range []LibnoiseQuality{LibnoiseFast, LibnoiseStandard, LibnoiseBest} {
+		p := NewLibnoisePerlin(0, vectors)
+		p.Quality = quality
+
+		// Lattice points have no gradient contribution at any octave
+		assert.Equal(t, 0.0, p.Eval64(1, 2, 3))
+
+		lo, hi := 1.0, -1.0
+		for i := 0; i < 2000; i++ {
+			v := p.Eval64(float64(i)*0.137, float64(i)*-0.071, 0.5)
+			lo, hi = min(lo, v), max(hi, v)
+		}
+		assert.Greater(t, lo, -2.0)
+		assert.Less(t, hi, 2.0)
+		assert.Less(t, lo, -0.3)
+		assert.Greater(t, hi, 0.3)
+	}
+
+	p := NewLibnoisePerlin(7, vectors)
+	assert.NotEqual(t, p.Eval64(0.3, 0.4, 0.5), NewLibnoisePerlin(8, vectors).Eval64(0.3, 0.4, 0.5))
+	assert.Equal(t, float32(p.Eval64(0.25, 0.5, 0)), p.Eval2(0.25, 0.5))
+	assert.Equal(t, float32(p.Eval64(0.25, 0.5, 2)), p.Eval3(0.25, 0.5, 2))
+	assert.InDelta(t, p.Eval64(10.3, 0.4, 0.5), p.Eval64(10.3+1e-6, 0.4, 0.5), 1e-4)
+}
+
+func TestLibnoiseHelpers(t *testing.T) {
+	assert.Equal(t, int32(-1), libnoiseFloor(0))
+	assert.Equal(t, int32(-2), libnoiseFloor(-1))
+	assert.Equal(t, int32(2), libnoiseFloor(2.5))
+	assert.Equal(t, 5.0, libnoiseRange(5))
+	assert.Equal(t, -1073741824.0+2, libnoiseRange(1073741825))
+	assert.Equal(t, 1073741824.0-2, libnoiseRange(-1073741825))
+	assert.Equal(t, 0.5, libnoiseCurve3(0.5))
+	assert.Equal(t, 0.5, libnoiseCurve5(0.5))
+	assert.Equal(t, 1.0, libnoiseCurve5(1))
+
+	_, err := ParseLibnoiseVectors("const double other[] = { 1.0 };")
+	assert.Error(t, err)
+	_, err = ParseLibnoiseVectors("g_randomVectors[4] = { 1.0, 2.0 };")
+	assert.Error(t, err)
+	_, err = ParseLibnoiseVectors("g_randomVectors[4] = { 1.0, x };")
+	assert.Error(t, err)
+	assert.Panics(t, func() { NewLibnoisePerlin(0, make([]float64, 4)) })
+}
@@ -0,0 +1,78 @@
+package noise
+
+import "math"
+
+// ---------------------------------- Level of Detail ----------------------------------
+
+// EvalLOD evaluates 2D fBM band-limited for the given level of detail, where each
+// level halves the sampling rate. The octaves too fine to be represented at that
+// rate are left out, so that distant terrain sampled sparsely is both cheaper and
+// free of aliasing. Fractional levels fade the finest remaining octave out, so the
+// detail changes continuously with the distance. Level 0 is the same as Eval2, and
+// every level keeps the same scale, so only the detail differs.
+//
+// Example:
+//
+//	level := float32(math.Log2(float64(distance / 64))) // one level per doubling
+//	height := terrain.EvalLOD(x, y, max(level, 0))
+func (f *FBM) EvalLOD(x, y, level float32) float32 {
+	if f.Octaves <= 0 {
+		return 0
+	}
+
+	// Each level drops the octaves above half of the previous highest frequency,
+	// which is log2(2) / log2(lacunarity) octaves
+	keep := float32(f.Octaves)
+	if level > 0 && f.Lacunarity > 1 {
+		keep -= float32(float64(level) / math.Log2(float64(f.Lacunarity)))
+	}
+
+	var sum, totalAmp float32
+	var amp, freq float32 = 1, 1
+	for o := 0; o < f.Octaves; o++ {
+		if weight := min(keep-float32(o), 1); weight > 0 {
+			noise := f.noise2D(x*freq, y*freq)
+			if weight < 1 {
+				noise *= weight
+			}
+			sum += float32(amp * noise)
+		}
+
+		totalAmp += amp
+		freq *= f.Lacunarity
+		amp *= f.Gain
+	}
+
+	if totalAmp > 0 {
+		return sum / totalAmp
+	}
+	return 0
+}
+
+// EvalFiltered evaluates 2D fBM filtered for a pixel footprint, where dx and dy are
+// the distances in noise units between two neighbouring pixels along each axis. An
+// octave is fully kept while its frequency is below a quarter of the sampling rate
+// and fades out as it reaches the Nyquist frequency, which removes the shimmering
+// of fine octaves when rendering at varying zoom levels. The larger of the two
+// distances is used, so that the most compressed axis does not alias.
+//
+// Example:
+//
+//	step := 1 / zoom // noise units per pixel
+//	for py := 0; py < h; py++ {
+//	    for px := 0; px < w; px++ {
+//	        v := fbm.EvalFiltered(x0+float32(px)*step, y0+float32(py)*step, step, step)
+//	    }
+//	}
+func (f *FBM) EvalFiltered(x, y, dx, dy float32) float32 {
+	footprint := max(abs(dx), abs(dy))
+	if footprint <= 0 || f.Octaves <= 0 {
+		return f.Eval2(x, y)
+	}
+
+	// The finest octave has a frequency of lacunarity^(octaves-1), and each level of
+	// detail halves the highest frequency kept
+	highest := math.Pow(float64(f.Lacunarity), float64(f.Octaves-1))
+	level := math.Log2(highest * float64(footprint) * 4)
+	return f.EvalLOD(x, y, float32(max(level, 0)))
+}
@@ -0,0 +1,73 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvalLOD(t *testing.T) {
+	f := NewFBM(1)
+	f.Octaves = 6
+	for i := 0; i < 100; i++ {
+		x, y := float32(i)*0.37, float32(i)*0.11
+		assert.Equal(t, f.Eval2(x, y), f.EvalLOD(x, y, 0))
+	}
+
+	// Each level matches fBM with one octave less, on the same scale
+	coarse := f.Clone()
+	coarse.Octaves = 4
+	for i := 0; i < 100; i++ {
+		x, y := float32(i)*0.37, float32(i)*0.11
+		scale := float32(1+0.5+0.25+0.125) / float32(1+0.5+0.25+0.125+0.0625+0.03125)
+		assert.InDelta(t, float64(coarse.Eval2(x, y)*scale), float64(f.EvalLOD(x, y, 2)), 1e-5)
+	}
+
+	// Fractional levels move continuously between the integer ones
+	x, y := float32(1.3), float32(2.7)
+	a, b := f.EvalLOD(x, y, 1), f.EvalLOD(x, y, 2)
+	assert.InDelta(t, float64(a+b)/2, float64(f.EvalLOD(x, y, 1.5)), 1e-5)
+	assert.Equal(t, float32(0), f.EvalLOD(x, y, 100))
+
+	f.Octaves = 0
+	assert.Equal(t, float32(0), f.EvalLOD(x, y, 0))
+}
+
+func TestEvalLODSmooth(t *testing.T) {
+	f := NewFBM(1)
+	f.Octaves = 8
+
+	// Coarser levels vary less between neighbouring samples
+	roughness := func(level float32) (sum float32) {
+		for i := 0; i < 1000; i++ {
+			x := float32(i) * 0.01
+			sum += abs(f.EvalLOD(x+0.01, 0.5, level) - f.EvalLOD(x, 0.5, level))
+		}
+		return
+	}
+	assert.Less(t, roughness(4), roughness(0))
+}
+
+func TestEvalFiltered(t *testing.T) {
+	f := NewFBM(1)
+	f.Octaves = 6
+
+	// A footprint well below the finest octave keeps every octave
+	x, y := float32(1.3), float32(2.7)
+	assert.Equal(t, f.Eval2(x, y), f.EvalFiltered(x, y, 0.001, 0.001))
+	assert.Equal(t, f.Eval2(x, y), f.EvalFiltered(x, y, 0, 0))
+
+	// The larger axis of the footprint sets the filter
+	assert.Equal(t, f.EvalFiltered(x, y, 0.1, 0.01), f.EvalFiltered(x, y, -0.01, 0.1))
+	assert.Equal(t, f.EvalLOD(x, y, 2), f.EvalFiltered(x, y, 1.0/32, 0))
+
+	// Sampling every 2 units cannot resolve any octave, so the field averages out
+	var raw, filtered float32
+	for i := 0; i < 1000; i++ {
+		px, py := float32(i%32)*2, float32(i/32)*2
+		raw += abs(f.Eval2(px, py))
+		filtered += abs(f.EvalFiltered(px, py, 2, 2))
+	}
+	assert.Greater(t, raw, float32(10))
+	assert.Equal(t, float32(0), filtered)
+}
@@ -0,0 +1,138 @@
+package noise
+
+import "math"
+
+const (
+	f4 = 0.309017  // float32((math.Sqrt(5) - 1) / 4)
+	g4 = 0.1381966 // float32((5 - math.Sqrt(5)) / 20)
+)
+
+// ---------------------------------- Looping Noise ----------------------------------
+
+// grad4 holds the 32 gradients of 4D simplex noise, the midpoints of the edges of
+// a 4D hypercube
+var grad4 = func() (out [32][4]float32) {
+	i := 0
+	for a := 0; a < 4; a++ {
+		for _, s := range [8][3]float32{
+			{1, 1, 1}, {1, 1, -1}, {1, -1, 1}, {1, -1, -1},
+			{-1, 1, 1}, {-1, 1, -1}, {-1, -1, 1}, {-1, -1, -1},
+		} {
+			// Insert a zero at position a, the other components are ±1
+			var g [4]float32
+			for k, c := 0, 0; k < 4; k++ {
+				if k != a {
+					g[k] = s[c]
+					c++
+				}
+			}
+			out[i] = g
+			i++
+		}
+	}
+	return
+}()
+
+// EvalLoop evaluates 2D simplex noise animated over time t, such that the animation
+// loops seamlessly after period. The time axis is mapped onto a circle of
+// circumference period in two extra dimensions of 4D simplex noise, so the noise
+// changes at the same rate as when moving t units along a third axis.
+//
+// Example:
+//
+//	const frames = 60
+//	for f := 0; f < frames; f++ {
+//	    v := noise.EvalLoop(x, y, float32(f), frames) // frame 60 equals frame 0
+//	}
+func (s *Simplex) EvalLoop(x, y, t, period float32) float32 {
+	z, w := loop(t, period)
+	return s.noise4D(x, y, z, w)
+}
+
+// EvalLoop evaluates 2D fBM animated over time t, such that the animation loops
+// seamlessly after period. It panics if the basis of the generator is not simplex
+// noise, as it relies on 4D simplex noise.
+func (f *FBM) EvalLoop(x, y, t, period float32) float32 {
+	if f.simplex == nil {
+		panic("noise: EvalLoop requires a simplex basis")
+	}
+	if f.Octaves <= 0 {
+		return 0
+	}
+
+	z, w := loop(t, period)
+	var sum, total float32
+	amp, freq := float32(1), float32(1)
+	for o := 0; o < f.Octaves; o++ {
+		sum += float32(amp * f.simplex.noise4D(x*freq, y*freq, z*freq, w*freq))
+		total += amp
+		freq *= f.Lacunarity
+		amp *= f.Gain
+	}
+	return sum / total
+}
+
+// loop maps the time t onto a circle of circumference period
+func loop(t, period float32) (float32, float32) {
+	if period <= 0 {
+		panic("noise: loop period must be positive")
+	}
+
+	r := float64(period) / (2 * math.Pi)
+	a := 2 * math.Pi * float64(t/period)
+	return float32(r * math.Cos(a)), float32(r * math.Sin(a))
+}
+
+// noise4D computes 4D simplex noise using the generator's permutation table
+func (s *Simplex) noise4D(x, y, z, w float32) float32 {
+	// Skew the input space to determine which simplex cell we're in
+	sk := float32((x + y + z + w) * f4)
+	i, j, k, l := floor(x+sk), floor(y+sk), floor(z+sk), floor(w+sk)
+
+	// Unskew the cell origin back to (x,y,z,w) space
+	t := float32(float32(i+j+k+l) * g4)
+	x0 := x - (float32(i) - t)
+	y0 := y - (float32(j) - t)
+	z0 := z - (float32(k) - t)
+	w0 := w - (float32(l) - t)
+
+	// Rank the coordinates by magnitude to find which of the 24 simplices we are
+	// in, the corners are then visited by stepping along the largest axes first
+	var rank [4]int
+	d := [4]float32{x0, y0, z0, w0}
+	for a := 0; a < 4; a++ {
+		for b := a + 1; b < 4; b++ {
+			if d[a] > d[b] {
+				rank[a]++
+			} else {
+				rank[b]++
+			}
+		}
+	}
+
+	ii, jj, kk, ll := i&255, j&255, k&255, l&255
+	var n float32
+	for c := 0; c <= 4; c++ {
+		// Offset of the corner in (i,j,k,l) coords, each axis steps once its rank is reached
+		var o [4]int
+		for a := range o {
+			if rank[a] >= 4-c {
+				o[a] = 1
+			}
+		}
+
+		g := float32(c) * g4
+		cx := x0 - float32(o[0]) + g
+		cy := y0 - float32(o[1]) + g
+		cz := z0 - float32(o[2]) + g
+		cw := w0 - float32(o[3]) + g
+		if t := 0.6 - float32(cx*cx) - float32(cy*cy) - float32(cz*cz) - float32(cw*cw); t > 0 {
+			h := s.perm[ii+o[0]+int(s.perm[jj+o[1]+int(s.perm[kk+o[2]+int(s.perm[ll+o[3]])])])] % 32
+			gr := grad4[h]
+			n += float32(pow4(t) * (gr[0]*cx + gr[1]*cy + gr[2]*cz + gr[3]*cw))
+		}
+	}
+
+	// The result is scaled to stay just inside [-1,1]
+	return 27.0 * n
+}
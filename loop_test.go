@@ -0,0 +1,48 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoise4D(t *testing.T) {
+	s := NewSimplex(42)
+	var lo, hi float32
+	for i := 0; i < 20000; i++ {
+		h := xxhash64(uint64(i), 1)
+		v := s.noise4D(Float32(1, h)*20, Float32(2, h)*20, Float32(3, h)*20, Float32(4, h)*20)
+		lo, hi = min(lo, v), max(hi, v)
+	}
+
+	assert.True(t, lo >= -1 && hi <= 1, "range [%v, %v]", lo, hi)
+	assert.Less(t, lo, float32(-0.5))
+	assert.Greater(t, hi, float32(0.5))
+	assert.Equal(t, float32(0), s.noise4D(0, 0, 0, 0))
+}
+
+func TestEvalLoop(t *testing.T) {
+	s, f := NewSimplex(42), NewFBM(42)
+	for _, tt := range []float32{0, 3, 17.5} {
+		assert.InDelta(t, s.EvalLoop(1.3, 2.7, tt, 30), s.EvalLoop(1.3, 2.7, tt+30, 30), 1e-4)
+		assert.InDelta(t, f.EvalLoop(1.3, 2.7, tt, 30), f.EvalLoop(1.3, 2.7, tt+30, 30), 1e-4)
+	}
+
+	// Consecutive frames change smoothly
+	var jump float32
+	for i := 0; i < 60; i++ {
+		jump = max(jump, abs(s.EvalLoop(0.5, 0.5, float32(i)*0.1, 6)-s.EvalLoop(0.5, 0.5, float32(i+1)*0.1, 6)))
+	}
+	assert.Less(t, jump, float32(0.3))
+
+	assert.Panics(t, func() { s.EvalLoop(0, 0, 0, 0) })
+	assert.Panics(t, func() { NewFBMWith(NewValue(1)).EvalLoop(0, 0, 0, 1) })
+}
+
+func BenchmarkEvalLoop(b *testing.B) {
+	s := NewSimplex(42)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.EvalLoop(1.3, 2.7, float32(i), 64)
+	}
+}
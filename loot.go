@@ -0,0 +1,126 @@
+package noise
+
+// ---------------------------------- Loot Tables ----------------------------------
+
+// Loot is a quantity of an item dropped by a loot table
+type Loot[T any] struct {
+	Item  T   // Item that was dropped
+	Count int // Number of copies of the item
+}
+
+// LootTable is a deterministic drop table. Each roll picks one weighted entry, which
+// is either an item with a quantity range, a nested table which is rolled in turn,
+// or nothing. Guaranteed entries drop on every evaluation regardless of the rolls.
+// Tables are built once and are safe for concurrent evaluation afterwards.
+type LootTable[T any] struct {
+	rolls   int
+	entries []lootEntry[T] // weighted entries
+	always  []lootEntry[T] // guaranteed entries
+	weights []float32      // weights of the entries, for Weighted
+}
+
+// lootEntry is an item, a nested table or nothing
+type lootEntry[T any] struct {
+	item     T
+	table    *LootTable[T]
+	nothing  bool
+	min, max int
+}
+
+// NewLootTable creates an empty loot table which picks rolls weighted entries
+// every time it is evaluated.
+//
+// Example:
+//
+//	gems := NewLootTable[string](1).
+//	    Add("ruby", 1, 1, 1).
+//	    Add("emerald", 1, 1, 1)
+//
+//	chest := NewLootTable[string](2).
+//	    Always("gold", 10, 50).
+//	    Add("potion", 60, 1, 3).
+//	    AddTable(gems, 10).
+//	    Nothing(30)
+//
+//	drops := chest.Roll(12345, encounterID)
+func NewLootTable[T any](rolls int) *LootTable[T] {
+	if rolls < 0 {
+		panic("invalid argument to NewLootTable")
+	}
+
+	return &LootTable[T]{rolls: rolls}
+}
+
+// Add adds an item with the given weight, dropping between min and max copies
+func (t *LootTable[T]) Add(item T, weight float32, min, max int) *LootTable[T] {
+	if min > max {
+		panic("invalid range: min > max")
+	}
+
+	return t.add(weight, lootEntry[T]{item: item, min: min, max: max})
+}
+
+// AddTable adds a nested table with the given weight, which is rolled when picked
+func (t *LootTable[T]) AddTable(table *LootTable[T], weight float32) *LootTable[T] {
+	return t.add(weight, lootEntry[T]{table: table})
+}
+
+// Nothing adds an entry with the given weight which drops nothing when picked
+func (t *LootTable[T]) Nothing(weight float32) *LootTable[T] {
+	return t.add(weight, lootEntry[T]{nothing: true})
+}
+
+// Always adds a guaranteed drop of between min and max copies of an item
+func (t *LootTable[T]) Always(item T, min, max int) *LootTable[T] {
+	if min > max {
+		panic("invalid range: min > max")
+	}
+
+	t.always = append(t.always, lootEntry[T]{item: item, min: min, max: max})
+	return t
+}
+
+// add appends a weighted entry
+func (t *LootTable[T]) add(weight float32, entry lootEntry[T]) *LootTable[T] {
+	t.entries = append(t.entries, entry)
+	t.weights = append(t.weights, weight)
+	return t
+}
+
+// Roll evaluates the table for an encounter, returning the guaranteed drops
+// followed by the drops of each roll. The same seed and encounter always produce
+// the same drops. Entries with a count of zero are omitted.
+func (t *LootTable[T]) Roll(seed uint32, encounter uint64) []Loot[T] {
+	return t.roll(seed, encounter, nil)
+}
+
+// roll appends the drops of the table for the key to out
+func (t *LootTable[T]) roll(seed uint32, key uint64, out []Loot[T]) []Loot[T] {
+	for i, e := range t.always {
+		out = e.drop(seed, xxhash64(uint64(i), key^0x9e3779b97f4a7c15), out)
+	}
+
+	if len(t.entries) == 0 {
+		return out
+	}
+
+	for r := 0; r < t.rolls; r++ {
+		k := xxhash64(uint64(r), key)
+		switch e := t.entries[Weighted(seed, k, t.weights)]; {
+		case e.nothing:
+		case e.table != nil:
+			out = e.table.roll(seed, xxhash64(k, uint64(seed)), out)
+		default:
+			out = e.drop(seed, k, out)
+		}
+	}
+	return out
+}
+
+// drop rolls the quantity of an item entry and appends it to out
+func (e *lootEntry[T]) drop(seed uint32, key uint64, out []Loot[T]) []Loot[T] {
+	if n := IntIn(seed^1, e.min, e.max, key); n > 0 {
+		out = append(out, Loot[T]{Item: e.item, Count: n})
+	}
+	return out
+}
@@ -0,0 +1,51 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLootTable(t *testing.T) {
+	gems := NewLootTable[string](1).
+		Add("ruby", 1, 1, 1).
+		Add("emerald", 1, 1, 1)
+
+	chest := NewLootTable[string](2).
+		Always("gold", 10, 50).
+		Add("potion", 60, 1, 3).
+		AddTable(gems, 10).
+		Nothing(30)
+
+	assert.Equal(t, chest.Roll(42, 7), chest.Roll(42, 7))
+
+	counts := make(map[string]int)
+	for id := uint64(0); id < 5000; id++ {
+		drops := chest.Roll(42, id)
+		assert.Equal(t, "gold", drops[0].Item)
+		assert.True(t, drops[0].Count >= 10 && drops[0].Count <= 50)
+		assert.LessOrEqual(t, len(drops), 3)
+
+		for _, d := range drops[1:] {
+			counts[d.Item]++
+			if d.Item == "potion" {
+				assert.True(t, d.Count >= 1 && d.Count <= 3)
+			}
+		}
+	}
+
+	// 2 rolls per encounter, with 60% potions and 5% of each gem
+	assert.InDelta(t, 6000, counts["potion"], 300)
+	assert.InDelta(t, 500, counts["ruby"], 100)
+	assert.InDelta(t, 500, counts["emerald"], 100)
+}
+
+func TestLootTableEdgeCases(t *testing.T) {
+	assert.Empty(t, NewLootTable[int](3).Roll(1, 1))
+	assert.Empty(t, NewLootTable[int](3).Add(1, 1, 0, 0).Roll(1, 1))
+	assert.Equal(t, []Loot[int]{{Item: 5, Count: 2}}, NewLootTable[int](0).Always(5, 2, 2).Roll(1, 1))
+
+	assert.Panics(t, func() { NewLootTable[int](-1) })
+	assert.Panics(t, func() { NewLootTable[int](1).Add(1, 1, 3, 2) })
+	assert.Panics(t, func() { NewLootTable[int](1).Always(1, 3, 2) })
+}
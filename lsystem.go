@@ -0,0 +1,136 @@
+package noise
+
+import (
+	"math"
+	"strings"
+)
+
+// ---------------------------------- L-Systems ----------------------------------
+
+// LSystem is a stochastic Lindenmayer system, which rewrites every symbol of a
+// string in parallel at each iteration. A symbol with several rules is replaced by
+// one of them, picked with a probability proportional to its weight, and symbols
+// without rules are kept as they are. Systems are built once and are safe for
+// concurrent expansion afterwards.
+type LSystem struct {
+	axiom string
+	rules map[rune]*lsystemRules
+}
+
+// lsystemRules holds the weighted successors of a symbol
+type lsystemRules struct {
+	successors []string
+	weights    []float32
+}
+
+// NewLSystem creates an L-system starting from the axiom, with no rules
+//
+// Example:
+//
+//	plant := NewLSystem("X").
+//	    Rule('X', "F[+X]F[-X]+X", 2).
+//	    Rule('X', "F[-X][+X]FX", 1).
+//	    Rule('F', "FF", 1)
+//
+//	for _, s := range Turtle(plant.Expand(12345, 5), 1, 25) {
+//	    // draw a line from s.From to s.To
+//	}
+func NewLSystem(axiom string) *LSystem {
+	return &LSystem{axiom: axiom, rules: make(map[rune]*lsystemRules)}
+}
+
+// Rule adds a successor of the symbol with the given weight
+func (l *LSystem) Rule(symbol rune, successor string, weight float32) *LSystem {
+	if weight <= 0 {
+		panic("invalid argument to Rule")
+	}
+
+	r := l.rules[symbol]
+	if r == nil {
+		r = &lsystemRules{}
+		l.rules[symbol] = r
+	}
+
+	r.successors = append(r.successors, successor)
+	r.weights = append(r.weights, weight)
+	return l
+}
+
+// Expand rewrites the axiom for the given number of iterations. The choice of each
+// stochastic rule is keyed by the iteration and the position of the symbol, so the
+// same seed always gives the same string. The length grows exponentially with the
+// iterations, so keep them low.
+func (l *LSystem) Expand(seed uint32, iterations int) string {
+	current := l.axiom
+	var next strings.Builder
+	for it := 0; it < iterations; it++ {
+		next.Reset()
+		for i, symbol := range current {
+			r := l.rules[symbol]
+			switch {
+			case r == nil:
+				next.WriteRune(symbol)
+			case len(r.successors) == 1:
+				next.WriteString(r.successors[0])
+			default:
+				pick := Weighted(seed, xxhash64(uint64(i), uint64(it)), r.weights)
+				next.WriteString(r.successors[pick])
+			}
+		}
+		current = next.String()
+	}
+	return current
+}
+
+// ---------------------------------- Turtle ----------------------------------
+
+// Segment is a line drawn by the turtle
+type Segment struct {
+	From, To [2]float32 // End points of the line
+	Depth    int        // Number of open branches, to thin out the outer ones
+}
+
+// Turtle interprets the symbols as turtle graphics, starting at the origin heading
+// along +y, and returns the lines it draws. F and G move forward by step while
+// drawing, f moves without drawing, + and - turn left and right by angle degrees,
+// | turns around, [ saves the state to start a branch and ] restores it. Other
+// symbols are ignored.
+func Turtle(symbols string, step, angle float32) []Segment {
+	type state struct {
+		x, y, heading float64
+	}
+
+	turn := float64(angle) * math.Pi / 180
+	t := state{heading: math.Pi / 2}
+	var stack []state
+	var out []Segment
+	for _, symbol := range symbols {
+		switch symbol {
+		case 'F', 'G', 'f':
+			x := t.x + float64(step)*math.Cos(t.heading)
+			y := t.y + float64(step)*math.Sin(t.heading)
+			if symbol != 'f' {
+				out = append(out, Segment{
+					From:  [2]float32{float32(t.x), float32(t.y)},
+					To:    [2]float32{float32(x), float32(y)},
+					Depth: len(stack),
+				})
+			}
+			t.x, t.y = x, y
+		case '+':
+			t.heading += turn
+		case '-':
+			t.heading -= turn
+		case '|':
+			t.heading += math.Pi
+		case '[':
+			stack = append(stack, t)
+		case ']':
+			if len(stack) > 0 {
+				t = stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,55 @@
+package noise
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLSystem(t *testing.T) {
+	algae := NewLSystem("A").Rule('A', "AB", 1).Rule('B', "A", 1)
+	assert.Equal(t, "A", algae.Expand(1, 0))
+	assert.Equal(t, "ABAABABA", algae.Expand(1, 4))
+
+	// Symbols without rules are kept
+	assert.Equal(t, "F+F+F", NewLSystem("F+F+F").Expand(1, 3))
+}
+
+func TestLSystemStochastic(t *testing.T) {
+	plant := NewLSystem("X").
+		Rule('X', "F[+X]F[-X]+X", 2).
+		Rule('X', "F[-X][+X]FX", 1).
+		Rule('F', "FF", 1)
+
+	out := plant.Expand(1, 4)
+	assert.Equal(t, out, plant.Expand(1, 4))
+	assert.NotEqual(t, out, plant.Expand(2, 4))
+
+	// Both rules are used, in proportion to their weights
+	seq := NewLSystem(strings.Repeat("X", 3000)).Rule('X', "a", 2).Rule('X', "b", 1).Expand(1, 1)
+	assert.InDelta(t, 2000, strings.Count(seq, "a"), 100)
+	assert.Panics(t, func() { NewLSystem("X").Rule('X', "Y", 0) })
+}
+
+func TestTurtle(t *testing.T) {
+	lines := Turtle("F+F[-F]fF|F", 1, 90)
+	assert.Len(t, lines, 5)
+
+	at := func(i int) [2][2]float32 {
+		s := lines[i]
+		round := func(v float32) float32 { return float32(int(v*1000+10000)-10000) / 1000 }
+		return [2][2]float32{
+			{round(s.From[0]), round(s.From[1])},
+			{round(s.To[0]), round(s.To[1])},
+		}
+	}
+
+	assert.Equal(t, [2][2]float32{{0, 0}, {0, 1}}, at(0))
+	assert.Equal(t, [2][2]float32{{0, 1}, {-1, 1}}, at(1))
+	assert.Equal(t, [2][2]float32{{-1, 1}, {-1, 2}}, at(2))
+	assert.Equal(t, 1, lines[2].Depth)
+	assert.Equal(t, [2][2]float32{{-2, 1}, {-3, 1}}, at(3))
+	assert.Equal(t, [2][2]float32{{-3, 1}, {-2, 1}}, at(4))
+	assert.Empty(t, Turtle("]]+-", 1, 90))
+}
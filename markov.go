@@ -0,0 +1,115 @@
+package noise
+
+import (
+	"slices"
+	"strings"
+)
+
+// ---------------------------------- Markov Chains ----------------------------------
+
+// markovAttempts is the number of names generated before giving up on the constraints
+const markovAttempts = 100
+
+// Markov generates names from a character-level Markov chain trained on samples,
+// where each character depends on the previous order characters. It is immutable
+// once constructed, so it is safe for concurrent use.
+type Markov struct {
+	order   int
+	states  map[string]*markovState
+	samples map[string]bool
+}
+
+// markovState holds the characters that may follow a context, with their weights
+type markovState struct {
+	next  []rune
+	table *AliasTable
+}
+
+// NewMarkov trains a Markov chain of the given order on the samples. An order of 2
+// or 3 works well for names: lower orders are more inventive, higher orders stick
+// closer to the samples but need more of them.
+//
+// Example:
+//
+//	elves := NewMarkov(2, "Aelar", "Caelynn", "Erevan", "Galinndan", "Ilphelkiir")
+//	name := elves.Generate(12345, npcID, 4, 10)
+func NewMarkov(order int, samples ...string) *Markov {
+	if order <= 0 {
+		panic("invalid argument to NewMarkov")
+	}
+
+	// Count the transitions, the zero rune marking both the start and the end
+	counts := make(map[string]map[rune]float32)
+	m := &Markov{order: order, states: make(map[string]*markovState), samples: make(map[string]bool)}
+	for _, sample := range samples {
+		if sample == "" {
+			continue
+		}
+
+		m.samples[sample] = true
+		ctx := make([]rune, order)
+		for _, r := range append([]rune(sample), 0) {
+			key := string(ctx)
+			if counts[key] == nil {
+				counts[key] = make(map[rune]float32)
+			}
+			counts[key][r]++
+			ctx = append(ctx[1:], r)
+		}
+	}
+
+	// Sort the characters so that the tables do not depend on the map order
+	for key, next := range counts {
+		state := &markovState{}
+		for r := range next {
+			state.next = append(state.next, r)
+		}
+		slices.Sort(state.next)
+
+		weights := make([]float32, len(state.next))
+		for i, r := range state.next {
+			weights[i] = next[r]
+		}
+		state.table = NewAliasTable(weights)
+		m.states[key] = state
+	}
+	return m
+}
+
+// Generate returns a name of minLen to maxLen characters for the key, which is not
+// one of the samples. The same seed and key always give the same name. It returns an
+// empty string if no such name was found, typically because there are too few
+// samples or the length constraints cannot be met.
+func (m *Markov) Generate(seed uint32, key uint64, minLen, maxLen int) string {
+	var name strings.Builder
+	for attempt := uint64(0); attempt < markovAttempts; attempt++ {
+		name.Reset()
+		n, ok := 0, true
+		ctx := make([]rune, m.order)
+		for step := uint64(0); ; step++ {
+			state := m.states[string(ctx)]
+			if state == nil {
+				ok = false
+				break
+			}
+
+			r := state.next[state.table.Sample(seed, xxhash64(attempt<<32|step, key))]
+			if r == 0 {
+				break
+			}
+
+			if n++; n > maxLen {
+				ok = false
+				break
+			}
+
+			name.WriteRune(r)
+			ctx = append(ctx[1:], r)
+		}
+
+		if ok && n >= minLen && !m.samples[name.String()] {
+			return name.String()
+		}
+	}
+	return ""
+}
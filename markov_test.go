@@ -0,0 +1,49 @@
+package noise
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testNames = []string{
+	"Aldric", "Aldwin", "Beren", "Bryn", "Cedric", "Corwin", "Darian", "Doran",
+	"Edric", "Elwin", "Faren", "Garrick", "Gavin", "Halden", "Hadrian", "Iven",
+	"Jorin", "Kellan", "Loric", "Marden", "Merrin", "Norric", "Orin", "Perrin",
+	"Quinlan", "Roderic", "Rowan", "Soren", "Tavin", "Torren", "Ulric", "Varen",
+	"Wendel", "Willem", "Yorick", "Zarek",
+}
+
+func TestMarkov(t *testing.T) {
+	m := NewMarkov(2, testNames...)
+	seen := make(map[string]bool)
+	for i := uint64(0); i < 100; i++ {
+		name := m.Generate(1, i, 4, 8)
+		assert.NotEmpty(t, name)
+		assert.Equal(t, name, m.Generate(1, i, 4, 8))
+		assert.Equal(t, name, NewMarkov(2, testNames...).Generate(1, i, 4, 8))
+
+		n := utf8.RuneCountInString(name)
+		assert.True(t, n >= 4 && n <= 8)
+		assert.NotContains(t, testNames, name)
+		seen[name] = true
+	}
+
+	// Names are varied, and the seed changes them
+	assert.Greater(t, len(seen), 50)
+	assert.NotEqual(t, m.Generate(1, 0, 4, 8), m.Generate(2, 0, 4, 8))
+}
+
+func TestMarkovUnicode(t *testing.T) {
+	m := NewMarkov(1, "Ærøskøbing", "Ålesund", "Øresund", "Ærø")
+	name := m.Generate(1, 0, 3, 12)
+	assert.True(t, utf8.ValidString(name))
+}
+
+func TestMarkovImpossible(t *testing.T) {
+	m := NewMarkov(3, "Bob")
+	assert.Equal(t, "", m.Generate(1, 0, 1, 10))
+	assert.Equal(t, "", NewMarkov(2).Generate(1, 0, 1, 10))
+	assert.Panics(t, func() { NewMarkov(0, "Bob") })
+}
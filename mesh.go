@@ -0,0 +1,186 @@
+package noise
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// ---------------------------------- Terrain Mesh ----------------------------------
+
+// Mesh is a triangulated terrain with one vertex per heightmap sample. It is y-up,
+// with the columns of the heightmap along x and its rows along z, as expected by
+// Blender and game engines.
+type Mesh struct {
+	Positions [][3]float32 // Vertex positions
+	Normals   [][3]float32 // Unit vertex normals
+	UVs       [][2]float32 // Texture coordinates in [0, 1], with (0, 0) at the first sample
+	Indices   []uint32     // Three vertices per triangle, counter-clockwise seen from above
+}
+
+// NewMesh triangulates a w×h heightmap, placing samples spacing units apart and
+// multiplying the heights by scale. Normals are computed from the scaled slopes,
+// so the terrain is lit correctly whatever its vertical exaggeration.
+//
+// Example:
+//
+//	mesh := NewMesh(heights, 256, 256, 1, 40)
+//	err := mesh.WriteGLB(file)
+func NewMesh(heights []float32, w, h int, spacing, scale float32) *Mesh {
+	if w < 2 || h < 2 || len(heights) < w*h {
+		panic("invalid argument to NewMesh")
+	}
+
+	m := &Mesh{
+		Positions: make([][3]float32, 0, w*h),
+		Normals:   make([][3]float32, 0, w*h),
+		UVs:       make([][2]float32, 0, w*h),
+		Indices:   make([]uint32, 0, 6*(w-1)*(h-1)),
+	}
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			gx, gy := gradient(heights, w, h, x, y, spacing)
+			nx, nz := -gx*float64(scale), -gy*float64(scale)
+			n := math.Sqrt(nx*nx + 1 + nz*nz)
+
+			m.Positions = append(m.Positions, [3]float32{float32(x) * spacing, heights[y*w+x] * scale, float32(y) * spacing})
+			m.Normals = append(m.Normals, [3]float32{float32(nx / n), float32(1 / n), float32(nz / n)})
+			m.UVs = append(m.UVs, [2]float32{float32(x) / float32(w-1), float32(y) / float32(h-1)})
+		}
+	}
+
+	for y := 0; y < h-1; y++ {
+		for x := 0; x < w-1; x++ {
+			a := uint32(y*w + x)
+			b, c, d := a+1, a+uint32(w), a+uint32(w)+1
+			m.Indices = append(m.Indices, a, c, b, b, c, d)
+		}
+	}
+	return m
+}
+
+// WriteOBJ writes the mesh as a Wavefront OBJ file, with texture coordinates flipped
+// to the bottom-left origin of the format
+func (m *Mesh) WriteOBJ(dst io.Writer) error {
+	buf := bufio.NewWriter(dst)
+	line := make([]byte, 0, 64)
+	vec := func(prefix string, v ...float32) {
+		line = append(line[:0], prefix...)
+		for _, f := range v {
+			line = append(line, ' ')
+			line = strconv.AppendFloat(line, float64(f), 'g', -1, 32)
+		}
+		buf.Write(append(line, '\n'))
+	}
+
+	for _, p := range m.Positions {
+		vec("v", p[0], p[1], p[2])
+	}
+	for _, uv := range m.UVs {
+		vec("vt", uv[0], 1-uv[1])
+	}
+	for _, n := range m.Normals {
+		vec("vn", n[0], n[1], n[2])
+	}
+
+	for i := 0; i+2 < len(m.Indices); i += 3 {
+		a, b, c := m.Indices[i]+1, m.Indices[i+1]+1, m.Indices[i+2]+1
+		if _, err := fmt.Fprintf(buf, "f %d/%d/%d %d/%d/%d %d/%d/%d\n", a, a, a, b, b, b, c, c, c); err != nil {
+			return err
+		}
+	}
+	return buf.Flush()
+}
+
+// WriteGLB writes the mesh as binary glTF 2.0, a single file holding the scene
+// description and the vertex buffers
+func (m *Mesh) WriteGLB(dst io.Writer) error {
+	var bin bytes.Buffer
+	le := binary.LittleEndian
+	binary.Write(&bin, le, m.Positions)
+	binary.Write(&bin, le, m.Normals)
+	binary.Write(&bin, le, m.UVs)
+	binary.Write(&bin, le, m.Indices)
+
+	lo, hi := [3]float32{}, [3]float32{}
+	if len(m.Positions) > 0 {
+		lo, hi = m.Positions[0], m.Positions[0]
+	}
+	for _, p := range m.Positions {
+		for i := range p {
+			lo[i], hi[i] = min(lo[i], p[i]), max(hi[i], p[i])
+		}
+	}
+
+	// Buffer views follow the order in which the attributes were written
+	type view struct {
+		Buffer     int `json:"buffer"`
+		ByteOffset int `json:"byteOffset"`
+		ByteLength int `json:"byteLength"`
+		Target     int `json:"target"`
+	}
+	type accessor struct {
+		BufferView    int       `json:"bufferView"`
+		ComponentType int       `json:"componentType"`
+		Count         int       `json:"count"`
+		Type          string    `json:"type"`
+		Min           []float32 `json:"min,omitempty"`
+		Max           []float32 `json:"max,omitempty"`
+	}
+
+	const arrayBuffer, elementBuffer = 34962, 34963
+	const f32, u32 = 5126, 5125
+	n := len(m.Positions)
+	views := []view{
+		{0, 0, 12 * n, arrayBuffer},
+		{0, 12 * n, 12 * len(m.Normals), arrayBuffer},
+		{0, 12 * (n + len(m.Normals)), 8 * len(m.UVs), arrayBuffer},
+		{0, 12*(n+len(m.Normals)) + 8*len(m.UVs), 4 * len(m.Indices), elementBuffer},
+	}
+
+	doc, err := json.Marshal(map[string]any{
+		"asset":  map[string]string{"version": "2.0", "generator": "github.com/kelindar/noise"},
+		"scene":  0,
+		"scenes": []any{map[string]any{"nodes": []int{0}}},
+		"nodes":  []any{map[string]any{"mesh": 0, "name": "terrain"}},
+		"meshes": []any{map[string]any{"primitives": []any{map[string]any{
+			"attributes": map[string]int{"POSITION": 0, "NORMAL": 1, "TEXCOORD_0": 2},
+			"indices":    3,
+		}}}},
+		"buffers":     []any{map[string]int{"byteLength": bin.Len()}},
+		"bufferViews": views,
+		"accessors": []accessor{
+			{0, f32, n, "VEC3", lo[:], hi[:]},
+			{1, f32, len(m.Normals), "VEC3", nil, nil},
+			{2, f32, len(m.UVs), "VEC2", nil, nil},
+			{3, u32, len(m.Indices), "SCALAR", nil, nil},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Chunks are padded to 4 bytes, the JSON with spaces and the binary with zeros
+	for len(doc)%4 != 0 {
+		doc = append(doc, ' ')
+	}
+	for bin.Len()%4 != 0 {
+		bin.WriteByte(0)
+	}
+
+	buf := bufio.NewWriter(dst)
+	binary.Write(buf, le, [3]uint32{0x46546c67, 2, uint32(12 + 8 + len(doc) + 8 + bin.Len())})
+	binary.Write(buf, le, [2]uint32{uint32(len(doc)), 0x4e4f534a})
+	buf.Write(doc)
+	binary.Write(buf, le, [2]uint32{uint32(bin.Len()), 0x004e4942})
+	if _, err := buf.Write(bin.Bytes()); err != nil {
+		return err
+	}
+	return buf.Flush()
+}
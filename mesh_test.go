@@ -0,0 +1,113 @@
+package noise
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMesh(t *testing.T) {
+	m := NewMesh(plane(4, 3, 0, 0), 4, 3, 2, 10)
+	assert.Len(t, m.Positions, 12)
+	assert.Len(t, m.Normals, 12)
+	assert.Len(t, m.UVs, 12)
+	assert.Len(t, m.Indices, 6*3*2)
+	assert.Equal(t, [3]float32{6, 0, 4}, m.Positions[11])
+	assert.Equal(t, [2]float32{1, 1}, m.UVs[11])
+	assert.Equal(t, [3]float32{0, 1, 0}, m.Normals[5])
+
+	// Every triangle faces up
+	for i := 0; i < len(m.Indices); i += 3 {
+		a, b, c := m.Positions[m.Indices[i]], m.Positions[m.Indices[i+1]], m.Positions[m.Indices[i+2]]
+		ux, uz := b[0]-a[0], b[2]-a[2]
+		vx, vz := c[0]-a[0], c[2]-a[2]
+		assert.Greater(t, uz*vx-ux*vz, float32(0))
+	}
+
+	assert.Panics(t, func() { NewMesh(make([]float32, 4), 1, 4, 1, 1) })
+}
+
+func TestMeshNormals(t *testing.T) {
+	// A ramp rising along x tilts the normals towards -x, more so when scaled
+	m := NewMesh(plane(5, 5, 1, 0), 5, 5, 1, 1)
+	n := m.Normals[2*5+2]
+	assert.InDelta(t, -math.Sqrt2/2, n[0], 1e-6)
+	assert.InDelta(t, math.Sqrt2/2, n[1], 1e-6)
+	assert.InDelta(t, 0, n[2], 1e-6)
+
+	steep := NewMesh(plane(5, 5, 1, 0), 5, 5, 1, 4).Normals[2*5+2]
+	assert.Less(t, steep[1], n[1])
+}
+
+func TestMeshOBJ(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewMesh(plane(3, 2, 0, 0), 3, 2, 1, 1)
+	assert.NoError(t, m.WriteOBJ(&buf))
+
+	count := map[string]int{}
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		count[strings.Fields(scanner.Text())[0]]++
+	}
+	assert.Equal(t, map[string]int{"v": 6, "vt": 6, "vn": 6, "f": 4}, count)
+
+	buf.Reset()
+	assert.NoError(t, m.WriteOBJ(&buf))
+	assert.True(t, strings.HasPrefix(buf.String(), "v 0 0 0\nv 1 0 0\n"))
+	assert.Contains(t, buf.String(), "vt 0 1\n")
+	assert.Contains(t, buf.String(), "f 1/1/1 4/4/4 2/2/2\n")
+}
+
+func TestMeshGLB(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewMesh(plane(3, 2, 0.5, 0), 3, 2, 1, 2)
+	assert.NoError(t, m.WriteGLB(&buf))
+
+	data := buf.Bytes()
+	le := binary.LittleEndian
+	assert.Equal(t, "glTF", string(data[:4]))
+	assert.Equal(t, uint32(2), le.Uint32(data[4:]))
+	assert.Equal(t, uint32(len(data)), le.Uint32(data[8:]))
+
+	size := le.Uint32(data[12:])
+	assert.Equal(t, "JSON", string(data[16:20]))
+	assert.Zero(t, size%4)
+
+	var doc struct {
+		Accessors []struct {
+			BufferView int       `json:"bufferView"`
+			Count      int       `json:"count"`
+			Min        []float32 `json:"min"`
+			Max        []float32 `json:"max"`
+		} `json:"accessors"`
+		BufferViews []struct {
+			ByteOffset int `json:"byteOffset"`
+			ByteLength int `json:"byteLength"`
+		} `json:"bufferViews"`
+		Buffers []struct {
+			ByteLength int `json:"byteLength"`
+		} `json:"buffers"`
+	}
+	assert.NoError(t, json.Unmarshal(data[20:20+size], &doc))
+	assert.Len(t, doc.Accessors, 4)
+	assert.Equal(t, 6, doc.Accessors[0].Count)
+	assert.Equal(t, []float32{0, 0, 0}, doc.Accessors[0].Min)
+	assert.Equal(t, []float32{2, 2, 1}, doc.Accessors[0].Max)
+	assert.Equal(t, 12, doc.Accessors[3].Count)
+
+	// The indices are the last view of the binary chunk
+	bin := data[20+size:]
+	assert.Equal(t, "BIN\x00", string(bin[4:8]))
+	assert.Equal(t, doc.Buffers[0].ByteLength, int(le.Uint32(bin)))
+
+	idx := doc.BufferViews[3]
+	indices := make([]uint32, 12)
+	assert.NoError(t, binary.Read(bytes.NewReader(bin[8+idx.ByteOffset:8+idx.ByteOffset+idx.ByteLength]), le, indices))
+	assert.Equal(t, m.Indices, indices)
+}
@@ -0,0 +1,177 @@
+package noise
+
+import "math"
+
+// ---------------------------------- Combiners ----------------------------------
+
+// operator identifies how a combiner merges the values of its two sources
+type operator uint8
+
+const (
+	opAdd operator = iota
+	opMultiply
+	opMin
+	opMax
+	opPower
+)
+
+// combiner merges two sources with an arithmetic operator
+type combiner struct {
+	op   operator
+	a, b Source
+}
+
+// Add returns a source that evaluates to a + b
+func Add(a, b Source) Source {
+	return &combiner{op: opAdd, a: a, b: b}
+}
+
+// Multiply returns a source that evaluates to a * b
+func Multiply(a, b Source) Source {
+	return &combiner{op: opMultiply, a: a, b: b}
+}
+
+// Min returns a source that evaluates to the smaller of a and b
+func Min(a, b Source) Source {
+	return &combiner{op: opMin, a: a, b: b}
+}
+
+// Max returns a source that evaluates to the larger of a and b
+func Max(a, b Source) Source {
+	return &combiner{op: opMax, a: a, b: b}
+}
+
+// Power returns a source that evaluates to base raised to the power of exp. As with
+// math.Pow, a negative base with a non-integer exponent yields NaN.
+func Power(base, exp Source) Source {
+	return &combiner{op: opPower, a: base, b: exp}
+}
+
+// Eval2 evaluates the combiner in 2D
+func (c *combiner) Eval2(x, y float32) float32 {
+	return c.apply(c.a.Eval2(x, y), c.b.Eval2(x, y))
+}
+
+// Eval3 evaluates the combiner in 3D
+func (c *combiner) Eval3(x, y, z float32) float32 {
+	return c.apply(c.a.Eval3(x, y, z), c.b.Eval3(x, y, z))
+}
+
+// apply merges two values with the operator of the combiner
+func (c *combiner) apply(a, b float32) float32 {
+	switch c.op {
+	case opMultiply:
+		return a * b
+	case opMin:
+		return min(a, b)
+	case opMax:
+		return max(a, b)
+	case opPower:
+		return float32(math.Pow(float64(a), float64(b)))
+	default:
+		return a + b
+	}
+}
+
+// ---------------------------------- Constant ----------------------------------
+
+// constant is a source that evaluates to the same value everywhere
+type constant float32
+
+// Constant returns a source that evaluates to v everywhere, which is useful as an
+// operand of the combiners, e.g. Multiply(src, Constant(0.5)).
+func Constant(v float32) Source {
+	return constant(v)
+}
+
+// Eval2 returns the constant value
+func (c constant) Eval2(x, y float32) float32 {
+	return float32(c)
+}
+
+// Eval3 returns the constant value
+func (c constant) Eval3(x, y, z float32) float32 {
+	return float32(c)
+}
+
+// ---------------------------------- Selectors ----------------------------------
+
+// blend interpolates between two sources using a control source
+type blend struct {
+	a, b, control Source
+}
+
+// Blend returns a source that linearly interpolates between a and b, weighted by
+// the control source: a control value of -1 yields a, 1 yields b and 0 their mean.
+func Blend(a, b, control Source) Source {
+	return &blend{a: a, b: b, control: control}
+}
+
+// Eval2 evaluates the blend in 2D
+func (s *blend) Eval2(x, y float32) float32 {
+	t := (s.control.Eval2(x, y) + 1) / 2
+	return lerp(s.a.Eval2(x, y), s.b.Eval2(x, y), t)
+}
+
+// Eval3 evaluates the blend in 3D
+func (s *blend) Eval3(x, y, z float32) float32 {
+	t := (s.control.Eval3(x, y, z) + 1) / 2
+	return lerp(s.a.Eval3(x, y, z), s.b.Eval3(x, y, z), t)
+}
+
+// selector picks one of two sources depending on a control source
+type selector struct {
+	a, b, control      Source
+	threshold, falloff float32
+}
+
+// Select returns a source that evaluates to a where the control source is below
+// the threshold and to b where it is above. A positive falloff smoothly blends the
+// two within [threshold-falloff, threshold+falloff] instead of a hard cut.
+//
+// Example:
+//
+//	// Mountains where the continental mask is high, plains elsewhere
+//	terrain := Select(plains, mountains, continents, 0.3, 0.1)
+func Select(a, b, control Source, threshold, falloff float32) Source {
+	return &selector{a: a, b: b, control: control, threshold: threshold, falloff: max(falloff, 0)}
+}
+
+// Eval2 evaluates the selector in 2D
+func (s *selector) Eval2(x, y float32) float32 {
+	switch t := s.weight(s.control.Eval2(x, y)); t {
+	case 0:
+		return s.a.Eval2(x, y)
+	case 1:
+		return s.b.Eval2(x, y)
+	default:
+		return lerp(s.a.Eval2(x, y), s.b.Eval2(x, y), t)
+	}
+}
+
+// Eval3 evaluates the selector in 3D
+func (s *selector) Eval3(x, y, z float32) float32 {
+	switch t := s.weight(s.control.Eval3(x, y, z)); t {
+	case 0:
+		return s.a.Eval3(x, y, z)
+	case 1:
+		return s.b.Eval3(x, y, z)
+	default:
+		return lerp(s.a.Eval3(x, y, z), s.b.Eval3(x, y, z), t)
+	}
+}
+
+// weight returns the weight of b in [0, 1] for a control value, using a cubic
+// s-curve across the falloff band so the transition has no visible seam.
+func (s *selector) weight(v float32) float32 {
+	lo, hi := s.threshold-s.falloff, s.threshold+s.falloff
+	switch {
+	case v < lo:
+		return 0
+	case v >= hi:
+		return 1
+	default:
+		t := (v - lo) / (hi - lo)
+		return t * t * (3 - 2*t)
+	}
+}
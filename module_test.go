@@ -0,0 +1,69 @@
+package noise
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCombiners(t *testing.T) {
+	a, b := Constant(0.5), Constant(-0.25)
+	tests := []struct {
+		src    Source
+		expect float32
+	}{
+		{Add(a, b), 0.25},
+		{Multiply(a, b), -0.125},
+		{Min(a, b), -0.25},
+		{Max(a, b), 0.5},
+		{Power(a, Constant(2)), 0.25},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.expect, tc.src.Eval2(1, 2))
+		assert.Equal(t, tc.expect, tc.src.Eval3(1, 2, 3))
+	}
+
+	assert.True(t, math.IsNaN(float64(Power(b, a).Eval2(0, 0))))
+}
+
+func TestCombinersSource(t *testing.T) {
+	s, v := NewSimplex(42), NewValue(42)
+	sum := Add(s, v)
+	for i := 0; i < 100; i++ {
+		x := float32(i) * 0.37
+		assert.Equal(t, s.Eval2(x, -x)+v.Eval2(x, -x), sum.Eval2(x, -x))
+		assert.Equal(t, s.Eval3(x, -x, x)+v.Eval3(x, -x, x), sum.Eval3(x, -x, x))
+	}
+}
+
+func TestBlend(t *testing.T) {
+	a, b := Constant(-1), Constant(3)
+	assert.Equal(t, float32(-1), Blend(a, b, Constant(-1)).Eval2(0, 0))
+	assert.Equal(t, float32(1), Blend(a, b, Constant(0)).Eval2(0, 0))
+	assert.Equal(t, float32(3), Blend(a, b, Constant(1)).Eval3(0, 0, 0))
+}
+
+func TestSelect(t *testing.T) {
+	a, b := Constant(-1), Constant(1)
+
+	// Hard cut without falloff
+	assert.Equal(t, float32(-1), Select(a, b, Constant(0.2), 0.3, 0).Eval2(0, 0))
+	assert.Equal(t, float32(1), Select(a, b, Constant(0.3), 0.3, 0).Eval2(0, 0))
+	assert.Equal(t, float32(1), Select(a, b, Constant(0.4), 0.3, 0).Eval3(0, 0, 0))
+
+	// Smooth transition within the falloff band
+	assert.Equal(t, float32(-1), Select(a, b, Constant(0.1), 0.3, 0.1).Eval2(0, 0))
+	assert.Equal(t, float32(0), Select(a, b, Constant(0.3), 0.3, 0.1).Eval2(0, 0))
+	assert.Equal(t, float32(1), Select(a, b, Constant(0.5), 0.3, 0.1).Eval3(0, 0, 0))
+
+	// Monotonic across the band
+	prev := float32(-1)
+	for i := 0; i <= 100; i++ {
+		c := 0.2 + float32(i)*0.002
+		v := Select(a, b, Constant(c), 0.3, 0.1).Eval2(0, 0)
+		assert.GreaterOrEqual(t, v, prev)
+		prev = v
+	}
+}
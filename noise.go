@@ -111,6 +111,19 @@ func Norm32(seed uint32, x uint64) float32 {
 	return float32(Norm64(seed, x))
 }
 
+// NormFast64 returns a deterministic normally distributed float64 based on x, using
+// the ziggurat method which avoids transcendental functions for most samples. It
+// is faster than Norm64, but produces different values for the same seed and key.
+func NormFast64(seed uint32, x uint64) float64 {
+	return ziggurat(seed, x)
+}
+
+// NormFast32 returns a deterministic normally distributed float32 based on x, like
+// NormFast64
+func NormFast32(seed uint32, x uint64) float32 {
+	return float32(NormFast64(seed, x))
+}
+
 // Int returns a deterministic int based on x
 func Int(seed uint32, x uint64) int {
 	hash := xxhash64(x, uint64(seed))
@@ -228,6 +241,47 @@ func Int64In(seed uint32, a, b int64, x uint64) int64 {
 	return Int64N(seed, b-a+1, x) + a
 }
 
+// Float32In returns a deterministic float32 in [a, b) based on x
+func Float32In(seed uint32, a, b float32, x uint64) float32 {
+	if a > b {
+		panic("invalid range: a > b")
+	}
+
+	// Rounding may land exactly on b, keep the interval half-open
+	v := a + float32(Float32(seed, x)*(b-a))
+	if v >= b && a < b {
+		v = math.Nextafter32(b, a)
+	}
+	return v
+}
+
+// Float64In returns a deterministic float64 in [a, b) based on x
+func Float64In(seed uint32, a, b float64, x uint64) float64 {
+	if a > b {
+		panic("invalid range: a > b")
+	}
+
+	v := a + float64(Float64(seed, x)*(b-a))
+	if v >= b && a < b {
+		v = math.Nextafter(b, a)
+	}
+	return v
+}
+
+// Bool returns a deterministic fair coin flip based on x
+func Bool(seed uint32, x uint64) bool {
+	return xxhash64(x, uint64(seed))>>63 == 1
+}
+
+// Sign returns a deterministic -1 or +1 with equal probability based on x, handy
+// for mirroring a prop or flipping a decal
+func Sign(seed uint32, x uint64) float32 {
+	if Bool(seed, x) {
+		return 1
+	}
+	return -1
+}
+
 // Roll32 returns true if Float32(seed, x) < probability
 func Roll32(seed uint32, probability float32, x uint64) bool {
 	return Float32(seed, x) < probability
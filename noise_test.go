@@ -3,6 +3,7 @@ package noise
 import (
 	"image"
 	"image/png"
+	"math"
 	"os"
 	"testing"
 
@@ -122,6 +123,21 @@ func TestRandomFunctions(t *testing.T) {
 				assert.True(t, v >= 10 && v <= 20, "got %d", v)
 			}
 		}},
+		{"Float32In [a,b)", func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				v := Float32In(seed, -2.5, 7.5, uint64(i))
+				assert.True(t, v >= -2.5 && v < 7.5, "got %f", v)
+			}
+			assert.Equal(t, float32(3), Float32In(seed, 3, 3, x))
+			assert.Less(t, Float32In(seed, 1, math.Nextafter32(1, 2), x), math.Nextafter32(1, 2))
+		}},
+		{"Float64In [a,b)", func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				v := Float64In(seed, 100, 200, uint64(i))
+				assert.True(t, v >= 100 && v < 200, "got %f", v)
+			}
+			assert.Equal(t, float64(Float64(seed, x)*10)+5, Float64In(seed, 5, 15, x))
+		}},
 		{"Int32In [a,b]", func(t *testing.T) {
 			for i := 0; i < 100; i++ {
 				v := Int32In(seed, -5, 5, uint64(i))
@@ -193,6 +209,24 @@ func TestRandomFunctions(t *testing.T) {
 			assert.True(t, v >= -5 && v <= 5, "got %f", v)
 		}},
 
+		// Coin flips
+		{"Bool fair", func(t *testing.T) {
+			count := 0
+			for i := 0; i < 1000; i++ {
+				if Bool(seed, uint64(i)) {
+					count++
+				}
+			}
+			assert.True(t, count > 450 && count < 550, "got %d", count)
+		}},
+		{"Sign matches Bool", func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				v := Sign(seed, uint64(i))
+				assert.True(t, v == 1 || v == -1, "got %f", v)
+				assert.Equal(t, Bool(seed, uint64(i)), v > 0)
+			}
+		}},
+
 		// Probability tests
 		{"Roll32 probability", func(t *testing.T) {
 			count := 0
@@ -293,5 +327,7 @@ func TestPanicCases(t *testing.T) {
 	assert.Panics(t, func() { Int64In(seed, 10, 5, x) })
 	assert.Panics(t, func() { Uint32In(seed, 10, 5, x) })
 	assert.Panics(t, func() { Uint64In(seed, 10, 5, x) })
+	assert.Panics(t, func() { Float32In(seed, 10, 5, x) })
+	assert.Panics(t, func() { Float64In(seed, 10, 5, x) })
 	assert.Panics(t, func() { White[int](seed) })
 }
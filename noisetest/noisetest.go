@@ -0,0 +1,177 @@
+// Package noisetest provides statistical checks for random functions and noise
+// sources, so that custom pipelines built on the noise package can validate their
+// distribution claims in CI. Each check returns nil if the output is consistent
+// with the claim, or an error describing the statistic otherwise. The tests reject
+// at a significance level of 0.001, so a correct generator fails about once in a
+// thousand runs with a random seed, and never with a fixed one.
+package noisetest
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/kelindar/noise"
+)
+
+// Standard normal quantiles of the significance level, 0.001
+const (
+	z          = 3.0902 // One-sided, for the chi-square test
+	zTwo       = 3.2905 // Two-sided, for the autocorrelation test
+	zAvalanche = 5.1621 // Two-sided with a Bonferroni correction over 64×64 tests
+)
+
+// CheckUniform runs a chi-square test on n values of f, which should be uniformly
+// distributed in [0, 1), bucketed into 64 equal bins.
+//
+// Example:
+//
+//	err := noisetest.CheckUniform(func(i uint64) float64 {
+//	    return noise.Float64(12345, i)
+//	}, 100000)
+func CheckUniform(f func(i uint64) float64, n int) error {
+	const bins = 64
+	return CheckBuckets(func(i uint64) int {
+		v := f(i)
+		if v < 0 || v >= 1 {
+			return -1
+		}
+		return int(v * bins)
+	}, bins, n)
+}
+
+// CheckBuckets runs a chi-square test on n values of f, which should be uniformly
+// distributed among the integers in [0, buckets).
+func CheckBuckets(f func(i uint64) int, buckets, n int) error {
+	if buckets < 2 || n < 5*buckets {
+		return fmt.Errorf("noisetest: need at least 2 buckets and 5 samples per bucket")
+	}
+
+	counts := make([]int, buckets)
+	for i := 0; i < n; i++ {
+		b := f(uint64(i))
+		if b < 0 || b >= buckets {
+			return fmt.Errorf("noisetest: sample %d is out of range (bucket %d)", i, b)
+		}
+		counts[b]++
+	}
+
+	expect := float64(n) / float64(buckets)
+	var chi2 float64
+	for _, c := range counts {
+		d := float64(c) - expect
+		chi2 += d * d / expect
+	}
+
+	if limit := chiSquareCritical(buckets - 1); chi2 > limit {
+		return fmt.Errorf("noisetest: not uniform, chi-square %.2f exceeds %.2f with %d degrees of freedom", chi2, limit, buckets-1)
+	}
+	return nil
+}
+
+// CheckAutocorrelation checks that n consecutive values of f are not correlated
+// with the values lag positions later.
+func CheckAutocorrelation(f func(i uint64) float64, n, lag int) error {
+	if lag <= 0 || n <= lag+2 {
+		return fmt.Errorf("noisetest: need a positive lag and more samples than the lag")
+	}
+
+	v := make([]float64, n)
+	var mean float64
+	for i := range v {
+		v[i] = f(uint64(i))
+		mean += v[i]
+	}
+	mean /= float64(n)
+
+	var cov, variance float64
+	for i := range v {
+		d := v[i] - mean
+		variance += d * d
+		if i+lag < n {
+			cov += d * (v[i+lag] - mean)
+		}
+	}
+
+	if variance == 0 {
+		return fmt.Errorf("noisetest: samples are constant")
+	}
+
+	// Under independence, the autocorrelation is approximately normal with a
+	// standard deviation of 1/sqrt(n)
+	r := cov / variance
+	if limit := zTwo / math.Sqrt(float64(n-lag)); math.Abs(r) > limit {
+		return fmt.Errorf("noisetest: autocorrelation %.4f at lag %d exceeds %.4f", r, lag, limit)
+	}
+	return nil
+}
+
+// CheckAvalanche checks that flipping any single input bit of the hash function
+// flips each output bit with a probability of one half, over n random inputs.
+//
+// Example:
+//
+//	err := noisetest.CheckAvalanche(func(x uint64) uint64 {
+//	    return noise.Uint64(12345, x)
+//	}, 10000)
+func CheckAvalanche(h func(x uint64) uint64, n int) error {
+	if n <= 0 {
+		return fmt.Errorf("noisetest: need at least one sample")
+	}
+
+	// flips[i][o] counts how often flipping input bit i flipped output bit o
+	var flips [64][64]int
+	for k := 0; k < n; k++ {
+		x := noise.Uint64(0, uint64(k))
+		base := h(x)
+		for i := 0; i < 64; i++ {
+			diff := base ^ h(x^1<<i)
+			for diff != 0 {
+				o := bits.TrailingZeros64(diff)
+				flips[i][o]++
+				diff &= diff - 1
+			}
+		}
+	}
+
+	// Each count is binomial(n, 0.5), and there are 64×64 of them so the limit is
+	// widened by a Bonferroni correction
+	limit := zAvalanche * math.Sqrt(float64(n)) / 2
+	for i := range flips {
+		for o, c := range flips[i] {
+			if d := math.Abs(float64(c) - float64(n)/2); d > limit {
+				return fmt.Errorf("noisetest: flipping input bit %d flips output bit %d with probability %.3f", i, o, float64(c)/float64(n))
+			}
+		}
+	}
+	return nil
+}
+
+// CheckRange checks that a source stays within [lo, hi] at n pseudo-random
+// positions within the square [-extent, extent]².
+func CheckRange(src noise.Source2, n int, extent, lo, hi float32) error {
+	for i := 0; i < n; i++ {
+		x := (noise.Float32(1, uint64(i))*2 - 1) * extent
+		y := (noise.Float32(2, uint64(i))*2 - 1) * extent
+		if v := src.Eval2(x, y); v < lo || v > hi || v != v {
+			return fmt.Errorf("noisetest: value %v at (%v, %v) is outside of [%v, %v]", v, x, y, lo, hi)
+		}
+	}
+	return nil
+}
+
+// Sample returns a function evaluating the source along a horizontal line with the
+// given step, for use with CheckAutocorrelation.
+func Sample(src noise.Source2, step float32) func(i uint64) float64 {
+	return func(i uint64) float64 {
+		return float64(src.Eval2(float32(i)*step, 0.5))
+	}
+}
+
+// chiSquareCritical approximates the critical value of the chi-square distribution
+// with k degrees of freedom at the significance level, using Wilson-Hilferty
+func chiSquareCritical(k int) float64 {
+	f := 2 / (9 * float64(k))
+	c := 1 - f + z*math.Sqrt(f)
+	return float64(k) * c * c * c
+}
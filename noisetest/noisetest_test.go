@@ -0,0 +1,60 @@
+package noisetest
+
+import (
+	"testing"
+
+	"github.com/kelindar/noise"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckUniform(t *testing.T) {
+	assert.NoError(t, CheckUniform(func(i uint64) float64 {
+		return noise.Float64(42, i)
+	}, 100000))
+
+	// Squaring skews the distribution towards zero
+	assert.ErrorContains(t, CheckUniform(func(i uint64) float64 {
+		v := noise.Float64(42, i)
+		return v * v
+	}, 100000), "not uniform")
+
+	assert.ErrorContains(t, CheckUniform(func(i uint64) float64 { return 1 }, 1000), "out of range")
+}
+
+func TestCheckBuckets(t *testing.T) {
+	assert.NoError(t, CheckBuckets(func(i uint64) int {
+		return noise.IntN(42, 6, i)
+	}, 6, 60000))
+
+	assert.Error(t, CheckBuckets(func(i uint64) int {
+		return int(i % 5) // never rolls a six
+	}, 6, 60000))
+
+	assert.Error(t, CheckBuckets(func(i uint64) int { return 0 }, 6, 10))
+}
+
+func TestCheckAutocorrelation(t *testing.T) {
+	assert.NoError(t, CheckAutocorrelation(func(i uint64) float64 {
+		return noise.Float64(42, i)
+	}, 50000, 1))
+
+	// Smooth noise is strongly correlated with its neighbours
+	assert.ErrorContains(t, CheckAutocorrelation(Sample(noise.NewSimplex(42), 0.05), 50000, 1), "autocorrelation")
+	assert.ErrorContains(t, CheckAutocorrelation(func(i uint64) float64 { return 1 }, 100, 1), "constant")
+}
+
+func TestCheckAvalanche(t *testing.T) {
+	assert.NoError(t, CheckAvalanche(func(x uint64) uint64 {
+		return noise.Uint64(42, x)
+	}, 5000))
+
+	// A plain multiplication never propagates bits downwards
+	assert.Error(t, CheckAvalanche(func(x uint64) uint64 {
+		return x * 0x9e3779b97f4a7c15
+	}, 5000))
+}
+
+func TestCheckRange(t *testing.T) {
+	assert.NoError(t, CheckRange(noise.NewSimplex(42), 10000, 100, -1, 1))
+	assert.Error(t, CheckRange(noise.NewSimplex(42), 10000, 100, 0, 1))
+}
@@ -0,0 +1,96 @@
+package noise
+
+import (
+	"iter"
+	"math"
+)
+
+// ---------------------------------- Placement ----------------------------------
+
+// Placement is a single object placed by Scatter, such as a tree or a rock
+type Placement struct {
+	Position [2]float32 // Location of the object
+	Rotation float32    // Rotation around the vertical axis in radians, in [0, 2π)
+	Scale    float32    // Random size factor in [0, 1), to be mapped to a size range
+	Hash     uint64     // Stable hash of the placement to derive further attributes
+}
+
+// Rule decides whether a candidate placement is kept
+type Rule func(p Placement) bool
+
+// Scatter places objects within [0, w) × [0, h) with at least gap units between
+// any two, draws per-object random attributes and rejects the candidates that do
+// not satisfy every rule, all in a single streaming pass.
+// Method: candidates are the well-spaced samples of SSI2 scaled by gap and
+// centered on the rectangle. Attributes are white noise keyed by the position.
+// Deterministic for a given seed. Complexity: inherits SSI2 cost.
+//
+// Example:
+//
+//	terrain := Scale(NewFBM(12345), 0.01, 0.01, 0.01)
+//	for tree := range Scatter(12345, 512, 512, 6,
+//	    MaxSlope(terrain, 100, 30),   // slopes under 30° with 100 units of relief
+//	    Altitude(terrain, -0.2, 0.6), // above water and below the snow line
+//	) {
+//	    // place a tree at tree.Position, rotated by tree.Rotation
+//	}
+func Scatter(seed uint32, w, h int, gap float32, rules ...Rule) iter.Seq[Placement] {
+	return func(yield func(Placement) bool) {
+		if w <= 0 || h <= 0 || gap <= 0 {
+			return
+		}
+
+		r1 := int(math.Ceil(float64(float32(w) / (2 * gap))))
+		r2 := int(math.Ceil(float64(float32(h) / (2 * gap))))
+		cx, cy := float32(w)/2, float32(h)/2
+
+	next:
+		for pt := range SSI2(seed, r1, r2) {
+			x, y := float32(pt[0]*gap)+cx, float32(pt[1]*gap)+cy
+			if x < 0 || x >= float32(w) || y < 0 || y >= float32(h) {
+				continue
+			}
+
+			hp := xxhash64(uint64(math.Float32bits(x))|uint64(math.Float32bits(y))<<32, uint64(seed))
+			p := Placement{
+				Position: [2]float32{x, y},
+				Rotation: Float32(seed^2, hp) * 2 * math.Pi,
+				Scale:    Float32(seed^3, hp),
+				Hash:     hp,
+			}
+
+			for _, accept := range rules {
+				if !accept(p) {
+					continue next
+				}
+			}
+
+			if !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// MaxSlope returns a rule that rejects placements on terrain steeper than the
+// given angle in degrees. The terrain value is multiplied by relief to convert it
+// into the same units as the horizontal coordinates.
+func MaxSlope(terrain Source2, relief, degrees float32) Rule {
+	limit := float32(math.Tan(float64(degrees) * math.Pi / 180))
+	return func(p Placement) bool {
+		const e = 0.5
+		x, y := p.Position[0], p.Position[1]
+		dx := (terrain.Eval2(x+e, y) - terrain.Eval2(x-e, y)) * relief / (2 * e)
+		dy := (terrain.Eval2(x, y+e) - terrain.Eval2(x, y-e)) * relief / (2 * e)
+		return float32(dx*dx)+float32(dy*dy) <= limit*limit
+	}
+}
+
+// Altitude returns a rule that only keeps placements where the terrain is within
+// the band [lo, hi]
+func Altitude(terrain Source2, lo, hi float32) Rule {
+	return func(p Placement) bool {
+		v := terrain.Eval2(p.Position[0], p.Position[1])
+		return v >= lo && v <= hi
+	}
+}
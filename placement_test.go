@@ -0,0 +1,43 @@
+package noise
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScatter(t *testing.T) {
+	all := slices.Collect(Scatter(42, 128, 64, 4))
+	assert.Equal(t, all, slices.Collect(Scatter(42, 128, 64, 4)))
+	assert.Greater(t, len(all), 100)
+
+	for i, p := range all {
+		assert.True(t, p.Position[0] >= 0 && p.Position[0] < 128)
+		assert.True(t, p.Position[1] >= 0 && p.Position[1] < 64)
+		assert.True(t, p.Rotation >= 0 && p.Rotation < 2*math.Pi)
+		assert.True(t, p.Scale >= 0 && p.Scale < 1)
+		for _, o := range all[i+1:] {
+			assert.GreaterOrEqual(t, dist2(p.Position, o.Position[0], o.Position[1]), float32(4*4)-0.01)
+		}
+	}
+
+	// Rules are applied in addition to the spacing
+	left := slices.Collect(Scatter(42, 128, 64, 4, func(p Placement) bool {
+		return p.Position[0] < 64
+	}))
+	assert.Greater(t, len(left), 0)
+	assert.Less(t, len(left), len(all))
+}
+
+func TestScatterRules(t *testing.T) {
+	ramp := SourceFunc(func(x, y, z float32) float32 { return x / 64 })
+	p := Placement{Position: [2]float32{16, 16}}
+
+	// The ramp rises by 1/64 per unit, so a relief of 64 makes it a 45° slope
+	assert.True(t, MaxSlope(ramp, 64, 46)(p))
+	assert.False(t, MaxSlope(ramp, 64, 44)(p))
+	assert.True(t, Altitude(ramp, 0, 0.5)(p))
+	assert.False(t, Altitude(ramp, 0.5, 1)(p))
+}
@@ -0,0 +1,184 @@
+package noise
+
+import (
+	"iter"
+	"math"
+)
+
+// PointSet is an immutable spatial index over a set of 2D points, typically the
+// output of one of the sparse iterators. Points are bucketed into a uniform grid
+// sized so that each cell holds about one point on average, which keeps nearest
+// neighbour and range queries close to O(1) for well-spaced point sets. Queries
+// do not mutate the index, so it is safe for concurrent use.
+type PointSet struct {
+	points     [][2]float32 // points sorted by grid cell
+	start      []int32      // offsets of each cell into points, len = cols*rows+1
+	minX, minY float32      // origin of the grid
+	cell       float32      // size of a grid cell
+	cols, rows int          // grid dimensions
+}
+
+// NewPointSet builds a spatial index from any 2D point iterator, such as the
+// output of Sparse2, SSI2 or Scatter2.
+//
+// Example:
+//
+//	trees := NewPointSet(Sparse2(12345, 512, 512, 8))
+//	if p, ok := trees.Nearest(100, 200); ok {
+//	    // p is the closest tree
+//	}
+func NewPointSet[T Number](seq iter.Seq[[2]T]) *PointSet {
+	var pts [][2]float32
+	for p := range seq {
+		pts = append(pts, [2]float32{float32(p[0]), float32(p[1])})
+	}
+
+	ps := &PointSet{cell: 1, cols: 1, rows: 1}
+	if len(pts) == 0 {
+		ps.start = make([]int32, 2)
+		return ps
+	}
+
+	// Compute the bounding box of the points
+	minX, minY := pts[0][0], pts[0][1]
+	maxX, maxY := minX, minY
+	for _, p := range pts[1:] {
+		minX, maxX = min(minX, p[0]), max(maxX, p[0])
+		minY, maxY = min(minY, p[1]), max(maxY, p[1])
+	}
+
+	// Pick a cell size so there is roughly one point per cell
+	w, h, n := maxX-minX, maxY-minY, float32(len(pts))
+	switch {
+	case w > 0 && h > 0:
+		ps.cell = float32(math.Sqrt(float64(w * h / n)))
+	case w > 0 || h > 0:
+		ps.cell = max(w, h) / n
+	}
+
+	ps.minX, ps.minY = minX, minY
+	ps.cols = int(w/ps.cell) + 1
+	ps.rows = int(h/ps.cell) + 1
+
+	// Counting sort of the points into their cells
+	ps.start = make([]int32, ps.cols*ps.rows+1)
+	for _, p := range pts {
+		ps.start[ps.indexOf(p[0], p[1])+1]++
+	}
+	for i := 1; i < len(ps.start); i++ {
+		ps.start[i] += ps.start[i-1]
+	}
+
+	next := make([]int32, len(ps.start))
+	copy(next, ps.start)
+	ps.points = make([][2]float32, len(pts))
+	for _, p := range pts {
+		i := ps.indexOf(p[0], p[1])
+		ps.points[next[i]] = p
+		next[i]++
+	}
+	return ps
+}
+
+// Len returns the number of points in the set
+func (ps *PointSet) Len() int {
+	return len(ps.points)
+}
+
+// Nearest returns the point closest to (x, y), or false if the set is empty
+func (ps *PointSet) Nearest(x, y float32) ([2]float32, bool) {
+	if len(ps.points) == 0 {
+		return [2]float32{}, false
+	}
+
+	cx, cy := ps.cellOf(x, y)
+	best, bestDist := [2]float32{}, float32(math.MaxFloat32)
+	for r := 0; r <= max(ps.cols, ps.rows); r++ {
+		for gy := cy - r; gy <= cy+r; gy++ {
+			step := 2 * r // only visit the left and right edges of the ring
+			if gy == cy-r || gy == cy+r {
+				step = 1
+			}
+
+			for gx := cx - r; gx <= cx+r; gx += max(step, 1) {
+				for _, p := range ps.bucket(gx, gy) {
+					if d := dist2(p, x, y); d < bestDist {
+						best, bestDist = p, d
+					}
+				}
+			}
+		}
+
+		// Stop once no unvisited cell can contain anything closer
+		x0 := ps.minX + float32(cx-r)*ps.cell
+		y0 := ps.minY + float32(cy-r)*ps.cell
+		x1 := ps.minX + float32(cx+r+1)*ps.cell
+		y1 := ps.minY + float32(cy+r+1)*ps.cell
+		if edge := min(x-x0, x1-x, y-y0, y1-y); edge > 0 && bestDist <= edge*edge {
+			break
+		}
+	}
+
+	return best, true
+}
+
+// Within returns all points at distance <= r from (x, y)
+func (ps *PointSet) Within(x, y, r float32) iter.Seq[[2]float32] {
+	return func(yield func([2]float32) bool) {
+		for p := range ps.RangeAABB(x-r, y-r, x+r, y+r) {
+			if dist2(p, x, y) <= r*r && !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// RangeAABB returns all points inside the axis-aligned box [x0, x1] × [y0, y1]
+func (ps *PointSet) RangeAABB(x0, y0, x1, y1 float32) iter.Seq[[2]float32] {
+	return func(yield func([2]float32) bool) {
+		if len(ps.points) == 0 || x0 > x1 || y0 > y1 {
+			return
+		}
+
+		gx0, gy0 := ps.cellOf(x0, y0)
+		gx1, gy1 := ps.cellOf(x1, y1)
+		for gy := gy0; gy <= gy1; gy++ {
+			for gx := gx0; gx <= gx1; gx++ {
+				for _, p := range ps.bucket(gx, gy) {
+					if p[0] >= x0 && p[0] <= x1 && p[1] >= y0 && p[1] <= y1 && !yield(p) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// cellOf returns the grid cell containing (x, y), clamped to the grid
+func (ps *PointSet) cellOf(x, y float32) (int, int) {
+	gx := int(math.Floor(float64((x - ps.minX) / ps.cell)))
+	gy := int(math.Floor(float64((y - ps.minY) / ps.cell)))
+	return min(max(gx, 0), ps.cols-1), min(max(gy, 0), ps.rows-1)
+}
+
+// indexOf returns the row-major cell index of (x, y)
+func (ps *PointSet) indexOf(x, y float32) int {
+	gx, gy := ps.cellOf(x, y)
+	return gy*ps.cols + gx
+}
+
+// bucket returns the points of a grid cell, or nil if the cell is out of bounds
+func (ps *PointSet) bucket(gx, gy int) [][2]float32 {
+	if gx < 0 || gx >= ps.cols || gy < 0 || gy >= ps.rows {
+		return nil
+	}
+
+	i := gy*ps.cols + gx
+	return ps.points[ps.start[i]:ps.start[i+1]]
+}
+
+// dist2 returns the squared distance between p and (x, y)
+func dist2(p [2]float32, x, y float32) float32 {
+	dx, dy := p[0]-x, p[1]-y
+	return dx*dx + dy*dy
+}
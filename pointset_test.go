@@ -0,0 +1,95 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPointSetNearest(t *testing.T) {
+	pts := collect2(Sparse2(42, 200, 100, 6))
+	ps := NewPointSet(Sparse2(42, 200, 100, 6))
+	assert.Equal(t, len(pts), ps.Len())
+
+	for i := 0; i < 500; i++ {
+		x := Float32(1, uint64(i))*260 - 30
+		y := Float32(2, uint64(i))*160 - 30
+
+		// Brute-force reference
+		var best float32 = 1e30
+		for _, p := range pts {
+			best = min(best, dist2([2]float32{float32(p[0]), float32(p[1])}, x, y))
+		}
+
+		p, ok := ps.Nearest(x, y)
+		assert.True(t, ok)
+		assert.Equal(t, best, dist2(p, x, y), "query (%v, %v)", x, y)
+	}
+}
+
+func TestPointSetWithin(t *testing.T) {
+	pts := collect2(SSI2(42, 20, 20))
+	ps := NewPointSet(SSI2(42, 20, 20))
+
+	for i := 0; i < 100; i++ {
+		x := Float32(1, uint64(i))*40 - 20
+		y := Float32(2, uint64(i))*40 - 20
+		r := Float32(3, uint64(i)) * 5
+
+		expect := 0
+		for _, p := range pts {
+			if dist2(p, x, y) <= r*r {
+				expect++
+			}
+		}
+
+		actual := collect2(ps.Within(x, y, r))
+		assert.Len(t, actual, expect)
+		for _, p := range actual {
+			assert.LessOrEqual(t, dist2(p, x, y), r*r)
+		}
+	}
+}
+
+func TestPointSetRangeAABB(t *testing.T) {
+	pts := collect2(Sparse2(7, 100, 100, 4))
+	ps := NewPointSet(Sparse2(7, 100, 100, 4))
+
+	expect := 0
+	for _, p := range pts {
+		if p[0] >= 10 && p[0] <= 40 && p[1] >= 20 && p[1] <= 60 {
+			expect++
+		}
+	}
+
+	assert.Len(t, collect2(ps.RangeAABB(10, 20, 40, 60)), expect)
+	assert.Empty(t, collect2(ps.RangeAABB(40, 20, 10, 60)))
+	assert.Len(t, collect2(ps.RangeAABB(-1e6, -1e6, 1e6, 1e6)), len(pts))
+}
+
+func TestPointSetDegenerate(t *testing.T) {
+	empty := NewPointSet(Sparse2(1, 0, 0, 1))
+	_, ok := empty.Nearest(0, 0)
+	assert.False(t, ok)
+	assert.Empty(t, collect2(empty.Within(0, 0, 10)))
+
+	// All points on a single line
+	line := NewPointSet(func(yield func([2]int) bool) {
+		for i := 0; i < 10; i++ {
+			if !yield([2]int{i * 3, 5}) {
+				return
+			}
+		}
+	})
+
+	p, ok := line.Nearest(10, 0)
+	assert.True(t, ok)
+	assert.Equal(t, [2]float32{9, 5}, p)
+	assert.Len(t, collect2(line.Within(0, 5, 3)), 2)
+
+	// Single point
+	one := NewPointSet(func(yield func([2]float32) bool) { yield([2]float32{1, 1}) })
+	p, ok = one.Nearest(100, -100)
+	assert.True(t, ok)
+	assert.Equal(t, [2]float32{1, 1}, p)
+}
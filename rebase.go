@@ -0,0 +1,99 @@
+package noise
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Skew and unskew factors in 0.64 fixed point, truncated so that each is below its
+// exact value by less than 2^-64. Scaling an integer origin sum n by one of them is
+// therefore off by less than |n| * 2^-64, which stays under the 2^-24 resolution of
+// the float32 in-cell offsets for |n| up to 2^40.
+const (
+	f2q = 0x5db3d742c265539d // 0.5 * (math.Sqrt(3) - 1)
+	g2q = 0x361962e9bf338ecb // (3 - math.Sqrt(3)) / 6
+	f3q = 0x5555555555555555 // 1.0 / 3.0
+	g3q = 0x2aaaaaaaaaaaaaaa // 1.0 / 6.0
+)
+
+// EvalAt evaluates simplex noise at origin + coords, where origin is an integer
+// lattice offset and coords is a (small) fractional offset from it. The skewing
+// of the integer part is carried out in 64.64 fixed point, so the cell lookup and
+// the in-cell offsets keep full float32 precision at any distance from the world
+// origin, e.g. when origin is the position of a chunk on a planetary scale map.
+// Supports 1D, 2D, and 3D noise; len(origin) must match len(coords).
+//
+// Example:
+//
+//	// Sample a 64x64 chunk at (cx, cy) without losing precision
+//	v := s.EvalAt([]int64{cx * 64, cy * 64}, float32(x), float32(y))
+func (s *Simplex) EvalAt(origin []int64, coords ...float32) float32 {
+	if len(origin) != len(coords) {
+		panic("noise: origin and coordinates must have the same dimension")
+	}
+
+	switch len(coords) {
+	case 1:
+		return s.rebase2D(origin[0], 0, coords[0], 0)
+	case 2:
+		return s.rebase2D(origin[0], origin[1], coords[0], coords[1])
+	case 3:
+		return s.rebase3D(origin[0], origin[1], origin[2], coords[0], coords[1], coords[2])
+	default:
+		panic("noise: simplex requires 1, 2, or 3 coordinates")
+	}
+}
+
+// rebase2D computes 2D simplex noise at (ox + x, oy + y)
+func (s *Simplex) rebase2D(ox, oy int64, x, y float32) float32 {
+	// Skew the integer origin exactly and the fractional part in float64
+	kInt, kFrac := mulFixed(ox+oy, f2q)
+	sk := kFrac + float64((float64(x)+float64(y))*f2x64)
+	i := ox + kInt + int64(math.Floor(float64(x)+sk))
+	j := oy + kInt + int64(math.Floor(float64(y)+sk))
+
+	// Unskew the cell origin back, the integer parts cancel out
+	tInt, tFrac := mulFixed(i+j, g2q)
+	x0 := float32(float64(ox-i+tInt) + float64(x) + tFrac)
+	y0 := float32(float64(oy-j+tInt) + float64(y) + tFrac)
+	return s.cell2D(int(i&255), int(j&255), x0, y0)
+}
+
+// rebase3D computes 3D simplex noise at (ox + x, oy + y, oz + z)
+func (s *Simplex) rebase3D(ox, oy, oz int64, x, y, z float32) float32 {
+	// Skew the integer origin exactly and the fractional part in float64
+	kInt, kFrac := mulFixed(ox+oy+oz, f3q)
+	sk := kFrac + float64((float64(x)+float64(y)+float64(z))*f3)
+	i := ox + kInt + int64(math.Floor(float64(x)+sk))
+	j := oy + kInt + int64(math.Floor(float64(y)+sk))
+	k := oz + kInt + int64(math.Floor(float64(z)+sk))
+
+	// Unskew the cell origin back, the integer parts cancel out
+	tInt, tFrac := mulFixed(i+j+k, g3q)
+	x0 := float32(float64(ox-i+tInt) + float64(x) + tFrac)
+	y0 := float32(float64(oy-j+tInt) + float64(y) + tFrac)
+	z0 := float32(float64(oz-k+tInt) + float64(z) + tFrac)
+	return s.cell3D(int(i&255), int(j&255), int(k&255), x0, y0, z0)
+}
+
+// mulFixed multiplies n by a 0.64 fixed point factor, returning the result split
+// into its integer floor and its fractional part in [0, 1].
+func mulFixed(n int64, q uint64) (int64, float64) {
+	v, frac := mulFixedBits(n, q)
+	return v, float64(frac) / (1 << 64)
+}
+
+// mulFixedBits multiplies n by a 0.64 fixed point factor, returning the integer
+// floor of the result and the remaining fraction in 0.64 fixed point
+func mulFixedBits(n int64, q uint64) (int64, uint64) {
+	if n >= 0 {
+		hi, lo := bits.Mul64(uint64(n), q)
+		return int64(hi), lo
+	}
+
+	hi, lo := bits.Mul64(uint64(-n), q)
+	if lo == 0 {
+		return -int64(hi), 0
+	}
+	return -int64(hi) - 1, -lo
+}
@@ -0,0 +1,68 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvalAtOrigin(t *testing.T) {
+	s := NewSimplex(42)
+
+	// With a zero origin, the rebased path must match the regular one
+	for i := 0; i < 1000; i++ {
+		x, y, z := White(1, i)*100, White(2, i)*100, White(3, i)*100
+		assert.InDelta(t, s.Eval(x), s.EvalAt([]int64{0}, x), 1e-4)
+		assert.InDelta(t, s.Eval(x, y), s.EvalAt([]int64{0, 0}, x, y), 1e-4)
+		assert.InDelta(t, s.Eval(x, y, z), s.EvalAt([]int64{0, 0, 0}, x, y, z), 1e-4)
+	}
+}
+
+func TestEvalAtTranslation(t *testing.T) {
+	s := NewSimplex(42)
+
+	// Moving a unit from the fraction into the origin must not change the result
+	for _, o := range []int64{-1e15, -123456789, 0, 987654321, 1e15} {
+		for i := 0; i < 100; i++ {
+			x, y, z := White(1, i)*2, White(2, i)*2, White(3, i)*2
+			assert.InDelta(t,
+				s.EvalAt([]int64{o, o}, x+1, y),
+				s.EvalAt([]int64{o + 1, o}, x, y), 1e-4)
+			assert.InDelta(t,
+				s.EvalAt([]int64{o, -o, o}, x, y, z+1),
+				s.EvalAt([]int64{o, -o, o + 1}, x, y, z), 1e-4)
+		}
+	}
+}
+
+func TestEvalAtPrecision(t *testing.T) {
+	s := NewSimplex(42)
+
+	// At planetary distances, small steps still resolve into distinct values
+	const origin = int64(1e12)
+	distinct := map[float32]bool{}
+	for i := 0; i < 100; i++ {
+		v := s.EvalAt([]int64{origin, origin}, float32(i)*0.01, 0.5)
+		assert.True(t, v >= -1 && v <= 1)
+		distinct[v] = true
+	}
+	assert.Equal(t, 100, len(distinct))
+
+	assert.Panics(t, func() { s.EvalAt([]int64{1}, 1, 2) })
+	assert.Panics(t, func() { s.EvalAt([]int64{1, 2, 3, 4}, 1, 2, 3, 4) })
+}
+
+func TestMulFixed(t *testing.T) {
+	half := uint64(1 << 63)
+	for _, tc := range []struct {
+		n    int64
+		i    int64
+		frac float64
+	}{
+		{0, 0, 0}, {1, 0, 0.5}, {3, 1, 0.5}, {-1, -1, 0.5}, {-2, -1, 0}, {-3, -2, 0.5},
+	} {
+		i, frac := mulFixed(tc.n, half)
+		assert.Equal(t, tc.i, i, "n=%d", tc.n)
+		assert.Equal(t, tc.frac, frac, "n=%d", tc.n)
+	}
+}
@@ -0,0 +1,190 @@
+package noise
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// ---------------------------------- Quantiles ----------------------------------
+
+// quantileKnots is the number of knots kept to approximate the distribution
+const quantileKnots = 1025
+
+// Quantiles is the empirical distribution of a source's output, measured once for
+// a given generator and parameters. Fractal noise is bell-shaped, so fixed
+// thresholds split it into very uneven areas; mapping values through the
+// distribution fixes that by making every value range equally likely.
+type Quantiles struct {
+	knots []float32 // sorted output values at evenly spaced probabilities
+}
+
+// NewQuantiles measures the distribution of a source by sampling it at the given
+// number of pseudo-random positions over a wide area. More samples give a more
+// accurate distribution, 100 000 is plenty for most generators.
+//
+// Example:
+//
+//	terrain := NewFBM(12345)
+//	q := NewQuantiles(terrain, 100000)
+//	flat := Remap(terrain, q, Uniform) // every height is now equally likely
+func NewQuantiles(src Source2, samples int) *Quantiles {
+	if samples < 2 {
+		panic("noise: quantiles need at least 2 samples")
+	}
+
+	values := make([]float32, samples)
+	for i := range values {
+		x, y, _ := probe(uint64(i))
+		values[i] = src.Eval2(x, y)
+	}
+	slices.Sort(values)
+	return newQuantiles(values)
+}
+
+// probe returns the i-th pseudo-random position used to measure the output of a
+// source, spread over a wide area so that its statistics are stationary
+func probe(i uint64) (x, y, z float32) {
+	const extent = 4096
+	x = (Float32(1, i) - 0.5) * extent
+	y = (Float32(2, i) - 0.5) * extent
+	z = (Float32(3, i) - 0.5) * extent
+	return
+}
+
+// newQuantiles builds the knots from sorted values
+func newQuantiles(sorted []float32) *Quantiles {
+	n := min(len(sorted), quantileKnots)
+	knots := make([]float32, n)
+	for i := range knots {
+		knots[i] = sorted[i*(len(sorted)-1)/(n-1)]
+	}
+	return &Quantiles{knots: knots}
+}
+
+// CDF returns the fraction of the source's output that is below v, in [0, 1]
+func (q *Quantiles) CDF(v float32) float32 {
+	k := q.knots
+	switch {
+	case v <= k[0]:
+		return 0
+	case v >= k[len(k)-1]:
+		return 1
+	}
+
+	// Interpolate linearly between the surrounding knots
+	i := sort.Search(len(k), func(i int) bool { return k[i] > v })
+	lo, hi := k[i-1], k[i]
+	t := (v - lo) / (hi - lo)
+	return (float32(i-1) + t) / float32(len(k)-1)
+}
+
+// Quantile returns the output value below which the fraction p of the source's
+// output lies, which is the inverse of CDF. This is handy to pick thresholds, for
+// example Quantile(0.3) is the sea level that floods 30% of the map.
+func (q *Quantiles) Quantile(p float32) float32 {
+	k := q.knots
+	f := min(max(p, 0), 1) * float32(len(k)-1)
+	i := min(int(f), len(k)-2)
+	return lerp(k[i], k[i+1], f-float32(i))
+}
+
+// ---------------------------------- Remap ----------------------------------
+
+// Distribution is a target distribution for Remap
+type Distribution uint8
+
+// Target distributions
+const (
+	Uniform Distribution = iota // uniform in [-1, 1]
+	Normal                      // standard normal, clamped to ±4 standard deviations
+)
+
+// Remap returns a source whose output follows the target distribution, by mapping
+// each value of src through its measured quantiles. The mapping is monotonic, so
+// features stay in place and only their relative heights change.
+func Remap(src Source, q *Quantiles, dist Distribution) Source {
+	return &remap{src: src, q: q, dist: dist}
+}
+
+// remap is the source returned by Remap
+type remap struct {
+	src  Source
+	q    *Quantiles
+	dist Distribution
+}
+
+// Eval2 evaluates the remapped source in 2D
+func (r *remap) Eval2(x, y float32) float32 {
+	return r.apply(r.src.Eval2(x, y))
+}
+
+// Eval3 evaluates the remapped source in 3D
+func (r *remap) Eval3(x, y, z float32) float32 {
+	return r.apply(r.src.Eval3(x, y, z))
+}
+
+// apply maps a value to the target distribution
+func (r *remap) apply(v float32) float32 {
+	p := r.q.CDF(v)
+	switch r.dist {
+	case Normal:
+		z := math.Sqrt2 * math.Erfinv(2*float64(p)-1)
+		return float32(min(max(z, -4), 4))
+	default:
+		return 2*p - 1
+	}
+}
+
+// ---------------------------------- Normalize ----------------------------------
+
+// Normalize returns a source whose output fills [-1, 1]. The scaling constants of
+// simplex noise and the amplitude sum of FBM only approximately bound the output,
+// and in practice the extremes are never reached: 3D simplex peaks at about 0.98
+// and 4 octaves of FBM at about 0.85. Normalize measures the peak magnitude of the
+// source in 2D and 3D at the given number of pseudo-random positions, divides by
+// it and clamps the rare values beyond it, so zero stays at zero and the output is
+// guaranteed to lie within [-1, 1]. Calibrate once per generator and parameters,
+// 100 000 samples give bounds within a percent.
+//
+// Example:
+//
+//	terrain := Normalize(NewFBM(12345), 100000)
+//	height := terrain.Eval2(x, y) // in [-1, 1], reaching both ends
+func Normalize(src Source, samples int) Source {
+	if samples < 1 {
+		panic("noise: normalization needs at least 1 sample")
+	}
+
+	var peak2, peak3 float32
+	for i := 0; i < samples; i++ {
+		x, y, z := probe(uint64(i))
+		peak2 = max(peak2, abs(src.Eval2(x, y)))
+		peak3 = max(peak3, abs(src.Eval3(x, y, z)))
+	}
+
+	n := &normalize{src: src, scale2: 1, scale3: 1}
+	if peak2 > 0 {
+		n.scale2 = 1 / peak2
+	}
+	if peak3 > 0 {
+		n.scale3 = 1 / peak3
+	}
+	return n
+}
+
+// normalize is the source returned by Normalize
+type normalize struct {
+	src            Source
+	scale2, scale3 float32
+}
+
+// Eval2 evaluates the normalized source in 2D
+func (n *normalize) Eval2(x, y float32) float32 {
+	return min(max(n.src.Eval2(x, y)*n.scale2, -1), 1)
+}
+
+// Eval3 evaluates the normalized source in 3D
+func (n *normalize) Eval3(x, y, z float32) float32 {
+	return min(max(n.src.Eval3(x, y, z)*n.scale3, -1), 1)
+}
@@ -0,0 +1,97 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuantiles(t *testing.T) {
+	q := NewQuantiles(NewFBM(1), 50000)
+	assert.Len(t, q.knots, quantileKnots)
+	assert.Equal(t, float32(0), q.CDF(-2))
+	assert.Equal(t, float32(1), q.CDF(2))
+
+	// CDF and Quantile are inverses of each other
+	for _, p := range []float32{0.1, 0.25, 0.5, 0.75, 0.9} {
+		assert.InDelta(t, p, q.CDF(q.Quantile(p)), 1e-3)
+	}
+
+	// FBM is symmetric around zero
+	assert.InDelta(t, 0, q.Quantile(0.5), 0.05)
+	assert.Panics(t, func() { NewQuantiles(NewFBM(1), 1) })
+}
+
+func TestRemapUniform(t *testing.T) {
+	src := NewFBM(1)
+	flat := Remap(src, NewQuantiles(src, 50000), Uniform)
+
+	// Each quarter of the range covers a quarter of the area
+	var counts [4]int
+	const n = 20000
+	for i := 0; i < n; i++ {
+		v := flat.Eval2(float32(i%200)*0.37, float32(i/200)*0.37)
+		assert.True(t, v >= -1 && v <= 1)
+		counts[min(int((v+1)*2), 3)]++
+	}
+	for _, c := range counts {
+		assert.InDelta(t, n/4, c, n/20)
+	}
+
+	// The mapping is monotonic
+	a, b := src.Eval3(1, 2, 3), src.Eval3(4, 5, 6)
+	assert.Equal(t, a < b, flat.Eval3(1, 2, 3) < flat.Eval3(4, 5, 6))
+}
+
+func TestRemapNormal(t *testing.T) {
+	src := NewFBM(1)
+	normal := Remap(src, NewQuantiles(src, 50000), Normal)
+
+	mean, variance := moments(20000, func(i uint64) float64 {
+		return float64(normal.Eval2(float32(i%200)*0.37, float32(i/200)*0.37))
+	})
+	assert.InDelta(t, 0, mean, 0.1)
+	assert.InDelta(t, 1, variance, 0.15)
+}
+
+func BenchmarkRemap(b *testing.B) {
+	src := NewFBM(1)
+	flat := Remap(src, NewQuantiles(src, 10000), Uniform)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		flat.Eval2(float32(i), 0)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	sources := []Source{NewSimplex(1), NewFBM(1)}
+	for octaves := 2; octaves <= 8; octaves++ {
+		f := NewFBM(uint32(octaves))
+		f.Octaves = octaves
+		sources = append(sources, f)
+	}
+
+	for _, src := range sources {
+		norm := Normalize(src, 100000)
+
+		// Both the raw and normalized output stay within bounds at unseen positions and reaches both ends
+		var lo2, hi2, lo3, hi3 float32
+		for i := uint64(0); i < 100000; i++ {
+			x, y, z := probe(i + 1<<40)
+			v2, v3 := norm.Eval2(x, y), norm.Eval3(x, y, z)
+			assert.LessOrEqual(t, abs(src.Eval3(x, y, z)), float32(1))
+			assert.True(t, v2 >= -1 && v2 <= 1)
+			assert.True(t, v3 >= -1 && v3 <= 1)
+			lo2, hi2 = min(lo2, v2), max(hi2, v2)
+			lo3, hi3 = min(lo3, v3), max(hi3, v3)
+		}
+
+		assert.Greater(t, min(-lo2, hi2), float32(0.9))
+		assert.Greater(t, min(-lo3, hi3), float32(0.9))
+	}
+
+	// Zero stays at zero
+	assert.Equal(t, float32(0), Normalize(Constant(0), 10).Eval3(1, 2, 3))
+	assert.Panics(t, func() { Normalize(NewSimplex(1), 0) })
+}
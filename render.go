@@ -0,0 +1,73 @@
+package noise
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// RenderTiles evaluates fn over a w×h grid and returns the values in row-major order.
+// The grid is partitioned into square tiles of the given size which are evaluated
+// concurrently by a pool of workers. Each cell only depends on fn(x, y), so the output
+// is identical regardless of the number of workers or the scheduling order.
+//
+// Notes:
+//   - fn must be safe for concurrent use, which holds for Simplex, FBM and White.
+//   - If workers <= 0, GOMAXPROCS workers are used. If tile <= 0, 64 is used.
+//   - Returns ctx.Err() if the context is cancelled before all tiles are rendered.
+//
+// Example:
+//
+//	f := NewFBM(12345)
+//	heights, err := RenderTiles(ctx, 1024, 1024, 64, func(x, y int) float32 {
+//	    return f.Eval(2.0, 0.5, 6, float32(x)*0.01, float32(y)*0.01)
+//	}, 0)
+func RenderTiles(ctx context.Context, w, h, tile int, fn func(x, y int) float32, workers int) ([]float32, error) {
+	if w <= 0 || h <= 0 {
+		return nil, nil
+	}
+
+	if tile <= 0 {
+		tile = 64
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	// Enumerate the tiles in row-major order
+	cols := (w + tile - 1) / tile
+	rows := (h + tile - 1) / tile
+	tiles := make(chan int, cols*rows)
+	for i := 0; i < cols*rows; i++ {
+		tiles <- i
+	}
+	close(tiles)
+
+	out := make([]float32, w*h)
+	var wg sync.WaitGroup
+	for i := 0; i < min(workers, cols*rows); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range tiles {
+				if ctx.Err() != nil {
+					return
+				}
+
+				x0, y0 := (t%cols)*tile, (t/cols)*tile
+				for y := y0; y < min(y0+tile, h); y++ {
+					row := out[y*w : (y+1)*w]
+					for x := x0; x < min(x0+tile, w); x++ {
+						row[x] = fn(x, y)
+					}
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
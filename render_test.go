@@ -0,0 +1,41 @@
+package noise
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderTiles(t *testing.T) {
+	f := NewFBM(42)
+	fn := func(x, y int) float32 {
+		return f.Eval(2.0, 0.5, 4, float32(x)*0.05, float32(y)*0.05)
+	}
+
+	expect, err := RenderTiles(context.Background(), 67, 45, 16, fn, 1)
+	assert.NoError(t, err)
+	assert.Len(t, expect, 67*45)
+	assert.Equal(t, fn(66, 44), expect[44*67+66])
+
+	for _, workers := range []int{0, 2, 7, 64} {
+		for _, tile := range []int{0, 1, 13, 100} {
+			actual, err := RenderTiles(context.Background(), 67, 45, tile, fn, workers)
+			assert.NoError(t, err)
+			assert.Equal(t, expect, actual, "workers=%d tile=%d", workers, tile)
+		}
+	}
+}
+
+func TestRenderTilesCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	out, err := RenderTiles(ctx, 100, 100, 10, func(x, y int) float32 { return 1 }, 4)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, out)
+
+	out, err = RenderTiles(context.Background(), 0, 100, 10, func(x, y int) float32 { return 1 }, 4)
+	assert.NoError(t, err)
+	assert.Empty(t, out)
+}
@@ -0,0 +1,264 @@
+package noise
+
+import (
+	"encoding/binary"
+	"fmt"
+	"iter"
+	"math"
+)
+
+// ---------------------------------- Resumable SSI ----------------------------------
+
+// Binary tags of the resumable samplers
+const (
+	tagSSI2    = 'i'
+	tagSparse2 = 'p'
+)
+
+// SSISampler2 is a resumable form of SSI2. It produces exactly the same points in
+// the same order, but keeps its progress (the current ring and the occupancy grid)
+// between calls, so that a world can be populated incrementally across frames or
+// server ticks. Its progress can be saved with MarshalBinary and restored later,
+// even in another process. It is not safe for concurrent use.
+type SSISampler2 struct {
+	seed   uint32
+	r1, r2 int
+	ring   int   // current ring, 0 being the center cell
+	cell   int   // index of the next cell to try within the ring
+	grid   grid2 // occupancy of the accepted samples
+}
+
+// NewSSISampler2 creates a resumable SSI2 sampler over [−r1, +r1] × [−r2, +r2]
+//
+// Example:
+//
+//	s := NewSSISampler2(12345, 512, 512)
+//	for p := range s.Take(100) {
+//	    // place 100 points this tick
+//	}
+//	checkpoint, err := s.MarshalBinary()
+func NewSSISampler2(seed uint32, r1, r2 int) *SSISampler2 {
+	s := &SSISampler2{seed: seed, r1: max(r1, 0), r2: max(r2, 0)}
+	if s.r1 > 0 && s.r2 > 0 {
+		s.grid = newGrid2(s.r1, s.r2)
+	} else {
+		s.ring = 1 // nothing to generate
+	}
+	return s
+}
+
+// Next returns the next point, or false once the sampler is exhausted
+func (s *SSISampler2) Next() ([2]float32, bool) {
+	for !s.Done() {
+		ix, iy, ok := ringCell(s.r1, s.r2, s.ring, s.cell)
+		if !ok {
+			s.ring++
+			s.cell = 0
+			continue
+		}
+
+		s.cell++
+		if pt, ok := s.grid.try(s.seed, ix, iy); ok {
+			return pt, true
+		}
+	}
+	return [2]float32{}, false
+}
+
+// Done returns whether every cell has been visited
+func (s *SSISampler2) Done() bool {
+	return s.ring > max(s.r1, s.r2)
+}
+
+// Take returns an iterator over at most n of the next points, advancing the
+// sampler as they are consumed. Use n < 0 to take all the remaining points.
+func (s *SSISampler2) Take(n int) iter.Seq[[2]float32] {
+	return func(yield func([2]float32) bool) {
+		for i := 0; i != n; i++ {
+			pt, ok := s.Next()
+			if !ok || !yield(pt) {
+				return
+			}
+		}
+	}
+}
+
+// MarshalBinary saves the progress of the sampler, implementing
+// encoding.BinaryMarshaler
+func (s *SSISampler2) MarshalBinary() ([]byte, error) {
+	out := header(tagSSI2, 20+8*len(s.grid.Bitmap))
+	for _, v := range []uint32{s.seed, uint32(s.r1), uint32(s.r2), uint32(s.ring), uint32(s.cell)} {
+		out = binary.LittleEndian.AppendUint32(out, v)
+	}
+	for _, w := range s.grid.Bitmap {
+		out = binary.LittleEndian.AppendUint64(out, w)
+	}
+	return out, nil
+}
+
+// UnmarshalBinary restores the progress of a sampler saved by MarshalBinary,
+// implementing encoding.BinaryUnmarshaler
+func (s *SSISampler2) UnmarshalBinary(data []byte) error {
+	data, err := readHeader(data, tagSSI2)
+	if err != nil {
+		return err
+	}
+	if len(data) < 20 {
+		return fmt.Errorf("noise: invalid sampler encoding length %d", len(data))
+	}
+
+	var v [5]uint32
+	for i := range v {
+		v[i] = binary.LittleEndian.Uint32(data[4*i:])
+	}
+
+	// Validate the radii and the size of their grid before allocating it
+	r1, r2 := int(int32(v[1])), int(int32(v[2]))
+	if r1 < 0 || r2 < 0 || v[3] > math.MaxInt32 || v[4] > math.MaxInt32 {
+		return fmt.Errorf("noise: invalid sampler encoding")
+	}
+
+	var size uint64
+	if r1 > 0 && r2 > 0 {
+		bits, ok := grid2Bits(r1, r2)
+		if !ok {
+			return fmt.Errorf("noise: invalid sampler radius %dx%d", r1, r2)
+		}
+		size = (bits + 63) / 64
+	}
+
+	words := data[20:]
+	if uint64(len(words)) != 8*size {
+		return fmt.Errorf("noise: invalid sampler encoding")
+	}
+
+	// Rebuild the grid for the radii, then restore its occupancy
+	out := NewSSISampler2(v[0], r1, r2)
+	for i := range out.grid.Bitmap {
+		out.grid.Bitmap[i] = binary.LittleEndian.Uint64(words[8*i:])
+	}
+
+	out.ring, out.cell = int(v[3]), int(v[4])
+	*s = *out
+	return nil
+}
+
+// ringCell returns the k-th lattice cell of a ring, in the order SSI2 visits them:
+// the top and bottom edges first, then the left and right edges without corners.
+func ringCell(r1, r2, ring, k int) (int, int, bool) {
+	if ring == 0 {
+		return 0, 0, k == 0
+	}
+
+	// Horizontal edges (top/bottom)
+	if ring <= r2 {
+		ixMin, ixMax := max(-ring, -r1), min(ring, r1)
+		n := ixMax - ixMin + 1
+		if k < 2*n {
+			return ixMin + k%n, ring * (2*(k/n) - 1), true
+		}
+		k -= 2 * n
+	}
+
+	// Vertical edges (left/right)
+	if ring <= r1 {
+		iyMin, iyMax := max(-ring+1, -r2), min(ring-1, r2)
+		if n := iyMax - iyMin + 1; n > 0 && k < 2*n {
+			return ring * (2*(k/n) - 1), iyMin + k%n, true
+		}
+	}
+	return 0, 0, false
+}
+
+// ---------------------------------- Resumable Sparse ----------------------------------
+
+// SparseSampler2 is a resumable form of Sparse2, producing exactly the same
+// positions in the same order. Its progress can be saved with MarshalBinary.
+type SparseSampler2 struct {
+	ssi       *SSISampler2
+	w, h, gap int
+}
+
+// NewSparseSampler2 creates a resumable Sparse2 sampler over [0, w) × [0, h)
+func NewSparseSampler2(seed uint32, w, h, gap int) *SparseSampler2 {
+	if w <= 0 || h <= 0 || gap <= 0 {
+		return &SparseSampler2{ssi: NewSSISampler2(seed, 0, 0)}
+	}
+
+	r1 := int(math.Ceil(float64(w) / float64(2*gap)))
+	r2 := int(math.Ceil(float64(h) / float64(2*gap)))
+	return &SparseSampler2{ssi: NewSSISampler2(seed, r1, r2), w: w, h: h, gap: gap}
+}
+
+// Next returns the next position, or false once the sampler is exhausted
+func (s *SparseSampler2) Next() ([2]int, bool) {
+	cx, cy, g := float32(s.w)/2, float32(s.h)/2, float32(s.gap)
+	for {
+		pt, ok := s.ssi.Next()
+		if !ok {
+			return [2]int{}, false
+		}
+
+		ix := int(float32(pt[0]*g) + cx)
+		iy := int(float32(pt[1]*g) + cy)
+		if ix >= 0 && ix < s.w && iy >= 0 && iy < s.h {
+			return [2]int{ix, iy}, true
+		}
+	}
+}
+
+// Done returns whether every cell has been visited
+func (s *SparseSampler2) Done() bool {
+	return s.ssi.Done()
+}
+
+// Take returns an iterator over at most n of the next positions, advancing the
+// sampler as they are consumed. Use n < 0 to take all the remaining positions.
+func (s *SparseSampler2) Take(n int) iter.Seq[[2]int] {
+	return func(yield func([2]int) bool) {
+		for i := 0; i != n; i++ {
+			pt, ok := s.Next()
+			if !ok || !yield(pt) {
+				return
+			}
+		}
+	}
+}
+
+// MarshalBinary saves the progress of the sampler, implementing
+// encoding.BinaryMarshaler
+func (s *SparseSampler2) MarshalBinary() ([]byte, error) {
+	ssi, err := s.ssi.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	out := header(tagSparse2, 12+len(ssi))
+	for _, v := range []int{s.w, s.h, s.gap} {
+		out = binary.LittleEndian.AppendUint32(out, uint32(v))
+	}
+	return append(out, ssi...), nil
+}
+
+// UnmarshalBinary restores the progress of a sampler saved by MarshalBinary,
+// implementing encoding.BinaryUnmarshaler
+func (s *SparseSampler2) UnmarshalBinary(data []byte) error {
+	data, err := readHeader(data, tagSparse2)
+	if err != nil {
+		return err
+	}
+	if len(data) < 12 {
+		return fmt.Errorf("noise: invalid sampler encoding length %d", len(data))
+	}
+
+	ssi := new(SSISampler2)
+	if err := ssi.UnmarshalBinary(data[12:]); err != nil {
+		return err
+	}
+
+	s.ssi = ssi
+	s.w = int(int32(binary.LittleEndian.Uint32(data[0:])))
+	s.h = int(int32(binary.LittleEndian.Uint32(data[4:])))
+	s.gap = int(int32(binary.LittleEndian.Uint32(data[8:])))
+	return nil
+}
@@ -0,0 +1,110 @@
+package noise
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSSISampler2(t *testing.T) {
+	for _, r := range [][2]int{{16, 16}, {20, 5}, {3, 12}, {1, 1}} {
+		var want [][2]float32
+		for p := range SSI2(7, r[0], r[1]) {
+			want = append(want, p)
+		}
+
+		// Same points in the same order
+		s := NewSSISampler2(7, r[0], r[1])
+		var got [][2]float32
+		for p := range s.Take(-1) {
+			got = append(got, p)
+		}
+		assert.Equal(t, want, got)
+		assert.True(t, s.Done())
+	}
+
+	_, ok := NewSSISampler2(7, 0, 10).Next()
+	assert.False(t, ok)
+}
+
+func TestSSISampler2Resume(t *testing.T) {
+	var want [][2]float32
+	for p := range SSI2(7, 32, 24) {
+		want = append(want, p)
+	}
+
+	// Generate in batches, checkpointing and restoring in between
+	var got [][2]float32
+	s := NewSSISampler2(7, 32, 24)
+	for !s.Done() {
+		for p := range s.Take(37) {
+			got = append(got, p)
+		}
+
+		data, err := s.MarshalBinary()
+		assert.NoError(t, err)
+
+		s = new(SSISampler2)
+		assert.NoError(t, s.UnmarshalBinary(data))
+	}
+	assert.Equal(t, want, got)
+
+	// Invalid encodings
+	data, _ := NewSSISampler2(7, 4, 4).MarshalBinary()
+	assert.Error(t, s.UnmarshalBinary(data[:len(data)-8]))
+	assert.Error(t, s.UnmarshalBinary(data[:10]))
+	assert.Error(t, s.UnmarshalBinary(nil))
+
+	// Radii that do not match the grid are rejected before allocating it
+	for _, r := range []uint32{5, 0x7fffffff, 0xffffffff} {
+		bad := append([]byte(nil), data...)
+		binary.LittleEndian.PutUint32(bad[8:], r)
+		assert.Error(t, s.UnmarshalBinary(bad))
+	}
+}
+
+func TestGrid2Bits(t *testing.T) {
+	n, ok := grid2Bits(4, 4)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(26*26), n)
+
+	_, ok = grid2Bits(1<<15, 1<<15)
+	assert.False(t, ok)
+	assert.Panics(t, func() { newGrid2(1<<15, 1<<15) })
+}
+
+func TestSparseSampler2Resume(t *testing.T) {
+	var want [][2]int
+	for p := range Sparse2(7, 300, 200, 8) {
+		want = append(want, p)
+	}
+
+	var got [][2]int
+	s := NewSparseSampler2(7, 300, 200, 8)
+	for !s.Done() {
+		for p := range s.Take(50) {
+			got = append(got, p)
+		}
+
+		data, err := s.MarshalBinary()
+		assert.NoError(t, err)
+
+		s = new(SparseSampler2)
+		assert.NoError(t, s.UnmarshalBinary(data))
+	}
+	assert.Equal(t, want, got)
+
+	_, ok := NewSparseSampler2(7, 0, 10, 8).Next()
+	assert.False(t, ok)
+	assert.Error(t, s.UnmarshalBinary([]byte{'N', 'Z', 1, 'p'}))
+}
+
+func BenchmarkSSISampler2(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s := NewSSISampler2(uint32(i), 32, 32)
+		for range s.Take(-1) {
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScatter2(t *testing.T) {
+	const w, h = 100, 50
+	half := func(x, y int) float32 {
+		if x < w/2 {
+			return 0
+		}
+		return 2
+	}
+
+	var count int
+	for p := range Scatter2(42, w, h, half) {
+		assert.True(t, p[0] >= w/2 && p[0] < w, "x out of range: %v", p[0])
+		assert.True(t, p[1] >= 0 && p[1] < h, "y out of range: %v", p[1])
+		count++
+	}
+
+	// Expect roughly 2 points per cell on the right half
+	expect := 2 * (w / 2) * h
+	assert.InDelta(t, expect, count, float64(expect)*0.05)
+}
+
+func TestScatter2Deterministic(t *testing.T) {
+	rate := func(x, y int) float32 { return 0.3 }
+	collect := func(seed uint32) (out [][2]float32) {
+		for p := range Scatter2(seed, 32, 32, rate) {
+			out = append(out, p)
+		}
+		return
+	}
+
+	assert.Equal(t, collect(7), collect(7))
+	assert.NotEqual(t, collect(7), collect(8))
+}
+
+func TestScatter2Edge(t *testing.T) {
+	one := func(x, y int) float32 { return 1 }
+	assert.Empty(t, collect2(Scatter2(1, 0, 10, one)))
+	assert.Empty(t, collect2(Scatter2(1, 10, 0, one)))
+	assert.Empty(t, collect2(Scatter2(1, 10, 10, nil)))
+
+	// Early termination
+	n := 0
+	for range Scatter2(1, 100, 100, one) {
+		if n++; n == 5 {
+			break
+		}
+	}
+	assert.Equal(t, 5, n)
+}
+
+func TestPoisson(t *testing.T) {
+	for _, lambda := range []float64{0.5, 4, 50} {
+		var sum int
+		for i := 0; i < 10000; i++ {
+			sum += poisson(42, lambda, uint64(i))
+		}
+		assert.InDelta(t, lambda, float64(sum)/10000, lambda*0.05)
+	}
+	assert.Equal(t, 0, poisson(42, 0, 1))
+}
+
+// collect2 gathers all points of a 2D iterator into a slice
+func collect2[T any](seq func(func(T) bool)) (out []T) {
+	for v := range seq {
+		out = append(out, v)
+	}
+	return
+}
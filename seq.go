@@ -0,0 +1,72 @@
+package noise
+
+import "iter"
+
+// ---------------------------------- Iterator Adaptors ----------------------------------
+
+// Take returns an iterator over the first n values of seq
+//
+// Example:
+//
+//	first := Collect(Take(Sparse2(12345, 512, 512, 8), 100))
+func Take[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+
+		i := 0
+		for v := range seq {
+			if !yield(v) {
+				return
+			}
+			if i++; i == n {
+				return
+			}
+		}
+	}
+}
+
+// Skip returns an iterator over the values of seq after the first n. The skipped
+// values are still generated, since the streaming iterators cannot seek.
+func Skip[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		i := 0
+		for v := range seq {
+			if i < n {
+				i++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// FilterInRect returns an iterator over the points of seq that lie inside the
+// half-open rectangle [x0, x1) × [y0, y1), such as a chunk of a larger map
+//
+// Example:
+//
+//	for p := range FilterInRect(Sparse2(12345, 4096, 4096, 8), 512, 512, 1024, 1024) {
+//	    // points of the chunk at (1, 1)
+//	}
+func FilterInRect[T Number](seq iter.Seq[[2]T], x0, y0, x1, y1 T) iter.Seq[[2]T] {
+	return func(yield func([2]T) bool) {
+		for p := range seq {
+			if p[0] >= x0 && p[0] < x1 && p[1] >= y0 && p[1] < y1 && !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// Collect gathers the values of a finite iterator into a slice
+func Collect[T any](seq iter.Seq[T]) []T {
+	var out []T
+	for v := range seq {
+		out = append(out, v)
+	}
+	return out
+}
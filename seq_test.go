@@ -0,0 +1,53 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTakeSkip(t *testing.T) {
+	all := Collect(Sparse2(1, 128, 128, 8))
+	assert.NotEmpty(t, all)
+
+	assert.Equal(t, all[:10], Collect(Take(Sparse2(1, 128, 128, 8), 10)))
+	assert.Equal(t, all[10:], Collect(Skip(Sparse2(1, 128, 128, 8), 10)))
+	assert.Equal(t, all[10:15], Collect(Take(Skip(Sparse2(1, 128, 128, 8), 10), 5)))
+	assert.Equal(t, all, Collect(Take(Sparse2(1, 128, 128, 8), len(all)+10)))
+	assert.Empty(t, Collect(Take(Sparse2(1, 128, 128, 8), 0)))
+	assert.Empty(t, Collect(Skip(Sparse2(1, 128, 128, 8), len(all))))
+
+	// Stopping early
+	n := 0
+	for range Skip(SSI1(1, 100), 5) {
+		if n++; n == 3 {
+			break
+		}
+	}
+	assert.Equal(t, 3, n)
+}
+
+func TestFilterInRect(t *testing.T) {
+	var want [][2]int
+	for p := range Sparse2(1, 256, 256, 8) {
+		if p[0] >= 64 && p[0] < 128 && p[1] >= 0 && p[1] < 64 {
+			want = append(want, p)
+		}
+	}
+
+	got := Collect(FilterInRect(Sparse2(1, 256, 256, 8), 64, 0, 128, 64))
+	assert.NotEmpty(t, got)
+	assert.Equal(t, want, got)
+
+	// Works with float points too
+	for _, p := range Collect(FilterInRect(SSI2(1, 32, 32), -4, -4, 4, 4)) {
+		assert.True(t, p[0] >= -4 && p[0] < 4 && p[1] >= -4 && p[1] < 4)
+	}
+
+	assert.Len(t, Collect(Take(FilterInRect(SSI2(1, 32, 32), -8, -8, 8, 8), 3)), 3)
+}
+
+func TestCollect(t *testing.T) {
+	assert.Nil(t, Collect(SSI1(1, 0)))
+	assert.Len(t, Collect(Take(SSI1(1, 50), 7)), 7)
+}
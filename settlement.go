@@ -0,0 +1,89 @@
+package noise
+
+import (
+	"math"
+	"slices"
+)
+
+// ---------------------------------- Settlements ----------------------------------
+
+// Site is a ranked settlement location
+type Site struct {
+	Position [2]float32 // Location of the settlement
+	Score    float32    // Suitability at the location in [0, 1], higher is better
+}
+
+// Settlements picks up to n settlement sites within [0, w) × [0, h), ranked from
+// the most to the least suitable, with at least spacing units between any two.
+// The suitability source is expected in [-1, 1], as returned by the generators of
+// this package, and is typically built from flatness, water proximity or fertility.
+// Method: candidates are drawn with Scatter2 at a density proportional to the
+// suitability, so good land is sampled more densely. They are then sorted by score
+// and accepted greedily unless they fall within spacing of a better site.
+// Deterministic for a given seed. Complexity: O(w·h + c·log c) for c candidates.
+//
+// Example:
+//
+//	lowlands := Scale(Ridged(NewFBM(12345)), 0.01, 0.01, 0.01) // prefer mid elevations
+//	for _, site := range Settlements(12345, 512, 512, lowlands, 40, 10) {
+//	    // site 0 is the capital
+//	}
+func Settlements(seed uint32, w, h int, suitability Source2, spacing float32, n int) []Site {
+	if w <= 0 || h <= 0 || n <= 0 || spacing <= 0 || suitability == nil {
+		return nil
+	}
+
+	// Oversample the best land by a few candidates per spacing-sized area
+	var sites []Site
+	rate := 4 / float32(spacing*spacing)
+	for p := range Scatter2(seed, w, h, func(x, y int) float32 {
+		return rate * (suitability.Eval2(float32(x)+0.5, float32(y)+0.5) + 1) / 2
+	}) {
+		if score := (suitability.Eval2(p[0], p[1]) + 1) / 2; score > 0 {
+			sites = append(sites, Site{Position: p, Score: min(score, 1)})
+		}
+	}
+
+	slices.SortStableFunc(sites, func(a, b Site) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	// Greedily accept the best candidates, using a grid of spacing-sized cells to
+	// look up the accepted sites that may be too close.
+	out := make([]Site, 0, n)
+	grid := make(map[[2]int][]int)
+	for _, s := range sites {
+		gx := int(math.Floor(float64(s.Position[0] / spacing)))
+		gy := int(math.Floor(float64(s.Position[1] / spacing)))
+		if !isolated(out, grid, gx, gy, s.Position, spacing) {
+			continue
+		}
+
+		grid[[2]int{gx, gy}] = append(grid[[2]int{gx, gy}], len(out))
+		if out = append(out, s); len(out) == n {
+			break
+		}
+	}
+	return out
+}
+
+// isolated returns whether p is at least spacing away from every accepted site
+func isolated(sites []Site, grid map[[2]int][]int, gx, gy int, p [2]float32, spacing float32) bool {
+	for y := gy - 1; y <= gy+1; y++ {
+		for x := gx - 1; x <= gx+1; x++ {
+			for _, i := range grid[[2]int{x, y}] {
+				if dist2(sites[i].Position, p[0], p[1]) < spacing*spacing {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,37 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettlements(t *testing.T) {
+	// Suitability increases from left to right
+	slope := SourceFunc(func(x, y, z float32) float32 {
+		return x/256*2 - 1
+	})
+
+	sites := Settlements(42, 256, 256, slope, 20, 30)
+	assert.Equal(t, sites, Settlements(42, 256, 256, slope, 20, 30))
+	assert.Len(t, sites, 30)
+
+	for i, s := range sites {
+		if i > 0 {
+			assert.LessOrEqual(t, s.Score, sites[i-1].Score)
+		}
+		for _, o := range sites[i+1:] {
+			assert.GreaterOrEqual(t, dist2(s.Position, o.Position[0], o.Position[1]), float32(20*20))
+		}
+	}
+
+	// The best site is on the suitable side of the map
+	assert.Greater(t, sites[0].Position[0], float32(200))
+}
+
+func TestSettlementsEmpty(t *testing.T) {
+	never := SourceFunc(func(x, y, z float32) float32 { return -1 })
+	assert.Empty(t, Settlements(42, 128, 128, never, 10, 5))
+	assert.Empty(t, Settlements(42, 0, 128, NewSimplex(1), 10, 5))
+	assert.Empty(t, Settlements(42, 128, 128, NewSimplex(1), 10, 0))
+}
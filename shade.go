@@ -0,0 +1,81 @@
+package noise
+
+import (
+	"image"
+	"math"
+)
+
+// ---------------------------------- Shading ----------------------------------
+
+// Hillshade renders a w×h heightmap as a grayscale relief, lit by a distant light
+// coming from the compass azimuth in degrees clockwise from north (-y), at altitude
+// degrees above the horizon. The classic cartographic light comes from the
+// north-west at 45°. Spacing is the horizontal distance between two cells, in the
+// same unit as the heights.
+//
+// Example:
+//
+//	preview := Hillshade(heights, 512, 512, 1, 315, 45)
+//	png.Encode(file, preview)
+func Hillshade(heights []float32, w, h int, spacing, azimuth, altitude float32) *image.Gray {
+	az := float64(azimuth) * math.Pi / 180
+	alt := float64(altitude) * math.Pi / 180
+	lx := math.Sin(az) * math.Cos(alt)
+	ly := -math.Cos(az) * math.Cos(alt)
+	lz := math.Sin(alt)
+
+	return shade(heights, w, h, func(x, y int) float64 {
+		gx, gy := gradient(heights, w, h, x, y, spacing)
+		return (-gx*lx - gy*ly + lz) / math.Sqrt(gx*gx+gy*gy+1)
+	})
+}
+
+// AmbientOcclusion renders the ambient occlusion of a w×h heightmap as grayscale,
+// white being fully open to the sky and darker cells sitting in valleys and creases.
+// For every cell, it marches up to radius cells in the given number of directions to
+// find the elevation of the horizon, and darkens the cell by the average sine of
+// that elevation. Spacing is the horizontal distance between two cells, in the same
+// unit as the heights. Complexity: O(w·h·directions·radius).
+func AmbientOcclusion(heights []float32, w, h int, spacing float32, directions, radius int) *image.Gray {
+	dirs := make([][2]float64, max(directions, 1))
+	for i := range dirs {
+		a := 2 * math.Pi * (float64(i) + 0.5) / float64(len(dirs))
+		dirs[i] = [2]float64{math.Cos(a), math.Sin(a)}
+	}
+
+	return shade(heights, w, h, func(x, y int) float64 {
+		base := float64(heights[y*w+x])
+		var occlusion float64
+		for _, d := range dirs {
+			var horizon float64 // tangent of the horizon elevation
+			for step := 1; step <= radius; step++ {
+				sx := x + int(math.Round(d[0]*float64(step)))
+				sy := y + int(math.Round(d[1]*float64(step)))
+				if sx < 0 || sy < 0 || sx >= w || sy >= h {
+					break
+				}
+
+				dist := float64(step) * float64(spacing)
+				horizon = max(horizon, (float64(heights[sy*w+sx])-base)/dist)
+			}
+			occlusion += horizon / math.Sqrt(1+horizon*horizon)
+		}
+		return 1 - occlusion/float64(len(dirs))
+	})
+}
+
+// shade renders a function in [0, 1] over every cell as a grayscale image
+func shade(heights []float32, w, h int, fn func(x, y int) float64) *image.Gray {
+	if w <= 0 || h <= 0 || len(heights) < w*h {
+		return image.NewGray(image.Rect(0, 0, 0, 0))
+	}
+
+	img := image.NewGray(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := min(max(fn(x, y), 0), 1)
+			img.Pix[y*img.Stride+x] = uint8(v*255 + 0.5)
+		}
+	}
+	return img
+}
@@ -0,0 +1,41 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHillshade(t *testing.T) {
+	flat := Hillshade(make([]float32, 16), 4, 4, 1, 315, 45)
+	assert.Equal(t, uint8(180), flat.GrayAt(1, 1).Y) // sin(45°)
+
+	// Slopes facing the light are brighter than those facing away
+	east := Hillshade(plane(4, 4, -1, 0), 4, 4, 1, 90, 45)
+	west := Hillshade(plane(4, 4, 1, 0), 4, 4, 1, 90, 45)
+	assert.Equal(t, uint8(255), east.GrayAt(1, 1).Y)
+	assert.Equal(t, uint8(0), west.GrayAt(1, 1).Y)
+
+	// Overhead light only depends on the slope
+	top := Hillshade(plane(4, 4, 1, 0), 4, 4, 1, 0, 90)
+	assert.Equal(t, uint8(180), top.GrayAt(1, 1).Y)
+	assert.Equal(t, 0, Hillshade(nil, 4, 4, 1, 0, 45).Bounds().Dx())
+}
+
+func TestAmbientOcclusion(t *testing.T) {
+	const w, h = 32, 32
+	assert.Equal(t, uint8(255), AmbientOcclusion(make([]float32, w*h), w, h, 1, 8, 8).GrayAt(5, 5).Y)
+
+	// The bottom of a valley is darker than the top of a ridge
+	heights := make([]float32, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			heights[y*w+x] = abs(float32(x - w/2))
+		}
+	}
+
+	ao := AmbientOcclusion(heights, w, h, 1, 16, 8)
+	assert.Less(t, ao.GrayAt(w/2, h/2).Y, uint8(200))
+	assert.Equal(t, uint8(255), ao.GrayAt(0, h/2).Y)
+	assert.Equal(t, ao, AmbientOcclusion(heights, w, h, 1, 16, 8))
+}
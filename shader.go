@@ -0,0 +1,431 @@
+package noise
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ---------------------------------- Shader Export ----------------------------------
+
+// ShaderLanguage is a shading language that a pipeline can be exported to
+type ShaderLanguage int
+
+const (
+	GLSL ShaderLanguage = iota // OpenGL Shading Language, 3.30 or ES 3.00 and above
+	HLSL                       // High-Level Shading Language, shader model 4 and above
+	WGSL                       // WebGPU Shading Language
+)
+
+// String returns the name of the shading language
+func (l ShaderLanguage) String() string {
+	switch l {
+	case GLSL:
+		return "glsl"
+	case HLSL:
+		return "hlsl"
+	case WGSL:
+		return "wgsl"
+	default:
+		return fmt.Sprintf("ShaderLanguage(%d)", int(l))
+	}
+}
+
+// ExportShader generates self-contained shader source code for a 2D function with
+// the given name, e.g. `float name(vec2 p)` in GLSL, that reproduces the pipeline
+// with the same seeds, permutation tables and constants. This keeps CPU-side world
+// generation and GPU-side rendering visually consistent. GPUs do not guarantee the
+// same floating-point rounding, so the output matches closely but not bit for bit.
+//
+// Simplex, FBM, Constant, Ridged, the combiners, selectors, transforms, Cache and
+// Curve are supported. Value and Cellular noise rely on 64-bit hashing, which is
+// not portable across shading languages, so they return an error like any other
+// unsupported source.
+//
+// Example:
+//
+//	code, err := ExportShader(terrain, GLSL, "terrain")
+func ExportShader(src Source, lang ShaderLanguage, name string) (string, error) {
+	if lang < GLSL || lang > WGSL {
+		return "", fmt.Errorf("noise: unsupported shader language %v", lang)
+	}
+
+	w := &shaderWriter{lang: lang, name: name, perms: make(map[[512]uint8]string)}
+	root, err := w.node(src)
+	if err != nil {
+		return "", err
+	}
+
+	w.function(name, "return "+root+"(p);")
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "// Generated by github.com/kelindar/noise, do not edit.\n\n")
+	out.WriteString(w.tables.String())
+	out.WriteString(w.funcs.String())
+	return out.String(), nil
+}
+
+// shaderWriter accumulates the tables and functions of a generated shader
+type shaderWriter struct {
+	lang   ShaderLanguage
+	name   string                // name of the entry point, prefix of the helpers
+	tables strings.Builder       // constant tables, emitted first
+	funcs  strings.Builder       // functions, in dependency order
+	perms  map[[512]uint8]string // simplex functions by permutation table
+	corner string                // simplex corner helper, once emitted
+	count  int                   // number of emitted node functions
+}
+
+// node emits the function evaluating a source and returns its name
+func (w *shaderWriter) node(src Source) (string, error) {
+	switch s := src.(type) {
+	case *Simplex:
+		return w.simplex(s), nil
+	case *FBM:
+		return w.fbm(s)
+	case *cache:
+		return w.node(s.src) // evaluation in a shader is stateless
+	case constant:
+		return w.emit("return " + w.float(float32(s)) + ";"), nil
+	case ridged:
+		in, err := w.node(s.src)
+		if err != nil {
+			return "", err
+		}
+		return w.emit("return 1.0 - 2.0 * abs(" + in + "(p));"), nil
+	case *combiner:
+		return w.combiner(s)
+	case *blend:
+		return w.selector(s.a, s.b, s.control, func(a, b, c string) string {
+			return w.mix(a, b, "("+c+" + 1.0) * 0.5")
+		})
+	case *selector:
+		return w.selector(s.a, s.b, s.control, func(a, b, c string) string {
+			if s.falloff == 0 {
+				return w.mix(a, b, "step("+w.float(s.threshold)+", "+c+")")
+			}
+
+			lo, hi := w.float(s.threshold-s.falloff), w.float(s.threshold+s.falloff)
+			return w.mix(a, b, "smoothstep("+lo+", "+hi+", "+c+")")
+		})
+	case *translate:
+		return w.transform(s.src, w.vec2("p.x + "+w.float(s.dx), "p.y + "+w.float(s.dy)))
+	case *scale:
+		return w.transform(s.src, w.vec2("p.x * "+w.float(s.sx), "p.y * "+w.float(s.sy)))
+	case *rotate:
+		sin, cos := w.float(s.s), w.float(s.c)
+		return w.transform(s.src, w.vec2(
+			cos+" * p.x - "+sin+" * p.y",
+			sin+" * p.x + "+cos+" * p.y",
+		))
+	case *curve:
+		return w.curve(s)
+	default:
+		return "", fmt.Errorf("noise: unable to export source of type %T to %v", src, w.lang)
+	}
+}
+
+// simplex emits the 2D simplex noise function of a generator, once per table
+func (w *shaderWriter) simplex(s *Simplex) string {
+	if name, ok := w.perms[s.perm]; ok {
+		return name
+	}
+
+	perm := w.permutation(s)
+	corner := w.simplexCorner()
+	name := w.emit(
+		w.let("float", "s", "(p.x + p.y) * "+w.float(f2)),
+		w.let("int", "i", w.cast("int", "floor(p.x + s)")),
+		w.let("int", "j", w.cast("int", "floor(p.y + s)")),
+		w.let("float", "t", w.cast("float", "i + j")+" * "+w.float(g2)),
+		w.let("vec2", "d0", "p - ("+w.vec2(w.cast("float", "i"), w.cast("float", "j"))+" - t)"),
+		w.let("int", "i1", w.ternary("d0.x > d0.y", "1", "0")),
+		w.let("int", "j1", "1 - i1"),
+		w.let("vec2", "d1", "d0 - "+w.vec2(w.cast("float", "i1"), w.cast("float", "j1"))+" + "+w.float(g2)),
+		w.let("vec2", "d2", "d0 + "+w.float(2*g2-1)),
+		w.let("int", "ii", "i & 255"),
+		w.let("int", "jj", "j & 255"),
+		fmt.Sprintf("return 70.0 * (%s(d0, %s[ii + %s[jj]]) + %s(d1, %s[ii + i1 + %s[jj + j1]]) + %s(d2, %s[ii + 1 + %s[jj + 1]]));",
+			corner, perm, perm, corner, perm, perm, corner, perm, perm),
+	)
+
+	w.perms[s.perm] = name
+	return name
+}
+
+// simplexCorner emits the helper computing the contribution of a simplex corner,
+// along with the gradient tables it uses
+func (w *shaderWriter) simplexCorner() string {
+	if w.corner != "" {
+		return w.corner
+	}
+
+	var gx, gy []string
+	for i := 0; i < 12; i++ {
+		gx = append(gx, w.float(grad2[i][0]))
+		gy = append(gy, w.float(grad2[i][1]))
+	}
+
+	w.corner = w.name + "_corner"
+	w.array("float", w.name+"_gx", gx)
+	w.array("float", w.name+"_gy", gy)
+
+	switch w.lang {
+	case WGSL:
+		fmt.Fprintf(&w.funcs, "fn %s(d: vec2<f32>, h: i32) -> f32 {\n", w.corner)
+	default:
+		fmt.Fprintf(&w.funcs, "float %s(%s d, int h) {\n", w.corner, w.typ("vec2"))
+	}
+
+	for _, line := range []string{
+		w.let("float", "t", "0.5 - dot(d, d)"),
+		w.let("int", "g", "h % 12"),
+		w.let("float", "n", "t * t * t * t * dot("+w.vec2(w.name+"_gx[g]", w.name+"_gy[g]")+", d)"),
+		"return " + w.ternary("t > 0.0", "n", "0.0") + ";",
+	} {
+		w.funcs.WriteString("\t" + line + "\n")
+	}
+	w.funcs.WriteString("}\n\n")
+	return w.corner
+}
+
+// permutation emits the permutation table of a simplex generator
+func (w *shaderWriter) permutation(s *Simplex) string {
+	values := make([]string, len(s.perm))
+	for i, v := range s.perm {
+		values[i] = strconv.Itoa(int(v))
+	}
+
+	name := fmt.Sprintf("%s_perm%d", w.name, len(w.perms))
+	w.array("int", name, values)
+	return name
+}
+
+// fbm emits the fractal Brownian motion function of a generator
+func (w *shaderWriter) fbm(f *FBM) (string, error) {
+	if f.Octaves <= 0 {
+		return w.emit("return 0.0;"), nil
+	}
+
+	basis, err := w.node(f.basis)
+	if err != nil {
+		return "", err
+	}
+
+	loop := "for (int o = 0; o < " + strconv.Itoa(f.Octaves) + "; o++) {"
+	if w.lang == WGSL {
+		loop = "for (var o = 0; o < " + strconv.Itoa(f.Octaves) + "; o++) {"
+	}
+
+	return w.emit(
+		w.mut("float", "sum", "0.0"),
+		w.mut("float", "amp", "1.0"),
+		w.mut("float", "freq", "1.0"),
+		w.mut("float", "total", "0.0"),
+		loop,
+		"\tsum += amp * "+basis+"(p * freq);",
+		"\ttotal += amp;",
+		"\tfreq *= "+w.float(f.Lacunarity)+";",
+		"\tamp *= "+w.float(f.Gain)+";",
+		"}",
+		"return "+w.ternary("total > 0.0", "sum / total", "0.0")+";",
+	), nil
+}
+
+// combiner emits the function merging two sources with an operator
+func (w *shaderWriter) combiner(c *combiner) (string, error) {
+	return w.selector(c.a, c.b, nil, func(a, b, _ string) string {
+		switch c.op {
+		case opMultiply:
+			return a + " * " + b
+		case opMin:
+			return "min(" + a + ", " + b + ")"
+		case opMax:
+			return "max(" + a + ", " + b + ")"
+		case opPower:
+			return "pow(" + a + ", " + b + ")"
+		default:
+			return a + " + " + b
+		}
+	})
+}
+
+// selector emits a function over the values of two sources and an optional control
+func (w *shaderWriter) selector(a, b, control Source, expr func(a, b, c string) string) (string, error) {
+	var names [3]string
+	for i, src := range []Source{a, b, control} {
+		if src == nil {
+			continue
+		}
+
+		name, err := w.node(src)
+		if err != nil {
+			return "", err
+		}
+		names[i] = name + "(p)"
+	}
+
+	return w.emit("return " + expr(names[0], names[1], names[2]) + ";"), nil
+}
+
+// transform emits a function evaluating a source at transformed coordinates
+func (w *shaderWriter) transform(src Source, coords string) (string, error) {
+	in, err := w.node(src)
+	if err != nil {
+		return "", err
+	}
+	return w.emit("return " + in + "(" + coords + ");"), nil
+}
+
+// curve emits the function remapping a source through a spline
+func (w *shaderWriter) curve(c *curve) (string, error) {
+	in, err := w.node(c.src)
+	if err != nil {
+		return "", err
+	}
+
+	pts := c.spline.points
+	if len(pts) == 0 {
+		return w.emit("return " + in + "(p);"), nil
+	}
+
+	first, last := pts[0], pts[len(pts)-1]
+	lines := []string{
+		w.let("float", "v", in+"(p)"),
+		fmt.Sprintf("if (v <= %s) { return %s + %s * (v - %s); }", w.float(first.x), w.float(first.y), w.float(first.slope), w.float(first.x)),
+	}
+
+	for i := 1; i < len(pts); i++ {
+		p0, p1 := pts[i-1], pts[i]
+		h, dy := p1.x-p0.x, p1.y-p0.y
+		a := float32(p0.slope*h) - dy
+		b := dy - float32(p1.slope*h)
+		t := "(v - " + w.float(p0.x) + ") / " + w.float(h)
+		lines = append(lines, fmt.Sprintf("if (v < %s) { %s return %s + t * (1.0 - t) * %s; }",
+			w.float(p1.x), w.let("float", "t", t),
+			w.mix(w.float(p0.y), w.float(p1.y), "t"), w.mix(w.float(a), w.float(b), "t")))
+	}
+
+	lines = append(lines, fmt.Sprintf("return %s + %s * (v - %s);", w.float(last.y), w.float(last.slope), w.float(last.x)))
+	return w.emit(lines...), nil
+}
+
+// ---------------------------------- Shader Syntax ----------------------------------
+
+// emit writes a new node function with the given body and returns its name
+func (w *shaderWriter) emit(body ...string) string {
+	name := fmt.Sprintf("%s_%d", w.name, w.count)
+	w.count++
+	w.function(name, body...)
+	return name
+}
+
+// function writes a function taking a 2D point p and returning a float
+func (w *shaderWriter) function(name string, body ...string) {
+	switch w.lang {
+	case WGSL:
+		fmt.Fprintf(&w.funcs, "fn %s(p: vec2<f32>) -> f32 {\n", name)
+	default:
+		fmt.Fprintf(&w.funcs, "float %s(%s p) {\n", name, w.typ("vec2"))
+	}
+
+	for _, line := range body {
+		w.funcs.WriteString("\t" + line + "\n")
+	}
+	w.funcs.WriteString("}\n\n")
+}
+
+// array writes a constant array of int or float values
+func (w *shaderWriter) array(typ, name string, values []string) {
+	var list strings.Builder
+	for i, v := range values {
+		switch {
+		case i == 0:
+		case i%16 == 0:
+			list.WriteString(",\n\t")
+		default:
+			list.WriteString(", ")
+		}
+		list.WriteString(v)
+	}
+
+	n, t := len(values), w.typ(typ)
+	switch w.lang {
+	case GLSL:
+		fmt.Fprintf(&w.tables, "const %s %s[%d] = %s[%d](\n\t%s);\n\n", t, name, n, t, n, list.String())
+	case HLSL:
+		fmt.Fprintf(&w.tables, "static const %s %s[%d] = {\n\t%s};\n\n", t, name, n, list.String())
+	case WGSL:
+		fmt.Fprintf(&w.tables, "var<private> %s: array<%s, %d> = array<%s, %d>(\n\t%s);\n\n", name, t, n, t, n, list.String())
+	}
+}
+
+// typ returns the name of a type ("int", "float" or "vec2") in the target language
+func (w *shaderWriter) typ(t string) string {
+	switch {
+	case w.lang == HLSL && t == "vec2":
+		return "float2"
+	case w.lang == WGSL && t == "vec2":
+		return "vec2<f32>"
+	case w.lang == WGSL && t == "float":
+		return "f32"
+	case w.lang == WGSL && t == "int":
+		return "i32"
+	default:
+		return t
+	}
+}
+
+// let declares an immutable local variable
+func (w *shaderWriter) let(typ, name, expr string) string {
+	if w.lang == WGSL {
+		return "let " + name + ": " + w.typ(typ) + " = " + expr + ";"
+	}
+	return w.typ(typ) + " " + name + " = " + expr + ";"
+}
+
+// mut declares a mutable local variable
+func (w *shaderWriter) mut(typ, name, expr string) string {
+	if w.lang == WGSL {
+		return "var " + name + ": " + w.typ(typ) + " = " + expr + ";"
+	}
+	return w.typ(typ) + " " + name + " = " + expr + ";"
+}
+
+// cast converts an expression to int or float
+func (w *shaderWriter) cast(typ, expr string) string {
+	return w.typ(typ) + "(" + expr + ")"
+}
+
+// vec2 constructs a 2D vector
+func (w *shaderWriter) vec2(x, y string) string {
+	return w.typ("vec2") + "(" + x + ", " + y + ")"
+}
+
+// mix linearly interpolates between a and b
+func (w *shaderWriter) mix(a, b, t string) string {
+	if w.lang == HLSL {
+		return "lerp(" + a + ", " + b + ", " + t + ")"
+	}
+	return "mix(" + a + ", " + b + ", " + t + ")"
+}
+
+// ternary selects a if the condition holds, b otherwise
+func (w *shaderWriter) ternary(cond, a, b string) string {
+	if w.lang == WGSL {
+		return "select(" + b + ", " + a + ", " + cond + ")"
+	}
+	return "(" + cond + " ? " + a + " : " + b + ")"
+}
+
+// float formats a float literal, parenthesized when negative
+func (w *shaderWriter) float(v float32) string {
+	s := strconv.FormatFloat(float64(v), 'g', -1, 32)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	if v < 0 {
+		s = "(" + s + ")"
+	}
+	return s
+}
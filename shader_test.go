@@ -0,0 +1,585 @@
+package noise
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportShader(t *testing.T) {
+	continents := Cache(NewFBM(1), 1)
+	mountains := NewFBMWith(Ridged(NewSimplex(2)))
+	terrain := Curve(
+		Select(Multiply(NewFBM(1), Constant(0.2)), mountains, continents, 0.3, 0.1),
+		NewSpline().Point(-1, 0, 0).Point(1, 1, 0),
+	)
+
+	for _, tc := range []struct {
+		lang   ShaderLanguage
+		expect []string
+	}{
+		{GLSL, []string{"float terrain(vec2 p) {", "const int terrain_perm0[512] = int[512](", "mix(", "smoothstep("}},
+		{HLSL, []string{"float terrain(float2 p) {", "static const int terrain_perm0[512] = {", "lerp("}},
+		{WGSL, []string{"fn terrain(p: vec2<f32>) -> f32 {", "var<private> terrain_perm0: array<i32, 512>", "select("}},
+	} {
+		code, err := ExportShader(terrain, tc.lang, "terrain")
+		assert.NoError(t, err)
+		for _, s := range tc.expect {
+			assert.Contains(t, code, s, tc.lang.String())
+		}
+
+		// Both FBMs with seed 1 share the same simplex function
+		assert.Contains(t, code, "terrain_perm1")
+		assert.NotContains(t, code, "terrain_perm2")
+		assert.Equal(t, 2, strings.Count(code, "(p.x + p.y) * 0.36602542"))
+
+		// Output is deterministic
+		again, _ := ExportShader(terrain, tc.lang, "terrain")
+		assert.Equal(t, code, again)
+		assert.Equal(t, strings.Count(code, "{"), strings.Count(code, "}"))
+	}
+}
+
+func TestExportShaderTables(t *testing.T) {
+	s := NewSimplex(42)
+	code, err := ExportShader(s, GLSL, "n")
+	assert.NoError(t, err)
+	assert.Contains(t, code, "const float n_gx[12] = float[12](\n\t1.0, (-1.0), 1.0, (-1.0), 1.0, (-1.0), 1.0, (-1.0), 0.0, 0.0, 0.0, 0.0);")
+
+	var head []string
+	for _, v := range s.perm[:16] {
+		head = append(head, strconv.Itoa(int(v)))
+	}
+	assert.Contains(t, code, "const int n_perm0[512] = int[512](\n\t"+strings.Join(head, ", ")+",\n")
+
+	// Versions of the same seed have their own tables
+	code, err = ExportShader(Add(NewSimplexVersion(7, V1), NewSimplexVersion(7, V2)), GLSL, "n")
+	assert.NoError(t, err)
+	assert.Contains(t, code, "n_perm1")
+
+	code, err = ExportShader(Add(NewSimplexVersion(7, V1), NewSimplexVersion(7, V1)), GLSL, "n")
+	assert.NoError(t, err)
+	assert.NotContains(t, code, "n_perm1")
+}
+
+func TestExportShaderErrors(t *testing.T) {
+	_, err := ExportShader(NewValue(1), GLSL, "n")
+	assert.Error(t, err)
+
+	_, err = ExportShader(Add(NewSimplex(1), NewCellular(1)), WGSL, "n")
+	assert.Error(t, err)
+
+	_, err = ExportShader(NewSimplex(1), ShaderLanguage(99), "n")
+	assert.Error(t, err)
+	assert.Equal(t, "ShaderLanguage(99)", ShaderLanguage(99).String())
+}
+
+func TestExportShaderParity(t *testing.T) {
+	continents := Cache(NewFBM(1), 1)
+	mountains := NewFBMWith(Ridged(NewSimplex(2)))
+	terrain := Curve(
+		Select(Multiply(NewFBM(1), Constant(0.2)), mountains, continents, 0.3, 0.1),
+		NewSpline().Point(-1, 0, 0).Point(0, 0.2, 0.5).Point(1, 1, 0),
+	)
+
+	for _, src := range []Source{
+		NewSimplex(42),
+		NewFBM(7),
+		Ridged(NewSimplex(3)),
+		Blend(NewSimplex(4), Scale(NewSimplex(5), 2, 3, 1), Max(NewSimplex(6), Constant(-0.5))),
+		Rotate(Translate(Min(NewSimplex(8), Power(Constant(0.5), Constant(2))), 1, 2, 0), 0, 0, 30),
+		Select(NewSimplex(9), NewSimplex(10), NewSimplex(11), 0.1, 0),
+		terrain,
+	} {
+		code, err := ExportShader(src, GLSL, "n")
+		assert.NoError(t, err)
+
+		shader, err := parseGLSL(code)
+		assert.NoError(t, err)
+		for i := 0; i < 64; i++ {
+			x, y := float32(i%8)*3.7-13.1, float32(i/8)*2.3-7.9
+			assert.InDelta(t, src.Eval2(x, y), shader.eval("n", x, y), 1e-4, "%T at (%v, %v)", src, x, y)
+		}
+	}
+}
+
+// ---------------------------------- GLSL Interpreter ----------------------------------
+
+// glslValue is a value of an int, bool, float or vec2 GLSL expression
+type glslValue struct {
+	kind byte // 'i'nt, 'b'ool, 'f'loat or 'v'ec2
+	i    int
+	f    [2]float32
+}
+
+// glslExpr and glslStmt are compiled expressions and statements, where a statement
+// reports whether it returned from the function
+type glslExpr func(env map[string]glslValue) glslValue
+type glslStmt func(env map[string]glslValue) (glslValue, bool)
+
+// glslFunc is a compiled function
+type glslFunc struct {
+	params []string
+	body   []glslStmt
+}
+
+// glslShader interprets the subset of GLSL produced by ExportShader on the CPU, in
+// single precision, to check that the generated code reproduces the pipeline.
+type glslShader struct {
+	toks   []string
+	pos    int
+	funcs  map[string]*glslFunc
+	arrays map[string][]glslValue
+}
+
+// parseGLSL compiles the constant tables and functions of a shader
+func parseGLSL(code string) (sh *glslShader, err error) {
+	sh = &glslShader{toks: glslTokens(code), funcs: make(map[string]*glslFunc), arrays: make(map[string][]glslValue)}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("glsl: %v at token %d", r, sh.pos)
+		}
+	}()
+
+	for sh.pos < len(sh.toks) {
+		if sh.peek() == "const" {
+			sh.table()
+			continue
+		}
+
+		sh.next() // return type
+		name, fn := sh.next(), &glslFunc{}
+		sh.expect("(")
+		for sh.peek() != ")" {
+			sh.next() // parameter type
+			fn.params = append(fn.params, sh.next())
+			if sh.peek() == "," {
+				sh.next()
+			}
+		}
+		sh.expect(")")
+		sh.expect("{")
+		fn.body = sh.block()
+		sh.funcs[name] = fn
+	}
+	return sh, nil
+}
+
+// eval calls a function of the shader with a 2D point
+func (sh *glslShader) eval(name string, x, y float32) float32 {
+	return sh.call(name, []glslValue{{kind: 'v', f: [2]float32{x, y}}}).f[0]
+}
+
+// call runs a function of the shader
+func (sh *glslShader) call(name string, args []glslValue) glslValue {
+	fn := sh.funcs[name]
+	env := make(map[string]glslValue, 8)
+	for i, p := range fn.params {
+		env[p] = args[i]
+	}
+
+	v, _ := glslRun(fn.body, env)
+	return v
+}
+
+// table parses a constant array declaration
+func (sh *glslShader) table() {
+	sh.expect("const")
+	sh.next()
+	name := sh.next()
+	for sh.peek() != "(" {
+		sh.next() // [N] = type[N]
+	}
+
+	for _, e := range sh.args() {
+		sh.arrays[name] = append(sh.arrays[name], e(nil))
+	}
+	sh.expect(";")
+}
+
+// block parses statements up to the closing brace
+func (sh *glslShader) block() (body []glslStmt) {
+	for sh.peek() != "}" {
+		body = append(body, sh.statement())
+	}
+	sh.expect("}")
+	return body
+}
+
+// statement parses a single statement
+func (sh *glslShader) statement() glslStmt {
+	switch sh.peek() {
+	case "return":
+		sh.next()
+		e := sh.expr()
+		sh.expect(";")
+		return func(env map[string]glslValue) (glslValue, bool) {
+			return e(env), true
+		}
+	case "if":
+		sh.next()
+		sh.expect("(")
+		cond := sh.expr()
+		sh.expect(")")
+		sh.expect("{")
+		body := sh.block()
+		return func(env map[string]glslValue) (glslValue, bool) {
+			if cond(env).i != 0 {
+				return glslRun(body, env)
+			}
+			return glslValue{}, false
+		}
+	case "for":
+		sh.next()
+		sh.expect("(")
+		init := sh.statement()
+		cond := sh.expr()
+		sh.expect(";")
+		name := sh.next()
+		sh.expect("++")
+		sh.expect(")")
+		sh.expect("{")
+		body := sh.block()
+		return func(env map[string]glslValue) (glslValue, bool) {
+			for init(env); cond(env).i != 0; {
+				if v, ok := glslRun(body, env); ok {
+					return v, true
+				}
+				env[name] = glslValue{kind: 'i', i: env[name].i + 1}
+			}
+			return glslValue{}, false
+		}
+	case "int", "float", "vec2":
+		sh.next()
+		fallthrough
+	default:
+		name, op := sh.next(), sh.next()
+		e := sh.expr()
+		sh.expect(";")
+		return func(env map[string]glslValue) (glslValue, bool) {
+			switch op {
+			case "=":
+				env[name] = e(env)
+			default:
+				env[name] = glslBinary(op[:1], env[name], e(env))
+			}
+			return glslValue{}, false
+		}
+	}
+}
+
+// glslPrecedence lists the binary operators from the lowest to the highest precedence
+var glslPrecedence = [][]string{{"&"}, {"==", "!="}, {"<", ">", "<=", ">="}, {"+", "-"}, {"*", "/", "%"}}
+
+// expr parses an expression, including the conditional operator
+func (sh *glslShader) expr() glslExpr {
+	cond := sh.binary(0)
+	if sh.peek() != "?" {
+		return cond
+	}
+
+	sh.next()
+	a := sh.expr()
+	sh.expect(":")
+	b := sh.expr()
+	return func(env map[string]glslValue) glslValue {
+		if cond(env).i != 0 {
+			return a(env)
+		}
+		return b(env)
+	}
+}
+
+// binary parses left-associative binary operators of a precedence level and above
+func (sh *glslShader) binary(level int) glslExpr {
+	if level == len(glslPrecedence) {
+		return sh.unary()
+	}
+
+	lhs := sh.binary(level + 1)
+	for slices.Contains(glslPrecedence[level], sh.peek()) {
+		op, a, b := sh.next(), lhs, sh.binary(level+1)
+		lhs = func(env map[string]glslValue) glslValue {
+			return glslBinary(op, a(env), b(env))
+		}
+	}
+	return lhs
+}
+
+// unary parses negations, primary expressions and swizzles
+func (sh *glslShader) unary() glslExpr {
+	if sh.peek() == "-" {
+		sh.next()
+		e := sh.unary()
+		return func(env map[string]glslValue) glslValue {
+			return glslBinary("-", glslValue{kind: 'i'}, e(env))
+		}
+	}
+
+	e := sh.primary()
+	for sh.peek() == "." {
+		sh.next()
+		c, v := strings.Index("xy", sh.next()), e
+		e = func(env map[string]glslValue) glslValue {
+			return glslValue{kind: 'f', f: [2]float32{v(env).f[c]}}
+		}
+	}
+	return e
+}
+
+// primary parses literals, parentheses, calls, array lookups and variables
+func (sh *glslShader) primary() glslExpr {
+	tok := sh.next()
+	switch {
+	case tok == "(":
+		e := sh.expr()
+		sh.expect(")")
+		return e
+	case unicode.IsDigit(rune(tok[0])):
+		v := glslValue{kind: 'i'}
+		if strings.ContainsAny(tok, ".e") {
+			f, _ := strconv.ParseFloat(tok, 32)
+			v = glslValue{kind: 'f', f: [2]float32{float32(f)}}
+		} else {
+			v.i, _ = strconv.Atoi(tok)
+		}
+		return func(map[string]glslValue) glslValue { return v }
+	case sh.peek() == "(":
+		args := sh.args()
+		return func(env map[string]glslValue) glslValue {
+			values := make([]glslValue, len(args))
+			for i, a := range args {
+				values[i] = a(env)
+			}
+			if fn, ok := glslBuiltins[tok]; ok {
+				return fn(values)
+			}
+			return sh.call(tok, values)
+		}
+	case sh.peek() == "[":
+		sh.next()
+		idx := sh.expr()
+		sh.expect("]")
+		return func(env map[string]glslValue) glslValue {
+			return sh.arrays[tok][idx(env).i]
+		}
+	default:
+		return func(env map[string]glslValue) glslValue {
+			return env[tok]
+		}
+	}
+}
+
+// args parses a parenthesized, comma-separated list of expressions
+func (sh *glslShader) args() (args []glslExpr) {
+	sh.expect("(")
+	for sh.peek() != ")" {
+		args = append(args, sh.expr())
+		if sh.peek() == "," {
+			sh.next()
+		}
+	}
+	sh.expect(")")
+	return args
+}
+
+func (sh *glslShader) peek() string {
+	if sh.pos < len(sh.toks) {
+		return sh.toks[sh.pos]
+	}
+	return ""
+}
+
+func (sh *glslShader) next() string {
+	tok := sh.peek()
+	sh.pos++
+	return tok
+}
+
+func (sh *glslShader) expect(tok string) {
+	if got := sh.next(); got != tok {
+		panic(fmt.Sprintf("expected %q, got %q", tok, got))
+	}
+}
+
+// glslRun runs statements until one of them returns
+func glslRun(body []glslStmt, env map[string]glslValue) (glslValue, bool) {
+	for _, s := range body {
+		if v, ok := s(env); ok {
+			return v, true
+		}
+	}
+	return glslValue{}, false
+}
+
+// glslTokens splits the source code into tokens, dropping comments
+func glslTokens(code string) (toks []string) {
+	for i := 0; i < len(code); {
+		c, n := rune(code[i]), 1
+		switch {
+		case unicode.IsSpace(c):
+			i++
+			continue
+		case strings.HasPrefix(code[i:], "//"):
+			for i < len(code) && code[i] != '\n' {
+				i++
+			}
+			continue
+		case unicode.IsLetter(c) || c == '_':
+			for i+n < len(code) && (unicode.IsLetter(rune(code[i+n])) || unicode.IsDigit(rune(code[i+n])) || code[i+n] == '_') {
+				n++
+			}
+		case unicode.IsDigit(c):
+			for i+n < len(code) && strings.ContainsRune("0123456789.e", rune(code[i+n])) {
+				if code[i+n] == 'e' && strings.ContainsRune("+-", rune(code[i+n+1])) {
+					n++
+				}
+				n++
+			}
+		case len(code) > i+1 && slices.Contains([]string{"+=", "*=", "++", "<=", ">=", "==", "!="}, code[i:i+2]):
+			n = 2
+		}
+
+		toks = append(toks, code[i:i+n])
+		i += n
+	}
+	return toks
+}
+
+// glslBinary applies a binary operator, on integers or on floats and vectors
+func glslBinary(op string, a, b glslValue) glslValue {
+	if a.kind == 'i' && b.kind == 'i' {
+		switch op {
+		case "+":
+			return glslValue{kind: 'i', i: a.i + b.i}
+		case "-":
+			return glslValue{kind: 'i', i: a.i - b.i}
+		case "*":
+			return glslValue{kind: 'i', i: a.i * b.i}
+		case "/":
+			return glslValue{kind: 'i', i: a.i / b.i}
+		case "%":
+			return glslValue{kind: 'i', i: a.i % b.i}
+		case "&":
+			return glslValue{kind: 'i', i: a.i & b.i}
+		}
+	}
+
+	a, b = glslFloat(a), glslFloat(b)
+	switch op {
+	case "<":
+		return glslBool(a.f[0] < b.f[0])
+	case ">":
+		return glslBool(a.f[0] > b.f[0])
+	case "<=":
+		return glslBool(a.f[0] <= b.f[0])
+	case ">=":
+		return glslBool(a.f[0] >= b.f[0])
+	case "==":
+		return glslBool(a.f[0] == b.f[0])
+	case "!=":
+		return glslBool(a.f[0] != b.f[0])
+	}
+
+	out := glslValue{kind: 'f'}
+	if a.kind == 'v' || b.kind == 'v' {
+		out.kind = 'v'
+	}
+
+	for c := range out.f {
+		x, y := a.f[min(c, glslWidth(a)-1)], b.f[min(c, glslWidth(b)-1)]
+		switch op {
+		case "+":
+			out.f[c] = x + y
+		case "-":
+			out.f[c] = x - y
+		case "*":
+			out.f[c] = float32(x * y)
+		case "/":
+			out.f[c] = x / y
+		}
+	}
+	return out
+}
+
+// glslBuiltins are the built-in functions and constructors used by generated code
+var glslBuiltins = map[string]func(args []glslValue) glslValue{
+	"int": func(args []glslValue) glslValue {
+		if args[0].kind == 'i' {
+			return args[0]
+		}
+		return glslValue{kind: 'i', i: int(args[0].f[0])}
+	},
+	"float": func(args []glslValue) glslValue {
+		return glslFloat(args[0])
+	},
+	"vec2": func(args []glslValue) glslValue {
+		return glslValue{kind: 'v', f: [2]float32{glslFloat(args[0]).f[0], glslFloat(args[1]).f[0]}}
+	},
+	"floor": func(args []glslValue) glslValue {
+		return glslScalar(float32(math.Floor(float64(glslFloat(args[0]).f[0]))))
+	},
+	"abs": func(args []glslValue) glslValue {
+		return glslScalar(float32(math.Abs(float64(glslFloat(args[0]).f[0]))))
+	},
+	"pow": func(args []glslValue) glslValue {
+		return glslScalar(float32(math.Pow(float64(glslFloat(args[0]).f[0]), float64(glslFloat(args[1]).f[0]))))
+	},
+	"min": func(args []glslValue) glslValue {
+		return glslScalar(min(glslFloat(args[0]).f[0], glslFloat(args[1]).f[0]))
+	},
+	"max": func(args []glslValue) glslValue {
+		return glslScalar(max(glslFloat(args[0]).f[0], glslFloat(args[1]).f[0]))
+	},
+	"dot": func(args []glslValue) glslValue {
+		a, b := args[0].f, args[1].f
+		return glslScalar(float32(a[0]*b[0]) + float32(a[1]*b[1]))
+	},
+	"mix": func(args []glslValue) glslValue {
+		return glslBinary("+", args[0], glslBinary("*", glslBinary("-", args[1], args[0]), args[2]))
+	},
+	"step": func(args []glslValue) glslValue {
+		if glslFloat(args[1]).f[0] < glslFloat(args[0]).f[0] {
+			return glslScalar(0)
+		}
+		return glslScalar(1)
+	},
+	"smoothstep": func(args []glslValue) glslValue {
+		e0, e1, x := glslFloat(args[0]).f[0], glslFloat(args[1]).f[0], glslFloat(args[2]).f[0]
+		t := min(max((x-e0)/(e1-e0), 0), 1)
+		return glslScalar(float32(t*t) * (3 - float32(2*t)))
+	},
+}
+
+func glslScalar(v float32) glslValue {
+	return glslValue{kind: 'f', f: [2]float32{v}}
+}
+
+func glslBool(v bool) glslValue {
+	if v {
+		return glslValue{kind: 'b', i: 1}
+	}
+	return glslValue{kind: 'b'}
+}
+
+// glslFloat converts an integer to a float, leaving floats and vectors as they are
+func glslFloat(v glslValue) glslValue {
+	if v.kind == 'i' {
+		return glslScalar(float32(v.i))
+	}
+	return v
+}
+
+// glslWidth returns the number of components of a value
+func glslWidth(v glslValue) int {
+	if v.kind == 'v' {
+		return 2
+	}
+	return 1
+}
@@ -0,0 +1,50 @@
+package noise
+
+// ---------------------------------- Screen Shake ----------------------------------
+
+// Shake produces trauma-based screen shake: smooth translational and rotational
+// offsets whose strength grows with the square of the trauma, so small hits barely
+// register while big ones shake hard. Each offset comes from its own 1D simplex
+// channel, so the three are decorrelated. The exported fields may be tuned after
+// NewShake.
+type Shake struct {
+	MaxOffset float32 // Offset at full trauma, in screen units, default 10
+	MaxAngle  float32 // Rotation at full trauma, in radians, default 0.1
+	Frequency float32 // Number of shakes per unit of time, default 15
+	channels  [3]*Simplex
+}
+
+// NewShake creates a screen shake generator with the given seed
+//
+// Example:
+//
+//	shake := NewShake(12345)
+//	trauma = max(trauma-dt, 0) // decay over time, add to it on impact
+//	dx, dy, angle := shake.At(trauma, elapsed)
+func NewShake(seed uint32) *Shake {
+	return &Shake{
+		MaxOffset: 10,
+		MaxAngle:  0.1,
+		Frequency: 15,
+		channels: [3]*Simplex{
+			NewSimplex(component(seed, 0)),
+			NewSimplex(component(seed, 1)),
+			NewSimplex(component(seed, 2)),
+		},
+	}
+}
+
+// At returns the camera offsets for a trauma in [0, 1] at time t. The trauma is
+// clamped to that range and a trauma of 0 returns no offset.
+func (s *Shake) At(trauma, t float32) (dx, dy, dAngle float32) {
+	trauma = min(max(trauma, 0), 1)
+	if trauma == 0 {
+		return 0, 0, 0
+	}
+
+	k, x := trauma*trauma, t*s.Frequency
+	dx = float32(s.MaxOffset*k) * s.channels[0].noise1D(x)
+	dy = float32(s.MaxOffset*k) * s.channels[1].noise1D(x)
+	dAngle = float32(s.MaxAngle*k) * s.channels[2].noise1D(x)
+	return
+}
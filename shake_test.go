@@ -0,0 +1,38 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShake(t *testing.T) {
+	s := NewShake(42)
+	dx, dy, da := s.At(0, 1.23)
+	assert.Equal(t, [3]float32{}, [3]float32{dx, dy, da})
+
+	var maxX, maxA float32
+	var same int
+	for i := 0; i < 1000; i++ {
+		dx, dy, da := s.At(1, float32(i)*0.01)
+		assert.True(t, abs(dx) <= 10 && abs(dy) <= 10 && abs(da) <= 0.1)
+		if dx == dy {
+			same++
+		}
+		maxX, maxA = max(maxX, abs(dx)), max(maxA, abs(da))
+	}
+	assert.Less(t, same, 50)
+	assert.Greater(t, maxX, float32(5))
+	assert.Greater(t, maxA, float32(0.05))
+
+	// Trauma scales quadratically and is clamped
+	full, _, _ := s.At(1, 0.37)
+	half, _, _ := s.At(0.5, 0.37)
+	over, _, _ := s.At(2, 0.37)
+	assert.InDelta(t, full/4, half, 1e-5)
+	assert.Equal(t, full, over)
+
+	x1, y1, a1 := s.At(0.7, 2)
+	x2, y2, a2 := NewShake(42).At(0.7, 2)
+	assert.Equal(t, [3]float32{x1, y1, a1}, [3]float32{x2, y2, a2})
+}
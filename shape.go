@@ -0,0 +1,60 @@
+package noise
+
+import "math"
+
+// ---------------------------------- Shapes ----------------------------------
+
+// UnitVec2 returns a deterministic 2D unit vector with a uniformly distributed
+// direction based on x
+func UnitVec2(seed uint32, x uint64) [2]float32 {
+	sin, cos := math.Sincos(2 * math.Pi * Float64(seed, x))
+	return [2]float32{float32(cos), float32(sin)}
+}
+
+// UnitVec3 returns a deterministic 3D unit vector with a uniformly distributed
+// direction based on x. Method: Archimedes' theorem, a uniform height on the
+// axis maps to a uniform area on the sphere.
+func UnitVec3(seed uint32, x uint64) [3]float32 {
+	z := 2*Float64(seed, x) - 1
+	r := math.Sqrt(1 - float64(z*z))
+	sin, cos := math.Sincos(2 * math.Pi * Float64(seed^1, x))
+	return [3]float32{float32(r * cos), float32(r * sin), float32(z)}
+}
+
+// InDisk returns a deterministic point uniformly distributed inside the disk of
+// the given radius centered on the origin, based on x
+func InDisk(seed uint32, radius float32, x uint64) [2]float32 {
+	r := float64(radius) * math.Sqrt(Float64(seed^2, x))
+	v := UnitVec2(seed, x)
+	return [2]float32{float32(r * float64(v[0])), float32(r * float64(v[1]))}
+}
+
+// OnSphere returns a deterministic point uniformly distributed on the surface of
+// the sphere of the given radius centered on the origin, based on x
+func OnSphere(seed uint32, radius float32, x uint64) [3]float32 {
+	v := UnitVec3(seed, x)
+	return [3]float32{v[0] * radius, v[1] * radius, v[2] * radius}
+}
+
+// InSphere returns a deterministic point uniformly distributed inside the ball of
+// the given radius centered on the origin, based on x
+func InSphere(seed uint32, radius float32, x uint64) [3]float32 {
+	r := float64(radius) * math.Cbrt(Float64(seed^2, x))
+	v := UnitVec3(seed, x)
+	return [3]float32{float32(r * float64(v[0])), float32(r * float64(v[1])), float32(r * float64(v[2]))}
+}
+
+// InTriangle returns a deterministic point uniformly distributed inside the
+// triangle abc, based on x. Method: a uniform point in the parallelogram spanned
+// by the edges is folded back into the triangle when it falls outside.
+func InTriangle(seed uint32, a, b, c [2]float32, x uint64) [2]float32 {
+	u, v := Float32(seed, x), Float32(seed^1, x)
+	if u+v > 1 {
+		u, v = 1-u, 1-v
+	}
+
+	return [2]float32{
+		a[0] + float32(u*(b[0]-a[0])) + float32(v*(c[0]-a[0])),
+		a[1] + float32(u*(b[1]-a[1])) + float32(v*(c[1]-a[1])),
+	}
+}
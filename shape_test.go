@@ -0,0 +1,80 @@
+package noise
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitVec(t *testing.T) {
+	var sum2 [2]float64
+	var sum3 [3]float64
+	for x := uint64(0); x < 10000; x++ {
+		v2, v3 := UnitVec2(42, x), UnitVec3(42, x)
+		assert.InDelta(t, 1, math.Hypot(float64(v2[0]), float64(v2[1])), 1e-6)
+		assert.InDelta(t, 1, length3(v3), 1e-6)
+		for i := range v2 {
+			sum2[i] += float64(v2[i])
+		}
+		for i := range v3 {
+			sum3[i] += float64(v3[i])
+		}
+	}
+
+	// Directions are uniform, so they cancel out on average
+	for _, s := range append(sum2[:], sum3[:]...) {
+		assert.InDelta(t, 0, s/10000, 0.03)
+	}
+}
+
+func TestInDisk(t *testing.T) {
+	var inner int
+	for x := uint64(0); x < 10000; x++ {
+		p := InDisk(42, 2, x)
+		r := math.Hypot(float64(p[0]), float64(p[1]))
+		assert.LessOrEqual(t, r, 2.0)
+		if r < 1 {
+			inner++
+		}
+	}
+
+	// A quarter of the area is within half the radius
+	assert.InDelta(t, 2500, inner, 150)
+}
+
+func TestSphere(t *testing.T) {
+	var inner int
+	for x := uint64(0); x < 10000; x++ {
+		assert.InDelta(t, 3, length3(OnSphere(42, 3, x)), 1e-5)
+
+		r := length3(InSphere(42, 2, x))
+		assert.LessOrEqual(t, r, 2.0+1e-6)
+		if r < 1 {
+			inner++
+		}
+	}
+
+	// An eighth of the volume is within half the radius
+	assert.InDelta(t, 1250, inner, 120)
+}
+
+func TestInTriangle(t *testing.T) {
+	a, b, c := [2]float32{0, 0}, [2]float32{4, 0}, [2]float32{0, 2}
+	var left int
+	for x := uint64(0); x < 10000; x++ {
+		p := InTriangle(42, a, b, c, x)
+		assert.True(t, p[0] >= 0 && p[1] >= 0 && p[0]/4+p[1]/2 <= 1+1e-6, "outside: %v", p)
+		if p[0] < 2 {
+			left++
+		}
+	}
+
+	// The left half of the base holds three quarters of the area
+	assert.InDelta(t, 7500, left, 150)
+}
+
+// length3 returns the length of a 3D vector
+func length3(v [3]float32) float64 {
+	return math.Sqrt(float64(v[0]*v[0]) + float64(v[1]*v[1]) + float64(v[2]*v[2]))
+}
@@ -26,16 +26,46 @@ var table = [...]uint8{151, 160, 137, 91, 90, 15,
 
 // ---------------------------------- Simplex Noise ----------------------------------
 
-// Simplex represents a simplex noise generator with its own permutation table
+// grad2, grad3 map a permutation value to its gradient vector. They are shared by
+// all generators, so that each Simplex only carries its 512-byte permutation table.
+var grad2, grad3 = gradients()
+
+// gradients builds the shared lookup tables of 2D and 3D gradients
+func gradients() (out2 [256][2]float32, out3 [256][3]float32) {
+	var g2d = [12]uint16{
+		0x0101, 0xff01, 0x01ff, 0xffff, // diagonal gradients
+		0x0100, 0xff00, 0x0100, 0xff00, // horizontal gradients
+		0x0001, 0x00ff, 0x0001, 0x00ff, // vertical gradients
+	}
+
+	var g3d = [12][3]float32{
+		{1, 1, 0}, {-1, 1, 0}, {1, -1, 0}, {-1, -1, 0},
+		{1, 0, 1}, {-1, 0, 1}, {1, 0, -1}, {-1, 0, -1},
+		{0, 1, 1}, {0, -1, 1}, {0, 1, -1}, {0, -1, -1},
+	}
+
+	for i := 0; i < 256; i++ {
+		idx2 := g2d[i%12]
+		gx := int8(idx2 >> 8)
+		gy := int8(idx2)
+		out2[i] = [2]float32{float32(gx), float32(gy)}
+		out3[i] = g3d[i%12]
+	}
+	return
+}
+
+// Simplex represents a simplex noise generator with its own permutation table. It
+// is immutable once constructed, so a single instance is safe for concurrent use.
 type Simplex struct {
-	perm  [512]uint8
-	grad2 [512][2]float32
-	grad3 [512][3]float32
+	perm    [512]uint8
+	seed    uint32
+	version uint8 // Version of the algorithms, zero being V1
 }
 
-// NewSimplex creates a new Simplex noise generator with the given seed
+// NewSimplex creates a new Simplex noise generator with the given seed. Its output
+// is frozen at V1 so existing worlds never change, see NewSimplexV2 for the latest.
 func NewSimplex(seed uint32) *Simplex {
-	s := new(Simplex)
+	s := &Simplex{seed: seed}
 	r := rand.New(rand.NewPCG(uint64(seed), 0))
 
 	// Initialize permutation table with Fisher-Yates shuffle
@@ -50,32 +80,16 @@ func NewSimplex(seed uint32) *Simplex {
 	for i := 0; i < 256; i++ {
 		s.perm[i+256] = s.perm[i]
 	}
-
-	// Initialize gradient tables
-	var g2d = [12]uint16{
-		0x0101, 0xff01, 0x01ff, 0xffff, // diagonal gradients
-		0x0100, 0xff00, 0x0100, 0xff00, // horizontal gradients
-		0x0001, 0x00ff, 0x0001, 0x00ff, // vertical gradients
-	}
-
-	var g3d = [12][3]float32{
-		{1, 1, 0}, {-1, 1, 0}, {1, -1, 0}, {-1, -1, 0},
-		{1, 0, 1}, {-1, 0, 1}, {1, 0, -1}, {-1, 0, -1},
-		{0, 1, 1}, {0, -1, 1}, {0, 1, -1}, {0, -1, -1},
-	}
-
-	for i := 0; i < 512; i++ {
-		idx2 := g2d[s.perm[i&255]%12]
-		gx := int8(idx2 >> 8)
-		gy := int8(idx2)
-		s.grad2[i] = [2]float32{float32(gx), float32(gy)}
-
-		idx3 := s.perm[i&255] % 12
-		s.grad3[i] = g3d[idx3]
-	}
 	return s
 }
 
+// Clone returns an independent copy of the generator, for callers that prefer
+// per-goroutine instances (e.g. to keep the tables in a core-local cache).
+func (s *Simplex) Clone() *Simplex {
+	c := *s
+	return &c
+}
+
 // Eval evaluates simplex noise at the given coordinates
 // Supports 1D, 2D, and 3D noise based on number of arguments
 func (s *Simplex) Eval(coords ...float32) float32 {
@@ -91,6 +105,16 @@ func (s *Simplex) Eval(coords ...float32) float32 {
 	}
 }
 
+// Eval2 evaluates 2D simplex noise, implementing Source2
+func (s *Simplex) Eval2(x, y float32) float32 {
+	return s.noise2D(x, y)
+}
+
+// Eval3 evaluates 3D simplex noise, implementing Source3
+func (s *Simplex) Eval3(x, y, z float32) float32 {
+	return s.noise3D(x, y, z)
+}
+
 // noise1D computes 1D simplex noise (using 2D with y=0)
 func (s *Simplex) noise1D(x float32) float32 {
 	return s.noise2D(x, 0)
@@ -99,15 +123,20 @@ func (s *Simplex) noise1D(x float32) float32 {
 // noise2D computes 2D simplex noise using the generator's permutation table
 func (s *Simplex) noise2D(x, y float32) float32 {
 	// Skew the input space to determine which simplex cell we're in
-	sk := (x + y) * f2
+	sk := float32((x + y) * f2)
 	i := floor(x + sk)
 	j := floor(y + sk)
 
 	// Unskew the cell origin back to (x,y) space
-	t := float32(i+j) * g2
+	t := float32(float32(i+j) * g2)
 	x0 := x - (float32(i) - t)
 	y0 := y - (float32(j) - t)
+	return s.cell2D(i, j, x0, y0)
+}
 
+// cell2D sums the corner contributions of the simplex cell (i, j), given the
+// offset (x0, y0) of the sample from the cell origin in unskewed space
+func (s *Simplex) cell2D(i, j int, x0, y0 float32) float32 {
 	// For the 2D case, the simplex shape is an equilateral triangle.
 	// Determine which simplex we are in
 	i1, j1 := float32(0), float32(1) // upper triangle
@@ -126,25 +155,25 @@ func (s *Simplex) noise2D(x, y float32) float32 {
 	y2 := y0 + g
 
 	// Work out the hashed gradient indices of the three simplex corners
+	ii := i & 255
 	pp := s.perm[j&255:]
-	gg := s.grad2[i&255:]
 	p0 := int(pp[0])
 	p1 := int(pp[int(j1)])
 	p2 := int(pp[1])
-	g0 := gg[p0]
-	g1 := gg[int(i1)+p1]
-	g2 := gg[1+p2]
+	g0 := grad2[s.perm[ii+p0]]
+	g1 := grad2[s.perm[ii+int(i1)+p1]]
+	g2 := grad2[s.perm[ii+1+p2]]
 
 	// Calculate the contribution from the three corners
 	n := float32(0.0)
-	if t := 0.5 - x0*x0 - y0*y0; t > 0 {
-		n += pow4(t) * (g0[0]*x0 + g0[1]*y0)
+	if t := 0.5 - float32(x0*x0) - float32(y0*y0); t > 0 {
+		n += float32(pow4(t) * (g0[0]*x0 + g0[1]*y0))
 	}
-	if t := 0.5 - x1*x1 - y1*y1; t > 0 {
-		n += pow4(t) * (g1[0]*x1 + g1[1]*y1)
+	if t := 0.5 - float32(x1*x1) - float32(y1*y1); t > 0 {
+		n += float32(pow4(t) * (g1[0]*x1 + g1[1]*y1))
 	}
-	if t := 0.5 - x2*x2 - y2*y2; t > 0 {
-		n += pow4(t) * (g2[0]*x2 + g2[1]*y2)
+	if t := 0.5 - float32(x2*x2) - float32(y2*y2); t > 0 {
+		n += float32(pow4(t) * (g2[0]*x2 + g2[1]*y2))
 	}
 
 	// Add contributions from each corner to get the final noise value.
@@ -155,17 +184,22 @@ func (s *Simplex) noise2D(x, y float32) float32 {
 // noise3D computes 3D simplex noise using the generator's permutation table
 func (s *Simplex) noise3D(x, y, z float32) float32 {
 	// Skew the input space to determine which simplex cell we're in
-	sk := (x + y + z) * f3
+	sk := float32((x + y + z) * f3)
 	i := floor(x + sk)
 	j := floor(y + sk)
 	k := floor(z + sk)
 
 	// Unskew the cell origin back to (x,y,z) space
-	t := float32(i+j+k) * g3
+	t := float32(float32(i+j+k) * g3)
 	x0 := x - (float32(i) - t)
 	y0 := y - (float32(j) - t)
 	z0 := z - (float32(k) - t)
+	return s.cell3D(i, j, k, x0, y0, z0)
+}
 
+// cell3D sums the corner contributions of the simplex cell (i, j, k), given the
+// offset (x0, y0, z0) of the sample from the cell origin in unskewed space
+func (s *Simplex) cell3D(i, j, k int, x0, y0, z0 float32) float32 {
 	// For the 3D case, the simplex shape is a slightly irregular tetrahedron.
 	// Determine which simplex we are in.
 	var i1, j1, k1 float32 // Offsets for second corner of simplex in (i,j,k) coords
@@ -220,28 +254,28 @@ func (s *Simplex) noise3D(x, y, z float32) float32 {
 	// Calculate the contribution from the four corners
 	var n0, n1, n2, n3 float32
 
-	t0 := 0.6 - x0*x0 - y0*y0 - z0*z0
+	t0 := 0.6 - float32(x0*x0) - float32(y0*y0) - float32(z0*z0)
 	if t0 >= 0 {
-		g := s.grad3[gi0]
-		n0 = t0 * t0 * t0 * t0 * (g[0]*x0 + g[1]*y0 + g[2]*z0)
+		g := grad3[s.grad3Of(gi0)]
+		n0 = float32(t0 * t0 * t0 * t0 * (g[0]*x0 + g[1]*y0 + g[2]*z0))
 	}
 
-	t1 := 0.6 - x1*x1 - y1*y1 - z1*z1
+	t1 := 0.6 - float32(x1*x1) - float32(y1*y1) - float32(z1*z1)
 	if t1 >= 0 {
-		g := s.grad3[gi1]
-		n1 = t1 * t1 * t1 * t1 * (g[0]*x1 + g[1]*y1 + g[2]*z1)
+		g := grad3[s.grad3Of(gi1)]
+		n1 = float32(t1 * t1 * t1 * t1 * (g[0]*x1 + g[1]*y1 + g[2]*z1))
 	}
 
-	t2 := 0.6 - x2*x2 - y2*y2 - z2*z2
+	t2 := 0.6 - float32(x2*x2) - float32(y2*y2) - float32(z2*z2)
 	if t2 >= 0 {
-		g := s.grad3[gi2]
-		n2 = t2 * t2 * t2 * t2 * (g[0]*x2 + g[1]*y2 + g[2]*z2)
+		g := grad3[s.grad3Of(gi2)]
+		n2 = float32(t2 * t2 * t2 * t2 * (g[0]*x2 + g[1]*y2 + g[2]*z2))
 	}
 
-	t3 := 0.6 - x3*x3 - y3*y3 - z3*z3
+	t3 := 0.6 - float32(x3*x3) - float32(y3*y3) - float32(z3*z3)
 	if t3 >= 0 {
-		g := s.grad3[gi3]
-		n3 = t3 * t3 * t3 * t3 * (g[0]*x3 + g[1]*y3 + g[2]*z3)
+		g := grad3[s.grad3Of(gi3)]
+		n3 = float32(t3 * t3 * t3 * t3 * (g[0]*x3 + g[1]*y3 + g[2]*z3))
 	}
 
 	// Add contributions from each corner to get the final noise value.
@@ -249,6 +283,16 @@ func (s *Simplex) noise3D(x, y, z float32) float32 {
 	return 32.0 * (n0 + n1 + n2 + n3)
 }
 
+// grad3Of returns the gradient index of a hashed corner. V1 looks the hash up in the
+// permutation table once more, so a seed only reaches the gradients that happen to
+// sit at perm[0..11]; later versions use the hash directly and reach all 12.
+func (s *Simplex) grad3Of(h uint8) uint8 {
+	if Version(s.version) < V2 {
+		return s.perm[h]
+	}
+	return h
+}
+
 // pow4 lifts the value to the power of 4
 func pow4(v float32) float32 {
 	v *= v
@@ -266,16 +310,60 @@ func floor(x float32) int {
 
 // ---------------------------------- Fractal Brownian Motion ----------------------------------
 
-// FBM represents a fractal Brownian motion generator
+// FBM represents a fractal Brownian motion generator. Its parameters can be changed
+// after construction, but not while it is being evaluated; once configured, it is
+// safe for concurrent use, provided its basis is too.
 type FBM struct {
-	simplex *Simplex
+	Lacunarity float32  // Frequency multiplier between octaves, used by Eval2 and Eval3
+	Gain       float32  // Amplitude multiplier between octaves, used by Eval2 and Eval3
+	Octaves    int      // Number of octaves, used by Eval2 and Eval3
+	basis      Source   // Noise summed at every octave
+	simplex    *Simplex // Basis when it is simplex noise, to avoid dynamic dispatch
 }
 
-// NewFBM creates a new FBM generator with the given seed
+// NewFBM creates a new FBM generator over simplex noise with the given seed. When
+// used as a Source, it defaults to 4 octaves with a lacunarity of 2 and a gain of 0.5.
 func NewFBM(seed uint32) *FBM {
-	return &FBM{
-		simplex: NewSimplex(seed),
+	return NewFBMWith(NewSimplex(seed))
+}
+
+// NewFBMWith creates a new FBM generator that sums octaves of any basis noise, such
+// as Value, Cellular or a Ridged source. It uses the same defaults as NewFBM.
+//
+// Example:
+//
+//	mountains := NewFBMWith(Ridged(NewSimplex(12345)))
+//	height := mountains.Eval(2.0, 0.5, 6, x, y)
+func NewFBMWith(basis Source) *FBM {
+	f := &FBM{
+		Lacunarity: 2,
+		Gain:       0.5,
+		Octaves:    4,
+		basis:      basis,
+	}
+
+	f.simplex, _ = basis.(*Simplex)
+	return f
+}
+
+// Clone returns an independent copy of the generator
+func (f *FBM) Clone() *FBM {
+	c := *f
+	if f.simplex != nil {
+		c.simplex = f.simplex.Clone()
+		c.basis = c.simplex
 	}
+	return &c
+}
+
+// Eval2 evaluates 2D fBM with the generator's parameters, implementing Source2
+func (f *FBM) Eval2(x, y float32) float32 {
+	return f.Eval(f.Lacunarity, f.Gain, f.Octaves, x, y)
+}
+
+// Eval3 evaluates 3D fBM with the generator's parameters, implementing Source3
+func (f *FBM) Eval3(x, y, z float32) float32 {
+	return f.Eval(f.Lacunarity, f.Gain, f.Octaves, x, y, z)
 }
 
 // Eval evaluates fractal Brownian motion at the given coordinates
@@ -297,14 +385,14 @@ func (f *FBM) Eval(lacunarity, gain float32, octaves int, coords ...float32) flo
 		var noise float32
 		switch len(coords) {
 		case 1:
-			noise = f.simplex.noise1D(coords[0] * freq)
+			noise = f.noise2D(coords[0]*freq, 0)
 		case 2:
-			noise = f.simplex.noise2D(coords[0]*freq, coords[1]*freq)
+			noise = f.noise2D(coords[0]*freq, coords[1]*freq)
 		case 3:
-			noise = f.simplex.noise3D(coords[0]*freq, coords[1]*freq, coords[2]*freq)
+			noise = f.noise3D(coords[0]*freq, coords[1]*freq, coords[2]*freq)
 		}
 
-		sum += amp * noise
+		sum += float32(amp * noise)
 		totalAmp += amp
 		freq *= lacunarity
 		amp *= gain
@@ -315,3 +403,19 @@ func (f *FBM) Eval(lacunarity, gain float32, octaves int, coords ...float32) flo
 	}
 	return 0
 }
+
+// noise2D evaluates a single 2D octave of the basis
+func (f *FBM) noise2D(x, y float32) float32 {
+	if f.simplex != nil {
+		return f.simplex.noise2D(x, y)
+	}
+	return f.basis.Eval2(x, y)
+}
+
+// noise3D evaluates a single 3D octave of the basis
+func (f *FBM) noise3D(x, y, z float32) float32 {
+	if f.simplex != nil {
+		return f.simplex.noise3D(x, y, z)
+	}
+	return f.basis.Eval3(x, y, z)
+}
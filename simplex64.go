@@ -0,0 +1,200 @@
+package noise
+
+import "math"
+
+const (
+	f2x64 = 0.36602540378443865 // 0.5 * (math.Sqrt(3) - 1)
+	g2x64 = 0.21132486540518713 // (3 - math.Sqrt(3)) / 6
+)
+
+// ---------------------------------- Simplex Noise (float64) ----------------------------------
+
+// Eval64 evaluates simplex noise at the given coordinates entirely in float64.
+// Supports 1D, 2D, and 3D noise based on number of arguments. It uses the same
+// permutation and gradients as Eval, but keeps full precision for very large
+// coordinates where float32 inputs visibly quantize the noise.
+func (s *Simplex) Eval64(coords ...float64) float64 {
+	switch len(coords) {
+	case 1:
+		return s.noise2D64(coords[0], 0)
+	case 2:
+		return s.noise2D64(coords[0], coords[1])
+	case 3:
+		return s.noise3D64(coords[0], coords[1], coords[2])
+	default:
+		panic("noise: simplex requires 1, 2, or 3 coordinates")
+	}
+}
+
+// noise2D64 computes 2D simplex noise in double precision
+func (s *Simplex) noise2D64(x, y float64) float64 {
+	// Skew the input space to determine which simplex cell we're in
+	sk := float64((x + y) * f2x64)
+	i := floor64(x + sk)
+	j := floor64(y + sk)
+
+	// Unskew the cell origin back to (x,y) space
+	t := float64(float64(i+j) * g2x64)
+	x0 := x - (float64(i) - t)
+	y0 := y - (float64(j) - t)
+
+	// Determine which simplex (upper or lower triangle) we are in
+	i1, j1 := 0, 1
+	if x0 > y0 {
+		i1, j1 = 1, 0
+	}
+
+	// Offsets for the middle and last corners in (x,y) unskewed coords
+	x1 := x0 - float64(i1) + g2x64
+	y1 := y0 - float64(j1) + g2x64
+	x2 := x0 - 1 + 2*g2x64
+	y2 := y0 - 1 + 2*g2x64
+
+	// Work out the hashed gradient indices of the three simplex corners
+	ii, jj := int(i&255), int(j&255)
+	g0 := grad2[s.perm[ii+int(s.perm[jj])]]
+	g1 := grad2[s.perm[ii+i1+int(s.perm[jj+j1])]]
+	g2 := grad2[s.perm[ii+1+int(s.perm[jj+1])]]
+
+	// Calculate the contribution from the three corners
+	n := 0.0
+	if t := 0.5 - float64(x0*x0) - float64(y0*y0); t > 0 {
+		n += float64(pow4x64(t) * (float64(g0[0])*x0 + float64(g0[1])*y0))
+	}
+	if t := 0.5 - float64(x1*x1) - float64(y1*y1); t > 0 {
+		n += float64(pow4x64(t) * (float64(g1[0])*x1 + float64(g1[1])*y1))
+	}
+	if t := 0.5 - float64(x2*x2) - float64(y2*y2); t > 0 {
+		n += float64(pow4x64(t) * (float64(g2[0])*x2 + float64(g2[1])*y2))
+	}
+
+	// The result is scaled to return values in the interval [-1,1].
+	return 70.0 * n
+}
+
+// noise3D64 computes 3D simplex noise in double precision
+func (s *Simplex) noise3D64(x, y, z float64) float64 {
+	// Skew the input space to determine which simplex cell we're in
+	sk := float64((x + y + z) * f3)
+	i := floor64(x + sk)
+	j := floor64(y + sk)
+	k := floor64(z + sk)
+
+	// Unskew the cell origin back to (x,y,z) space
+	t := float64(float64(i+j+k) * g3)
+	x0 := x - (float64(i) - t)
+	y0 := y - (float64(j) - t)
+	z0 := z - (float64(k) - t)
+
+	// Determine which of the six tetrahedra we are in
+	var i1, j1, k1, i2, j2, k2 int
+	if x0 >= y0 {
+		switch {
+		case y0 >= z0:
+			i1, j1, k1, i2, j2, k2 = 1, 0, 0, 1, 1, 0
+		case x0 >= z0:
+			i1, j1, k1, i2, j2, k2 = 1, 0, 0, 1, 0, 1
+		default:
+			i1, j1, k1, i2, j2, k2 = 0, 0, 1, 1, 0, 1
+		}
+	} else {
+		switch {
+		case y0 < z0:
+			i1, j1, k1, i2, j2, k2 = 0, 0, 1, 0, 1, 1
+		case x0 < z0:
+			i1, j1, k1, i2, j2, k2 = 0, 1, 0, 0, 1, 1
+		default:
+			i1, j1, k1, i2, j2, k2 = 0, 1, 0, 1, 1, 0
+		}
+	}
+
+	// Offsets of the remaining corners in (x,y,z) coords
+	x1 := x0 - float64(i1) + g3
+	y1 := y0 - float64(j1) + g3
+	z1 := z0 - float64(k1) + g3
+	x2 := x0 - float64(i2) + 2.0*g3
+	y2 := y0 - float64(j2) + 2.0*g3
+	z2 := z0 - float64(k2) + 2.0*g3
+	x3 := x0 - 1.0 + 3.0*g3
+	y3 := y0 - 1.0 + 3.0*g3
+	z3 := z0 - 1.0 + 3.0*g3
+
+	// Work out the hashed gradient indices of the four simplex corners
+	ii, jj, kk := int(i&255), int(j&255), int(k&255)
+	gi0 := s.perm[ii+int(s.perm[jj+int(s.perm[kk])])] % 12
+	gi1 := s.perm[ii+i1+int(s.perm[jj+j1+int(s.perm[kk+k1])])] % 12
+	gi2 := s.perm[ii+i2+int(s.perm[jj+j2+int(s.perm[kk+k2])])] % 12
+	gi3 := s.perm[ii+1+int(s.perm[jj+1+int(s.perm[kk+1])])] % 12
+
+	// Calculate the contribution from the four corners
+	n := 0.0
+	if t := 0.6 - float64(x0*x0) - float64(y0*y0) - float64(z0*z0); t >= 0 {
+		g := grad3[s.grad3Of(gi0)]
+		n += float64(pow4x64(t) * (float64(g[0])*x0 + float64(g[1])*y0 + float64(g[2])*z0))
+	}
+	if t := 0.6 - float64(x1*x1) - float64(y1*y1) - float64(z1*z1); t >= 0 {
+		g := grad3[s.grad3Of(gi1)]
+		n += float64(pow4x64(t) * (float64(g[0])*x1 + float64(g[1])*y1 + float64(g[2])*z1))
+	}
+	if t := 0.6 - float64(x2*x2) - float64(y2*y2) - float64(z2*z2); t >= 0 {
+		g := grad3[s.grad3Of(gi2)]
+		n += float64(pow4x64(t) * (float64(g[0])*x2 + float64(g[1])*y2 + float64(g[2])*z2))
+	}
+	if t := 0.6 - float64(x3*x3) - float64(y3*y3) - float64(z3*z3); t >= 0 {
+		g := grad3[s.grad3Of(gi3)]
+		n += float64(pow4x64(t) * (float64(g[0])*x3 + float64(g[1])*y3 + float64(g[2])*z3))
+	}
+
+	// The result is scaled to stay just inside [-1,1]
+	return 32.0 * n
+}
+
+// pow4x64 lifts the value to the power of 4
+func pow4x64(v float64) float64 {
+	v *= v
+	return v * v
+}
+
+// floor64 floors the floating-point value to an integer
+func floor64(x float64) int64 {
+	return int64(math.Floor(x))
+}
+
+// ---------------------------------- Fractal Brownian Motion (float64) ----------------------------------
+
+// Eval64 evaluates fractal Brownian motion at the given coordinates entirely in float64.
+// First 3 parameters are lacunarity, gain, octaves, followed by 1-3 coordinates
+func (f *FBM) Eval64(lacunarity, gain float64, octaves int, coords ...float64) float64 {
+	switch {
+	case len(coords) < 1 || len(coords) > 3:
+		panic("noise: fBM requires at least 1 and at most 3 coordinates")
+	case f.simplex == nil:
+		panic("noise: fBM requires a simplex basis for float64 evaluation")
+	case octaves <= 0:
+		return 0
+	}
+
+	var sum, totalAmp float64
+	amp, freq := 1.0, 1.0
+	for o := 0; o < octaves; o++ {
+		var noise float64
+		switch len(coords) {
+		case 1:
+			noise = f.simplex.noise2D64(coords[0]*freq, 0)
+		case 2:
+			noise = f.simplex.noise2D64(coords[0]*freq, coords[1]*freq)
+		case 3:
+			noise = f.simplex.noise3D64(coords[0]*freq, coords[1]*freq, coords[2]*freq)
+		}
+
+		sum += float64(amp * noise)
+		totalAmp += amp
+		freq *= lacunarity
+		amp *= gain
+	}
+
+	if totalAmp > 0 {
+		return sum / totalAmp
+	}
+	return 0
+}
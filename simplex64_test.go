@@ -0,0 +1,69 @@
+package noise
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEval64(t *testing.T) {
+	s := NewSimplex(42)
+
+	// Double precision should closely track the float32 implementation near the origin
+	for i := 0; i < 1000; i++ {
+		x := float64(White(1, i) * 100)
+		y := float64(White(2, i) * 100)
+		z := float64(White(3, i) * 100)
+		assert.InDelta(t, s.Eval(float32(x)), s.Eval64(x), 1e-3)
+		assert.InDelta(t, s.Eval(float32(x), float32(y)), s.Eval64(x, y), 1e-3)
+		assert.InDelta(t, s.Eval(float32(x), float32(y), float32(z)), s.Eval64(x, y, z), 1e-3)
+	}
+
+	assert.Panics(t, func() { s.Eval64() })
+	assert.Panics(t, func() { s.Eval64(1, 2, 3, 4) })
+}
+
+func TestEval64Range(t *testing.T) {
+	s := NewSimplex(7)
+	for i := 0; i < 10000; i++ {
+		x := float64(White(1, i)) * 1e9
+		y := float64(White(2, i)) * 1e9
+		z := float64(White(3, i)) * 1e9
+		v2, v3 := s.Eval64(x, y), s.Eval64(x, y, z)
+		assert.True(t, v2 >= -1 && v2 <= 1, "got %v", v2)
+		assert.True(t, v3 >= -1 && v3 <= 1, "got %v", v3)
+	}
+}
+
+func TestEval64LargeCoordinates(t *testing.T) {
+	s := NewSimplex(42)
+
+	// Far from the origin, consecutive float32 samples collapse onto the same value
+	// while float64 keeps resolving the small steps.
+	const origin, step = 1e6, 0.01
+	distinct32, distinct64 := map[float32]bool{}, map[float64]bool{}
+	for i := 0; i < 100; i++ {
+		x := origin + float64(i)*step
+		distinct32[s.Eval(float32(x), 0.5)] = true
+		distinct64[s.Eval64(x, 0.5)] = true
+	}
+
+	assert.Less(t, len(distinct32), 20)
+	assert.Equal(t, 100, len(distinct64))
+}
+
+func TestFBMEval64(t *testing.T) {
+	f := NewFBM(42)
+	for i := 0; i < 100; i++ {
+		x := float64(White(1, i) * 10)
+		y := float64(White(2, i) * 10)
+		assert.InDelta(t, f.Eval(2, 0.5, 4, float32(x)), f.Eval64(2, 0.5, 4, x), 1e-3)
+		assert.InDelta(t, f.Eval(2, 0.5, 4, float32(x), float32(y)), f.Eval64(2, 0.5, 4, x, y), 1e-3)
+		assert.InDelta(t, f.Eval(2, 0.5, 4, float32(x), float32(y), 1), f.Eval64(2, 0.5, 4, x, y, 1), 1e-3)
+	}
+
+	assert.Equal(t, 0.0, f.Eval64(2, 0.5, 0, 1))
+	assert.False(t, math.IsNaN(f.Eval64(2, 0.5, 8, 1e12, -1e12)))
+	assert.Panics(t, func() { f.Eval64(2, 0.5, 4) })
+}
@@ -0,0 +1,24 @@
+//go:build !noasm && amd64
+
+package noise
+
+import "github.com/klauspost/cpuid/v2"
+
+// avx2 is set when the CPU supports the AVX2 batch kernel
+var avx2 = cpuid.CPU.Supports(cpuid.AVX2)
+
+// batch2D evaluates 2D noise in batches of 8 using AVX2, with a scalar tail
+func (s *Simplex) batch2D(dst, xs, ys []float32) {
+	n := 0
+	if avx2 && len(xs) >= 8 {
+		n = len(xs) &^ 7
+		simplex2x8(&s.perm, &grad2, &dst[0], &xs[0], &ys[0], n)
+	}
+
+	for i := n; i < len(xs); i++ {
+		dst[i] = s.noise2D(xs[i], ys[i])
+	}
+}
+
+//go:noescape
+func simplex2x8(perm *[512]uint8, grad *[256][2]float32, dst, xs, ys *float32, n int)
@@ -0,0 +1,251 @@
+//go:build !noasm && amd64
+
+#include "textflag.h"
+
+// F2 skew factor
+DATA f2<>+0x00(SB)/4, $0x3ebb67af
+DATA f2<>+0x04(SB)/4, $0x3ebb67af
+DATA f2<>+0x08(SB)/4, $0x3ebb67af
+DATA f2<>+0x0c(SB)/4, $0x3ebb67af
+DATA f2<>+0x10(SB)/4, $0x3ebb67af
+DATA f2<>+0x14(SB)/4, $0x3ebb67af
+DATA f2<>+0x18(SB)/4, $0x3ebb67af
+DATA f2<>+0x1c(SB)/4, $0x3ebb67af
+GLOBL f2<>(SB), RODATA|NOPTR, $32
+
+// G2 unskew factor
+DATA g2<>+0x00(SB)/4, $0x3e58658c
+DATA g2<>+0x04(SB)/4, $0x3e58658c
+DATA g2<>+0x08(SB)/4, $0x3e58658c
+DATA g2<>+0x0c(SB)/4, $0x3e58658c
+DATA g2<>+0x10(SB)/4, $0x3e58658c
+DATA g2<>+0x14(SB)/4, $0x3e58658c
+DATA g2<>+0x18(SB)/4, $0x3e58658c
+DATA g2<>+0x1c(SB)/4, $0x3e58658c
+GLOBL g2<>(SB), RODATA|NOPTR, $32
+
+// 2*G2 - 1
+DATA g2m1<>+0x00(SB)/4, $0xbf13cd3a
+DATA g2m1<>+0x04(SB)/4, $0xbf13cd3a
+DATA g2m1<>+0x08(SB)/4, $0xbf13cd3a
+DATA g2m1<>+0x0c(SB)/4, $0xbf13cd3a
+DATA g2m1<>+0x10(SB)/4, $0xbf13cd3a
+DATA g2m1<>+0x14(SB)/4, $0xbf13cd3a
+DATA g2m1<>+0x18(SB)/4, $0xbf13cd3a
+DATA g2m1<>+0x1c(SB)/4, $0xbf13cd3a
+GLOBL g2m1<>(SB), RODATA|NOPTR, $32
+
+// 0.5
+DATA half<>+0x00(SB)/4, $0x3f000000
+DATA half<>+0x04(SB)/4, $0x3f000000
+DATA half<>+0x08(SB)/4, $0x3f000000
+DATA half<>+0x0c(SB)/4, $0x3f000000
+DATA half<>+0x10(SB)/4, $0x3f000000
+DATA half<>+0x14(SB)/4, $0x3f000000
+DATA half<>+0x18(SB)/4, $0x3f000000
+DATA half<>+0x1c(SB)/4, $0x3f000000
+GLOBL half<>(SB), RODATA|NOPTR, $32
+
+// 70.0
+DATA seventy<>+0x00(SB)/4, $0x428c0000
+DATA seventy<>+0x04(SB)/4, $0x428c0000
+DATA seventy<>+0x08(SB)/4, $0x428c0000
+DATA seventy<>+0x0c(SB)/4, $0x428c0000
+DATA seventy<>+0x10(SB)/4, $0x428c0000
+DATA seventy<>+0x14(SB)/4, $0x428c0000
+DATA seventy<>+0x18(SB)/4, $0x428c0000
+DATA seventy<>+0x1c(SB)/4, $0x428c0000
+GLOBL seventy<>(SB), RODATA|NOPTR, $32
+
+// 1.0
+DATA onef<>+0x00(SB)/4, $0x3f800000
+DATA onef<>+0x04(SB)/4, $0x3f800000
+DATA onef<>+0x08(SB)/4, $0x3f800000
+DATA onef<>+0x0c(SB)/4, $0x3f800000
+DATA onef<>+0x10(SB)/4, $0x3f800000
+DATA onef<>+0x14(SB)/4, $0x3f800000
+DATA onef<>+0x18(SB)/4, $0x3f800000
+DATA onef<>+0x1c(SB)/4, $0x3f800000
+GLOBL onef<>(SB), RODATA|NOPTR, $32
+
+// int32 1
+DATA onei<>+0x00(SB)/4, $0x00000001
+DATA onei<>+0x04(SB)/4, $0x00000001
+DATA onei<>+0x08(SB)/4, $0x00000001
+DATA onei<>+0x0c(SB)/4, $0x00000001
+DATA onei<>+0x10(SB)/4, $0x00000001
+DATA onei<>+0x14(SB)/4, $0x00000001
+DATA onei<>+0x18(SB)/4, $0x00000001
+DATA onei<>+0x1c(SB)/4, $0x00000001
+GLOBL onei<>(SB), RODATA|NOPTR, $32
+
+// int32 0xff
+DATA mask8<>+0x00(SB)/4, $0x000000ff
+DATA mask8<>+0x04(SB)/4, $0x000000ff
+DATA mask8<>+0x08(SB)/4, $0x000000ff
+DATA mask8<>+0x0c(SB)/4, $0x000000ff
+DATA mask8<>+0x10(SB)/4, $0x000000ff
+DATA mask8<>+0x14(SB)/4, $0x000000ff
+DATA mask8<>+0x18(SB)/4, $0x000000ff
+DATA mask8<>+0x1c(SB)/4, $0x000000ff
+GLOBL mask8<>(SB), RODATA|NOPTR, $32
+// func simplex2x8(perm *[512]uint8, grad *[256][2]float32, dst, xs, ys *float32, n int)
+// Evaluates 8 lanes of 2D simplex noise per iteration. The sequence of floating-point
+// operations mirrors noise2D exactly so that the output is bit-identical.
+TEXT ·simplex2x8(SB), NOSPLIT, $0-48
+	MOVQ perm+0(FP), AX
+	MOVQ grad+8(FP), BX
+	MOVQ dst+16(FP), DI
+	MOVQ xs+24(FP), SI
+	MOVQ ys+32(FP), DX
+	MOVQ n+40(FP), CX
+	XORQ R8, R8
+	VXORPS Y15, Y15, Y15
+
+loop:
+	CMPQ R8, CX
+	JGE  done
+
+	// Skew the input space to determine which simplex cell we're in
+	VMOVUPS (SI)(R8*4), Y0
+	VMOVUPS (DX)(R8*4), Y1
+	VADDPS  Y1, Y0, Y2
+	VMULPS  f2<>(SB), Y2, Y2
+	VADDPS  Y2, Y0, Y3
+	VADDPS  Y2, Y1, Y4
+	VROUNDPS $1, Y3, Y3
+	VROUNDPS $1, Y4, Y4
+	VCVTTPS2DQ Y3, Y5 // i
+	VCVTTPS2DQ Y4, Y6 // j
+
+	// Unskew the cell origin back to (x,y) space
+	VPADDD    Y6, Y5, Y2
+	VCVTDQ2PS Y2, Y2
+	VMULPS    g2<>(SB), Y2, Y2 // t
+	VCVTDQ2PS Y5, Y3
+	VCVTDQ2PS Y6, Y4
+	VSUBPS    Y2, Y3, Y3
+	VSUBPS    Y2, Y4, Y4
+	VSUBPS    Y3, Y0, Y0 // x0
+	VSUBPS    Y4, Y1, Y1 // y0
+
+	// Determine which triangle we are in
+	VCMPPS $0x1e, Y1, Y0, Y2
+	VANDPS onef<>(SB), Y2, Y3 // i1 (float)
+	VMOVUPS onef<>(SB), Y4
+	VSUBPS Y3, Y4, Y4         // j1 (float)
+	VPAND  onei<>(SB), Y2, Y2 // i1 (int)
+	VMOVUPS onei<>(SB), Y7
+	VPSUBD Y2, Y7, Y7         // j1 (int)
+
+	// Offsets for the middle and last corners
+	VSUBPS Y3, Y0, Y8
+	VADDPS g2<>(SB), Y8, Y8     // x1
+	VSUBPS Y4, Y1, Y9
+	VADDPS g2<>(SB), Y9, Y9     // y1
+	VADDPS g2m1<>(SB), Y0, Y10 // x2
+	VADDPS g2m1<>(SB), Y1, Y11 // y2
+
+	// Hashed permutation lookups for the three corners
+	VPAND mask8<>(SB), Y5, Y5 // ii
+	VPAND mask8<>(SB), Y6, Y6 // jj
+	VPCMPEQD Y12, Y12, Y12
+	VPADDD Y7, Y6, Y13
+	VPGATHERDD Y12, (AX)(Y13*1), Y3
+	VPAND mask8<>(SB), Y3, Y3 // p1
+	VPCMPEQD Y12, Y12, Y12
+	VPGATHERDD Y12, (AX)(Y6*1), Y4
+	VPAND mask8<>(SB), Y4, Y4 // p0
+	VPCMPEQD Y12, Y12, Y12
+	VPADDD onei<>(SB), Y6, Y13
+	VPGATHERDD Y12, (AX)(Y13*1), Y7
+	VPAND mask8<>(SB), Y7, Y7 // p2
+
+	// Permuted indices for the three corners, perm[k] == perm[k&255]
+	VPADDD Y5, Y4, Y4         // ii + p0
+	VPADDD Y2, Y3, Y3
+	VPADDD Y5, Y3, Y3         // ii + i1 + p1
+	VPADDD Y5, Y7, Y7
+	VPADDD onei<>(SB), Y7, Y7 // ii + 1 + p2
+	VPAND mask8<>(SB), Y4, Y4
+	VPAND mask8<>(SB), Y3, Y3
+	VPAND mask8<>(SB), Y7, Y7
+
+	// Gradient indices for the three corners
+	VPCMPEQD Y12, Y12, Y12
+	VPGATHERDD Y12, (AX)(Y4*1), Y2
+	VPAND mask8<>(SB), Y2, Y4
+	VPCMPEQD Y12, Y12, Y12
+	VPGATHERDD Y12, (AX)(Y3*1), Y2
+	VPAND mask8<>(SB), Y2, Y3
+	VPCMPEQD Y12, Y12, Y12
+	VPGATHERDD Y12, (AX)(Y7*1), Y2
+	VPAND mask8<>(SB), Y2, Y7
+
+	// Contribution of the first corner
+	VPCMPEQD Y12, Y12, Y12
+	VGATHERDPS Y12, (BX)(Y4*8), Y2
+	VPCMPEQD Y12, Y12, Y12
+	VGATHERDPS Y12, 4(BX)(Y4*8), Y5
+	VMULPS Y0, Y2, Y2
+	VMULPS Y1, Y5, Y5
+	VADDPS Y5, Y2, Y2
+	VMULPS Y0, Y0, Y6
+	VMOVUPS half<>(SB), Y13
+	VSUBPS Y6, Y13, Y13
+	VMULPS Y1, Y1, Y6
+	VSUBPS Y6, Y13, Y13
+	VMAXPS Y15, Y13, Y13
+	VMULPS Y13, Y13, Y13
+	VMULPS Y13, Y13, Y13
+	VMULPS Y2, Y13, Y13
+	VADDPS Y13, Y15, Y14
+
+	// Contribution of the middle corner
+	VPCMPEQD Y12, Y12, Y12
+	VGATHERDPS Y12, (BX)(Y3*8), Y2
+	VPCMPEQD Y12, Y12, Y12
+	VGATHERDPS Y12, 4(BX)(Y3*8), Y5
+	VMULPS Y8, Y2, Y2
+	VMULPS Y9, Y5, Y5
+	VADDPS Y5, Y2, Y2
+	VMULPS Y8, Y8, Y6
+	VMOVUPS half<>(SB), Y13
+	VSUBPS Y6, Y13, Y13
+	VMULPS Y9, Y9, Y6
+	VSUBPS Y6, Y13, Y13
+	VMAXPS Y15, Y13, Y13
+	VMULPS Y13, Y13, Y13
+	VMULPS Y13, Y13, Y13
+	VMULPS Y2, Y13, Y13
+	VADDPS Y13, Y14, Y14
+
+	// Contribution of the last corner
+	VPCMPEQD Y12, Y12, Y12
+	VGATHERDPS Y12, (BX)(Y7*8), Y2
+	VPCMPEQD Y12, Y12, Y12
+	VGATHERDPS Y12, 4(BX)(Y7*8), Y5
+	VMULPS Y10, Y2, Y2
+	VMULPS Y11, Y5, Y5
+	VADDPS Y5, Y2, Y2
+	VMULPS Y10, Y10, Y6
+	VMOVUPS half<>(SB), Y13
+	VSUBPS Y6, Y13, Y13
+	VMULPS Y11, Y11, Y6
+	VSUBPS Y6, Y13, Y13
+	VMAXPS Y15, Y13, Y13
+	VMULPS Y13, Y13, Y13
+	VMULPS Y13, Y13, Y13
+	VMULPS Y2, Y13, Y13
+	VADDPS Y13, Y14, Y14
+
+	// Scale the result to return values in the interval [-1,1]
+	VMULPS  seventy<>(SB), Y14, Y14
+	VMOVUPS Y14, (DI)(R8*4)
+
+	ADDQ $8, R8
+	JMP  loop
+
+done:
+	VZEROUPPER
+	RET
@@ -0,0 +1,44 @@
+package noise
+
+// EvalBatch evaluates 2D simplex noise for every pair (xs[i], ys[i]) and writes
+// the result into dst[i]. On CPUs with AVX2 support, 8 samples are evaluated at
+// once; elsewhere it falls back to the scalar implementation. The output is
+// bit-identical to Eval for coordinates within the int32 range.
+func (s *Simplex) EvalBatch(dst, xs, ys []float32) {
+	if len(xs) != len(ys) || len(dst) < len(xs) {
+		panic("noise: batch requires len(xs) == len(ys) <= len(dst)")
+	}
+
+	s.batch2D(dst[:len(xs)], xs, ys)
+}
+
+// Fill2D fills dst with a w×h grid of 2D simplex noise in row-major order, where
+// the cell (ix, iy) is sampled at (x + ix*step, y + iy*step).
+//
+// Example:
+//
+//	heights := make([]float32, 512*512)
+//	s.Fill2D(heights, 512, 512, 0, 0, 0.01)
+func (s *Simplex) Fill2D(dst []float32, w, h int, x, y, step float32) {
+	switch {
+	case w <= 0 || h <= 0:
+		return
+	case len(dst) < w*h:
+		panic("noise: destination is too small for the grid")
+	}
+
+	xs := make([]float32, w)
+	ys := make([]float32, w)
+	for ix := range xs {
+		xs[ix] = x + float32(float32(ix)*step)
+	}
+
+	for iy := 0; iy < h; iy++ {
+		fy := y + float32(float32(iy)*step)
+		for i := range ys {
+			ys[i] = fy
+		}
+
+		s.batch2D(dst[iy*w:(iy+1)*w], xs, ys)
+	}
+}
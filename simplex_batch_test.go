@@ -0,0 +1,67 @@
+package noise
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvalBatch(t *testing.T) {
+	s := NewSimplex(42)
+	for _, n := range []int{0, 1, 7, 8, 9, 63, 1000} {
+		xs := make([]float32, n)
+		ys := make([]float32, n)
+		for i := range xs {
+			xs[i] = White(1, i)*500 + 0.25
+			ys[i] = White(2, i) * 500
+		}
+
+		dst := make([]float32, n)
+		s.EvalBatch(dst, xs, ys)
+		for i := range dst {
+			expect := s.Eval(xs[i], ys[i])
+			assert.Equal(t, math.Float32bits(expect), math.Float32bits(dst[i]),
+				"mismatch at (%v, %v): %v != %v", xs[i], ys[i], expect, dst[i])
+		}
+	}
+}
+
+func TestEvalBatchLattice(t *testing.T) {
+	s := NewSimplex(7)
+
+	// Integer and negative coordinates exercise floor and triangle selection
+	var xs, ys []float32
+	for y := -20; y <= 20; y++ {
+		for x := -20; x <= 20; x++ {
+			xs = append(xs, float32(x)*0.5)
+			ys = append(ys, float32(y)*0.5)
+		}
+	}
+
+	dst := make([]float32, len(xs))
+	s.EvalBatch(dst, xs, ys)
+	for i := range dst {
+		assert.Equal(t, s.Eval(xs[i], ys[i]), dst[i])
+	}
+}
+
+func TestFill2D(t *testing.T) {
+	const w, h = 37, 21
+	s := NewSimplex(42)
+	dst := make([]float32, w*h)
+	s.Fill2D(dst, w, h, -3, 5, 0.05)
+
+	for iy := 0; iy < h; iy++ {
+		for ix := 0; ix < w; ix++ {
+			x := -3 + float32(float32(ix)*0.05)
+			y := 5 + float32(float32(iy)*0.05)
+			assert.Equal(t, s.Eval(x, y), dst[iy*w+ix])
+		}
+	}
+
+	assert.NotPanics(t, func() { s.Fill2D(nil, 0, 10, 0, 0, 1) })
+	assert.Panics(t, func() { s.Fill2D(make([]float32, 10), 4, 4, 0, 0, 1) })
+	assert.Panics(t, func() { s.EvalBatch(make([]float32, 2), make([]float32, 2), make([]float32, 3)) })
+	assert.Panics(t, func() { s.EvalBatch(make([]float32, 1), make([]float32, 2), make([]float32, 2)) })
+}
@@ -0,0 +1,10 @@
+//go:build noasm || !amd64
+
+package noise
+
+// batch2D evaluates 2D noise one sample at a time
+func (s *Simplex) batch2D(dst, xs, ys []float32) {
+	for i := range xs {
+		dst[i] = s.noise2D(xs[i], ys[i])
+	}
+}
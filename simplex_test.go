@@ -6,7 +6,9 @@ import (
 	"image/gif"
 	"image/png"
 	"os"
+	"sync"
 	"testing"
+	"unsafe"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -120,6 +122,75 @@ func TestSimplex(t *testing.T) {
 	}
 }
 
+func TestSimplexSize(t *testing.T) {
+	// Only the permutation table, seed and version are per-instance, gradients are shared
+	assert.Equal(t, uintptr(520), unsafe.Sizeof(Simplex{}))
+	assert.Equal(t, NewSimplex(1).Eval(1.5, 2.5), NewSimplex(1).Eval(1.5, 2.5))
+	assert.NotEqual(t, NewSimplex(1).Eval(1.5, 2.5), NewSimplex(2).Eval(1.5, 2.5))
+}
+
+func TestSimplexClone(t *testing.T) {
+	s := NewSimplex(42)
+	c := s.Clone()
+	assert.NotSame(t, s, c)
+	assert.Equal(t, s.Eval(1.5, 2.5), c.Eval(1.5, 2.5))
+
+	f := NewFBM(42)
+	assert.Equal(t, f.Eval(2, 0.5, 4, 1.5, 2.5, 3.5), f.Clone().Eval(2, 0.5, 4, 1.5, 2.5, 3.5))
+}
+
+func TestFBMWith(t *testing.T) {
+	f := NewFBM(42)
+	g := NewFBMWith(NewSimplex(42))
+	assert.Equal(t, f.Eval(2, 0.5, 4, 1.3, 2.7), g.Eval(2, 0.5, 4, 1.3, 2.7))
+
+	for _, basis := range []Source{NewValue(42), NewCellular(42), Ridged(NewSimplex(42))} {
+		f := NewFBMWith(basis)
+		for i := 0; i < 100; i++ {
+			x := float32(i) * 0.37
+			v2, v3 := f.Eval2(x, -x), f.Eval3(x, -x, x)
+			assert.True(t, v2 >= -1 && v2 <= 1)
+			assert.True(t, v3 >= -1 && v3 <= 1)
+		}
+
+		assert.Equal(t, f.Eval(2, 0.5, 4, 1.3, 0), f.Eval(2, 0.5, 4, 1.3))
+		assert.Panics(t, func() { f.Eval64(2, 0.5, 4, 1.3) })
+	}
+}
+
+func TestSimplexConcurrent(t *testing.T) {
+	s := NewSimplex(42)
+	f := NewFBM(42)
+
+	// Reference values computed on a single goroutine
+	const n = 1000
+	want := make([]float32, n)
+	for i := range want {
+		x := float32(float32(i) * 0.1)
+		want[i] = s.Eval(x, -x) + s.Eval(x, 1, -x) + f.Eval(2, 0.5, 4, x, -x)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan int, 8)
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range want {
+				x := float32(float32(i) * 0.1)
+				if s.Eval(x, -x)+s.Eval(x, 1, -x)+f.Eval(2, 0.5, 4, x, -x) != want[i] {
+					errs <- i
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+	assert.Empty(t, errs)
+}
+
 // createGreyscalePalette creates a 256-color greyscale palette
 func createGreyscalePalette() color.Palette {
 	palette := make(color.Palette, 256)
@@ -222,24 +293,48 @@ func generate1DNoiseImage(width, height int, scale float32, noiseFunc func(x flo
 
 // generate3DNoiseGIF creates a 3D noise animation as a GIF
 func generate3DNoiseGIF(width, height, frames int, scale float32, noiseFunc func(x, y, z float32) float32) *gif.GIF {
-	palette := createGreyscalePalette()
-	anim := &gif.GIF{}
-
-	for frame := 0; frame < frames; frame++ {
-		img := image.NewPaletted(image.Rect(0, 0, width, height), palette)
-		z := float32(frame) * 0.1 // Time parameter for animation
-
-		for y := 0; y < height; y++ {
-			for x := 0; x < width; x++ {
-				noise := noiseFunc(float32(x)*scale, float32(y)*scale, z)
-				normalized := normalizeNoise(noise)
-				img.SetColorIndex(x, y, uint8(normalized*255))
+	return Animate(Scale(SourceFunc(noiseFunc), scale, scale, 1), frames, width, height, 0.1)
+}
+
+func TestGradients3D(t *testing.T) {
+	var withZ int
+	for _, g := range grad3 {
+		assert.Equal(t, float32(2), g[0]*g[0]+g[1]*g[1]+g[2]*g[2])
+		if g[2] != 0 {
+			withZ++
+		}
+	}
+
+	// Two thirds of the 3D gradients point out of the xy plane
+	assert.Greater(t, withZ, 160)
+}
+
+func TestGradients3DSelection(t *testing.T) {
+	for _, seed := range []uint32{0, 1, 7, 12345} {
+		counts := func(s *Simplex) (out [12]int) {
+			for i := 0; i < 64; i++ {
+				for j := 0; j < 64; j++ {
+					for k := 0; k < 64; k++ {
+						gi := s.perm[i+int(s.perm[j+int(s.perm[k])])] % 12
+						out[s.grad3Of(gi)%12]++
+					}
+				}
 			}
+			return
 		}
 
-		anim.Image = append(anim.Image, img)
-		anim.Delay = append(anim.Delay, 10) // 100ms per frame
-	}
+		// Every gradient is selected about 1/12 of the time
+		for g, n := range counts(NewSimplexV2(seed)) {
+			assert.InDelta(t, 1.0/12, float64(n)/(64*64*64), 0.02, "seed %d, gradient %d", seed, g)
+		}
 
-	return anim
+		// V1 is frozen, including the gradients its seeds cannot reach
+		reached := 0
+		for _, n := range counts(NewSimplexV1(seed)) {
+			if n > 0 {
+				reached++
+			}
+		}
+		assert.Less(t, reached, 12, "seed %d", seed)
+	}
 }
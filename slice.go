@@ -0,0 +1,85 @@
+package noise
+
+// ---------------------------------- Slices ----------------------------------
+
+// Shuffle deterministically shuffles the slice in place using a Fisher-Yates
+// shuffle, where every swap is derived from the seed and key. The same seed, key
+// and length always produce the same order.
+//
+// Example:
+//
+//	deck := []string{"ace", "king", "queen", "jack"}
+//	Shuffle(12345, uint64(round), deck)
+func Shuffle[T any](seed uint32, key uint64, s []T) {
+	for i := len(s) - 1; i > 0; i-- {
+		j := IntN(seed, uint64(i+1), xxhash64(uint64(i), key))
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// Perm returns a deterministic permutation of the integers [0, n), in the same
+// order as Shuffle would arrange a slice of length n.
+func Perm(seed uint32, key uint64, n int) []int {
+	if n < 0 {
+		panic("invalid argument to Perm")
+	}
+
+	out := make([]int, n)
+	for i := range out {
+		out[i] = i
+	}
+
+	Shuffle(seed, key, out)
+	return out
+}
+
+// Pick deterministically picks one of the items, which must not be empty
+//
+// Example:
+//
+//	tree := Pick(12345, uint64(x)<<32|uint64(y), []string{"oak", "pine", "birch"})
+func Pick[T any](seed uint32, key uint64, items []T) T {
+	if len(items) == 0 {
+		panic("invalid argument to Pick")
+	}
+
+	return items[IntN(seed, uint64(len(items)), key)]
+}
+
+// SampleN deterministically picks n distinct items without replacement, in the
+// order in which they are drawn. If n exceeds the number of items, all of them are
+// returned in a shuffled order. The items themselves are not modified.
+func SampleN[T any](seed uint32, key uint64, items []T, n int) []T {
+	if n < 0 {
+		panic("invalid argument to SampleN")
+	}
+
+	// Run the last n steps of Shuffle on a copy, which settles its tail
+	s := append([]T(nil), items...)
+	n = min(n, len(s))
+	for i := len(s) - 1; i >= len(s)-n && i > 0; i-- {
+		j := IntN(seed, uint64(i+1), xxhash64(uint64(i), key))
+		s[i], s[j] = s[j], s[i]
+	}
+	return s[len(s)-n:]
+}
+
+// PickFunc deterministically picks one of the items satisfying the predicate, each
+// with the same probability. It returns false if none of the items qualify.
+// Method: reservoir sampling, so the items are visited once and not copied.
+func PickFunc[T any](seed uint32, key uint64, items []T, fn func(T) bool) (T, bool) {
+	var pick T
+	var count uint64
+	for _, item := range items {
+		if !fn(item) {
+			continue
+		}
+
+		// Keep the k-th candidate with a probability of 1/k
+		count++
+		if IntN(seed, count, xxhash64(count, key)) == 0 {
+			pick = item
+		}
+	}
+	return pick, count > 0
+}
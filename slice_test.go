@@ -0,0 +1,113 @@
+package noise
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShuffle(t *testing.T) {
+	s := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	a, b := slices.Clone(s), slices.Clone(s)
+	Shuffle(42, 1, a)
+	Shuffle(42, 1, b)
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, s, a)
+
+	// Same elements, different key gives a different order
+	sorted := slices.Clone(a)
+	slices.Sort(sorted)
+	assert.Equal(t, s, sorted)
+
+	c := slices.Clone(s)
+	Shuffle(42, 2, c)
+	assert.NotEqual(t, a, c)
+
+	// Empty and single element slices are fine
+	Shuffle(42, 1, []int{})
+	Shuffle(42, 1, []int{1})
+}
+
+func TestPerm(t *testing.T) {
+	p := Perm(42, 7, 10)
+	assert.Equal(t, p, Perm(42, 7, 10))
+	assert.Empty(t, Perm(42, 7, 0))
+	assert.Panics(t, func() { Perm(42, 7, -1) })
+
+	sorted := slices.Clone(p)
+	slices.Sort(sorted)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, sorted)
+
+	// Perm matches the order of Shuffle
+	s := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	Shuffle(42, 7, s)
+	assert.Equal(t, p, s)
+}
+
+func TestShuffleUniform(t *testing.T) {
+	// Every element ends up in every position about equally often
+	var counts [4][4]int
+	for key := uint64(0); key < 8000; key++ {
+		for pos, v := range Perm(1, key, 4) {
+			counts[v][pos]++
+		}
+	}
+
+	for _, row := range counts {
+		for _, n := range row {
+			assert.InDelta(t, 2000, n, 150)
+		}
+	}
+}
+
+func TestPick(t *testing.T) {
+	items := []string{"oak", "pine", "birch"}
+	assert.Equal(t, Pick(42, 9, items), Pick(42, 9, items))
+	assert.Panics(t, func() { Pick(42, 9, []int{}) })
+
+	var counts [3]int
+	for key := uint64(0); key < 3000; key++ {
+		counts[slices.Index(items, Pick(42, key, items))]++
+	}
+	for _, n := range counts {
+		assert.InDelta(t, 1000, n, 100)
+	}
+}
+
+func TestSampleN(t *testing.T) {
+	items := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	s := SampleN(42, 3, items, 4)
+	assert.Len(t, s, 4)
+	assert.Equal(t, s, SampleN(42, 3, items, 4))
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, items)
+
+	// Items are distinct and match the tail of a full shuffle
+	shuffled := slices.Clone(items)
+	Shuffle(42, 3, shuffled)
+	assert.Equal(t, shuffled[6:], s)
+
+	all := SampleN(42, 3, items, 20)
+	assert.Equal(t, shuffled, all)
+	assert.Empty(t, SampleN(42, 3, items, 0))
+	assert.Empty(t, SampleN(42, 3, []int{}, 2))
+	assert.Panics(t, func() { SampleN(42, 3, items, -1) })
+}
+
+func TestPickFunc(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	even := func(v int) bool { return v%2 == 0 }
+
+	var counts [9]int
+	for key := uint64(0); key < 4000; key++ {
+		v, ok := PickFunc(42, key, items, even)
+		assert.True(t, ok)
+		counts[v]++
+	}
+	for _, v := range []int{2, 4, 6, 8} {
+		assert.InDelta(t, 1000, counts[v], 100)
+	}
+
+	_, ok := PickFunc(42, 1, items, func(v int) bool { return v > 10 })
+	assert.False(t, ok)
+}
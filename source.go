@@ -0,0 +1,62 @@
+package noise
+
+import "math"
+
+// Source2 is a 2D noise source, such as Simplex or FBM
+type Source2 interface {
+	Eval2(x, y float32) float32
+}
+
+// Source3 is a 3D noise source, such as Simplex or FBM
+type Source3 interface {
+	Eval3(x, y, z float32) float32
+}
+
+// Source is a noise source that can be evaluated in both 2D and 3D. Modifiers,
+// combiners and fractals are built on top of it, so any generator implementing
+// it can be composed with the rest of the package.
+type Source interface {
+	Source2
+	Source3
+}
+
+// SourceFunc adapts a plain 3D function to a Source. The 2D form evaluates it
+// on the z = 0 plane.
+type SourceFunc func(x, y, z float32) float32
+
+// Eval2 evaluates the function at (x, y, 0)
+func (fn SourceFunc) Eval2(x, y float32) float32 {
+	return fn(x, y, 0)
+}
+
+// Eval3 evaluates the function at (x, y, z)
+func (fn SourceFunc) Eval3(x, y, z float32) float32 {
+	return fn(x, y, z)
+}
+
+// Ridged returns a source that folds the output of src around zero, turning its
+// zero crossings into sharp ridges. The value 1 - 2|n| stays in [-1, 1], so it
+// can be used as the basis of an FBM to produce mountain ranges.
+func Ridged(src Source) Source {
+	return ridged{src}
+}
+
+// ridged is the source returned by Ridged
+type ridged struct {
+	src Source
+}
+
+// Eval2 evaluates the ridged source in 2D
+func (r ridged) Eval2(x, y float32) float32 {
+	return 1 - 2*abs(r.src.Eval2(x, y))
+}
+
+// Eval3 evaluates the ridged source in 3D
+func (r ridged) Eval3(x, y, z float32) float32 {
+	return 1 - 2*abs(r.src.Eval3(x, y, z))
+}
+
+// abs returns the absolute value of v
+func abs(v float32) float32 {
+	return math.Float32frombits(math.Float32bits(v) &^ (1 << 31))
+}
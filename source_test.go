@@ -0,0 +1,41 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSource(t *testing.T) {
+	s := NewSimplex(42)
+	f := NewFBM(42)
+
+	for _, src := range []Source{s, f} {
+		assert.NotZero(t, src.Eval2(1.3, 2.7))
+		assert.NotZero(t, src.Eval3(1.3, 2.7, 3.1))
+	}
+
+	assert.Equal(t, s.Eval(1.5, 2.5), s.Eval2(1.5, 2.5))
+	assert.Equal(t, s.Eval(1.5, 2.5, 3.5), s.Eval3(1.5, 2.5, 3.5))
+	assert.Equal(t, f.Eval(2, 0.5, 4, 1.5, 2.5), f.Eval2(1.5, 2.5))
+	assert.Equal(t, f.Eval(2, 0.5, 4, 1.5, 2.5, 3.5), f.Eval3(1.5, 2.5, 3.5))
+}
+
+func TestSourceFunc(t *testing.T) {
+	fn := SourceFunc(func(x, y, z float32) float32 { return x + y + z })
+	assert.Equal(t, float32(3), fn.Eval2(1, 2))
+	assert.Equal(t, float32(6), fn.Eval3(1, 2, 3))
+}
+
+func TestRidged(t *testing.T) {
+	s := NewSimplex(42)
+	r := Ridged(s)
+	for i := 0; i < 100; i++ {
+		x := float32(i) * 0.37
+		assert.Equal(t, 1-2*abs(s.Eval2(x, -x)), r.Eval2(x, -x))
+		assert.Equal(t, 1-2*abs(s.Eval3(x, -x, x)), r.Eval3(x, -x, x))
+	}
+
+	assert.Equal(t, float32(2), abs(-2))
+	assert.Equal(t, float32(2), abs(2))
+}
@@ -58,13 +58,28 @@ type grid2 struct {
 }
 
 func newGrid2(r1, r2 int) grid2 {
+	n, ok := grid2Bits(r1, r2)
+	if !ok {
+		panic("noise: sampling radius is too large")
+	}
+
 	w := r1*4 + 10
 	h := r2*4 + 10
 	var b bitmap.Bitmap
-	b.Grow(uint32(w*h - 1))
+	b.Grow(uint32(n - 1))
 	return grid2{Bitmap: b, w: w, h: h, x: w / 2, y: h / 2}
 }
 
+// grid2Bits returns the number of cells in the grid of the radii, or false if
+// they cannot all be indexed with 32 bits
+func grid2Bits(r1, r2 int) (uint64, bool) {
+	w, h := uint64(r1)*4+10, uint64(r2)*4+10
+	if w > 1<<32/h {
+		return 0, false
+	}
+	return w * h, true
+}
+
 func (g *grid2) IsValid(x, y float32) bool {
 	gx := int(x/ssiCell) + g.x
 	gy := int(y/ssiCell) + g.y
@@ -92,6 +107,22 @@ func (g *grid2) Set(x, y float32) {
 	}
 }
 
+// try makes up to 2 jittered attempts to place a sample in the lattice cell
+// (ix, iy), and marks the grid if one of them is accepted
+func (g *grid2) try(seed uint32, ix, iy int) ([2]float32, bool) {
+	for t := 0; t < 2; t++ {
+		h := xxhash64(uint64(int64(ix))*0x9e3779b97f4a7c15^uint64(int64(iy))*0xc2b2ae3d27d4eb4f, uint64(seed)^uint64(t))
+		x := float32(ix) + (Float32(seed, h) - 0.5)
+		y := float32(iy) + (Float32(seed^1, h) - 0.5)
+
+		if g.IsValid(x, y) {
+			g.Set(x, y)
+			return [2]float32{x, y}, true
+		}
+	}
+	return [2]float32{}, false
+}
+
 // SSI1 generates a 1D hard-core pattern as a streaming iterator.
 // Method: Simple Sequential Inhibition on a unit lattice with one jittered
 // candidate per integer cell in [−r1, +r1]. A candidate is accepted only if
@@ -174,16 +205,8 @@ func SSI2(seed uint32, r1, r2 int) iter.Seq[[2]float32] {
 
 		g := newGrid2(r1, r2)
 		tryCell := func(ix, iy int) bool {
-			for t := 0; t < 2; t++ {
-				h := xxhash64(uint64(int64(ix))*0x9e3779b97f4a7c15^uint64(int64(iy))*0xc2b2ae3d27d4eb4f, uint64(seed)^uint64(t))
-				x := float32(ix) + (Float32(seed, h) - 0.5)
-				y := float32(iy) + (Float32(seed^1, h) - 0.5)
-
-				if g.IsValid(x, y) {
-					g.Set(x, y)
-					pt := [2]float32{x, y}
-					return !yield(pt)
-				}
+			if pt, ok := g.try(seed, ix, iy); ok {
+				return !yield(pt)
 			}
 			return false
 		}
@@ -229,6 +252,8 @@ func SSI2(seed uint32, r1, r2 int) iter.Seq[[2]float32] {
 // Properties:
 //   - Deterministic for a given seed.
 //   - Minimum integer spacing equals gap.
+//   - Fractional gaps such as 2.5 round samples to the nearest pixel and drop
+//     those that would end up closer than gap to an earlier one.
 //   - Empty sequence if w <= 0 or gap <= 0.
 //
 // Complexity: inherits SSI1 cost; O(n²) with global checks, O(n) with neighbor map.
@@ -238,18 +263,23 @@ func SSI2(seed uint32, r1, r2 int) iter.Seq[[2]float32] {
 //	for ix := range Sparse1(12345, 512, 8) {
 //	    // use ix
 //	}
-func Sparse1(seed uint32, w, gap int) iter.Seq[int] {
+func Sparse1[G Number](seed uint32, w int, gap G) iter.Seq[int] {
 	return func(yield func(int) bool) {
 		if w <= 0 || gap <= 0 {
 			return
 		}
 
 		// Radius in cell units so that after scaling and centering we cover [0,w)
-		r1 := int(math.Ceil(float64(w) / float64(2*gap)))
+		r1 := int(math.Ceil(float64(w) / (2 * float64(gap))))
 		c, g := float32(w)/2, float32(gap)
+		snap := newSpacing1(w, g)
 		for x := range SSI1(seed, r1) {
-			ix := int(x*g + c)
-			if ix < 0 || ix >= w {
+			ix := int(float32(x*g) + c)
+			if snap != nil {
+				ix = int(math.Round(float64(float32(x*g) + c)))
+			}
+
+			if ix < 0 || ix >= w || (snap != nil && !snap.accept(ix)) {
 				continue
 			}
 			if !yield(ix) {
@@ -266,6 +296,8 @@ func Sparse1(seed uint32, w, gap int) iter.Seq[int] {
 // Properties:
 //   - Deterministic for a given seed.
 //   - Minimum integer spacing equals gap in Euclidean metric.
+//   - Fractional gaps such as 2.5 round samples to the nearest pixel and drop
+//     those that would end up closer than gap to an earlier one.
 //   - Empty sequence if w <= 0, h <= 0, or gap <= 0.
 //
 // Complexity: inherits SSI2 cost; O(n²) with global checks, O(n) with neighbor map.
@@ -276,21 +308,27 @@ func Sparse1(seed uint32, w, gap int) iter.Seq[int] {
 //	    x, y := p[0], p[1]
 //	    // use x, y
 //	}
-func Sparse2(seed uint32, w, h, gap int) iter.Seq[[2]int] {
+func Sparse2[G Number](seed uint32, w, h int, gap G) iter.Seq[[2]int] {
 	return func(yield func([2]int) bool) {
 		if w <= 0 || h <= 0 || gap <= 0 {
 			return
 		}
 
 		// Radii in cell units so that after scaling and centering we cover [0,w) x [0,h)
-		r1 := int(math.Ceil(float64(w) / float64(2*gap)))
-		r2 := int(math.Ceil(float64(h) / float64(2*gap)))
+		r1 := int(math.Ceil(float64(w) / (2 * float64(gap))))
+		r2 := int(math.Ceil(float64(h) / (2 * float64(gap))))
 		cx, cy, g := float32(w)/2, float32(h)/2, float32(gap)
+		snap := newSpacing2(w, h, g)
 
 		for pt := range SSI2(seed, r1, r2) {
-			ix := int(pt[0]*g + cx)
-			iy := int(pt[1]*g + cy)
-			if ix < 0 || ix >= w || iy < 0 || iy >= h {
+			ix := int(float32(pt[0]*g) + cx)
+			iy := int(float32(pt[1]*g) + cy)
+			if snap != nil {
+				ix = int(math.Round(float64(float32(pt[0]*g) + cx)))
+				iy = int(math.Round(float64(float32(pt[1]*g) + cy)))
+			}
+
+			if ix < 0 || ix >= w || iy < 0 || iy >= h || (snap != nil && !snap.accept(ix, iy)) {
 				continue
 			}
 			if !yield([2]int{ix, iy}) {
@@ -299,3 +337,110 @@ func Sparse2(seed uint32, w, h, gap int) iter.Seq[[2]int] {
 		}
 	}
 }
+
+// Sparse1Margin emits positions like Sparse1 across [0, w), but keeps them at
+// least margin pixels away from both ends. Clipping a fill to the domain leaves
+// points hugging the edges, which looks wrong in user interfaces and maps; this
+// fills the inner span [margin, w−margin) instead. Empty if the margins leave
+// no room.
+func Sparse1Margin[G Number](seed uint32, w int, gap G, margin int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		m := max(margin, 0)
+		for x := range Sparse1(seed, w-2*m, gap) {
+			if !yield(x + m) {
+				return
+			}
+		}
+	}
+}
+
+// Sparse2Margin emits positions like Sparse2 across [0, w) × [0, h), but keeps
+// them at least margin pixels away from every edge by filling the inner rectangle
+// [margin, w−margin) × [margin, h−margin). Empty if the margins leave no room.
+//
+// Example:
+//
+//	for p := range Sparse2Margin(12345, 512, 512, 16, 24) {
+//	    // icons at least 24 pixels away from the borders
+//	}
+func Sparse2Margin[G Number](seed uint32, w, h int, gap G, margin int) iter.Seq[[2]int] {
+	return func(yield func([2]int) bool) {
+		m := max(margin, 0)
+		for p := range Sparse2(seed, w-2*m, h-2*m, gap) {
+			if !yield([2]int{p[0] + m, p[1] + m}) {
+				return
+			}
+		}
+	}
+}
+
+// ---------------------------------- Fractional Gaps ----------------------------------
+
+// spacing1 keeps pixel positions at least gap apart once the samples of a
+// fractional gap are rounded to the pixel grid. Cells are gap wide, so each holds
+// at most one position and only the neighbouring cells need to be checked.
+type spacing1 struct {
+	gap   float32
+	cells []int32 // position+1 in each cell, 0 if empty
+}
+
+// newSpacing1 returns the spacing of a fractional gap, or nil for an integer gap
+func newSpacing1(w int, gap float32) *spacing1 {
+	if gap == float32(math.Trunc(float64(gap))) {
+		return nil
+	}
+	return &spacing1{gap: gap, cells: make([]int32, int(float32(w)/gap)+1)}
+}
+
+// accept records the position and returns true if it is far enough from the others
+func (s *spacing1) accept(x int) bool {
+	c := int(float32(x) / s.gap)
+	for i := max(c-1, 0); i <= min(c+1, len(s.cells)-1); i++ {
+		if p := s.cells[i]; p != 0 && abs(float32(int(p)-1-x)) < s.gap {
+			return false
+		}
+	}
+
+	s.cells[c] = int32(x + 1)
+	return true
+}
+
+// spacing2 is the 2D counterpart of spacing1. Cells are gap/√2 wide so that each
+// holds at most one position, and positions closer than gap are within 2 cells.
+type spacing2 struct {
+	gap, cell  float32
+	cols, rows int
+	cells      [][2]int32 // position+1 in each cell, 0 if empty
+}
+
+// newSpacing2 returns the spacing of a fractional gap, or nil for an integer gap
+func newSpacing2(w, h int, gap float32) *spacing2 {
+	if gap == float32(math.Trunc(float64(gap))) {
+		return nil
+	}
+
+	cell := gap / math.Sqrt2
+	cols, rows := int(float32(w)/cell)+1, int(float32(h)/cell)+1
+	return &spacing2{gap: gap, cell: cell, cols: cols, rows: rows, cells: make([][2]int32, cols*rows)}
+}
+
+// accept records the position and returns true if it is far enough from the others
+func (s *spacing2) accept(x, y int) bool {
+	cx, cy := int(float32(x)/s.cell), int(float32(y)/s.cell)
+	for gy := max(cy-2, 0); gy <= min(cy+2, s.rows-1); gy++ {
+		for gx := max(cx-2, 0); gx <= min(cx+2, s.cols-1); gx++ {
+			p := s.cells[gy*s.cols+gx]
+			if p[0] == 0 {
+				continue
+			}
+
+			dx, dy := float32(int(p[0])-1-x), float32(int(p[1])-1-y)
+			if float32(dx*dx)+dy*dy < s.gap*s.gap {
+				return false
+			}
+		}
+	}
+
+	s.cells[cy*s.cols+cx] = [2]int32{int32(x + 1), int32(y + 1)}
+	return true
+}
@@ -0,0 +1,86 @@
+package noise
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSSI3(t *testing.T) {
+	pts := Collect(SSI3(1, 6, 4, 5))
+	assert.NotEmpty(t, pts)
+
+	// Every cell is visited once, so there is at most one point per cell
+	cells := make(map[[3]int]bool)
+	closest := float32(1e9)
+	for i, p := range pts {
+		c := [3]int{int(math.Round(float64(p[0]))), int(math.Round(float64(p[1]))), int(math.Round(float64(p[2])))}
+		assert.False(t, cells[c])
+		assert.True(t, abs(float32(c[0])) <= 6 && abs(float32(c[1])) <= 4 && abs(float32(c[2])) <= 5)
+		cells[c] = true
+
+		for _, q := range pts[i+1:] {
+			dx, dy, dz := p[0]-q[0], p[1]-q[1], p[2]-q[2]
+			closest = min(closest, dx*dx+dy*dy+dz*dz)
+		}
+	}
+	assert.GreaterOrEqual(t, closest, float32(1))
+
+	// Center-out and deterministic
+	assert.Less(t, abs(pts[0][0])+abs(pts[0][1])+abs(pts[0][2]), float32(1.5))
+	assert.Equal(t, pts, Collect(SSI3(1, 6, 4, 5)))
+	assert.NotEqual(t, pts, Collect(SSI3(2, 6, 4, 5)))
+	assert.Empty(t, Collect(SSI3(1, 0, 4, 5)))
+}
+
+func TestSparse3(t *testing.T) {
+	const w, h, d, gap = 96, 48, 64, 8
+	pts := Collect(Sparse3(1, w, h, d, gap))
+	assert.Greater(t, len(pts), 50)
+
+	closest := 1 << 30
+	for i, p := range pts {
+		assert.True(t, p[0] >= 0 && p[0] < w && p[1] >= 0 && p[1] < h && p[2] >= 0 && p[2] < d)
+		for _, q := range pts[i+1:] {
+			dx, dy, dz := p[0]-q[0], p[1]-q[1], p[2]-q[2]
+			closest = min(closest, dx*dx+dy*dy+dz*dz)
+		}
+	}
+	assert.GreaterOrEqual(t, closest, gap*gap)
+	assert.Len(t, Collect(Take(Sparse3(1, w, h, d, gap), 5)), 5)
+	assert.Empty(t, Collect(Sparse3(1, w, h, 0, gap)))
+
+	// Fractional gaps keep their spacing, integral ones are unchanged whatever their type
+	pts = Collect(Sparse3(1, 40, 30, 20, 3.5))
+	assert.NotEmpty(t, pts)
+	for i, p := range pts {
+		for _, q := range pts[i+1:] {
+			dx, dy, dz := float32(p[0]-q[0]), float32(p[1]-q[1]), float32(p[2]-q[2])
+			assert.GreaterOrEqual(t, dx*dx+dy*dy+dz*dz, float32(3.5*3.5))
+		}
+	}
+	assert.Equal(t, Collect(Sparse3(1, w, h, d, gap)), Collect(Sparse3(1, w, h, d, float32(gap))))
+}
+
+func TestSparse3Margin(t *testing.T) {
+	const w, h, d, margin = 64, 48, 40, 6
+	pts := Collect(Sparse3Margin(1, w, h, d, 8, margin))
+	assert.NotEmpty(t, pts)
+	for _, p := range pts {
+		assert.True(t, p[0] >= margin && p[0] < w-margin)
+		assert.True(t, p[1] >= margin && p[1] < h-margin)
+		assert.True(t, p[2] >= margin && p[2] < d-margin)
+	}
+
+	assert.Equal(t, Collect(Sparse3(1, w, h, d, 8)), Collect(Sparse3Margin(1, w, h, d, 8, -1)))
+	assert.Empty(t, Collect(Sparse3Margin(1, w, h, d, 8, 20)))
+}
+
+func BenchmarkSSI3(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for range SSI3(uint32(i), 50, 50, 50) {
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"image"
 	"image/color"
 	"image/png"
+	"math"
 	"os"
 	"testing"
 
@@ -132,3 +133,68 @@ func generateSparse1D(width, height int, gen func(func(int) bool)) *image.Gray {
 
 	return img
 }
+
+func TestSparseFractionalGap(t *testing.T) {
+	for _, gap := range []float32{1.5, 2.5, 3.7, 6.25} {
+		pts := Collect(Sparse2(42, 200, 150, gap))
+		assert.NotEmpty(t, pts)
+
+		closest := float32(math.MaxFloat32)
+		for i := range pts {
+			assert.True(t, pts[i][0] >= 0 && pts[i][0] < 200 && pts[i][1] >= 0 && pts[i][1] < 150)
+			for j := i + 1; j < len(pts); j++ {
+				dx, dy := float32(pts[i][0]-pts[j][0]), float32(pts[i][1]-pts[j][1])
+				closest = min(closest, dx*dx+dy*dy)
+			}
+		}
+		assert.GreaterOrEqual(t, closest, gap*gap)
+
+		xs := Collect(Sparse1(42, 500, gap))
+		assert.NotEmpty(t, xs)
+
+		closest = math.MaxFloat32
+		for i := range xs {
+			for j := i + 1; j < len(xs); j++ {
+				closest = min(closest, abs(float32(xs[i]-xs[j])))
+			}
+		}
+		assert.GreaterOrEqual(t, closest, gap)
+	}
+
+	// Finer gaps produce more points
+	assert.Greater(t, len(Collect(Sparse2(42, 200, 200, 2.5))), len(Collect(Sparse2(42, 200, 200, 3))))
+
+	// Integral gaps are unchanged whatever their type
+	assert.Equal(t, Collect(Sparse2(42, 200, 200, 5)), Collect(Sparse2(42, 200, 200, float32(5))))
+	assert.Equal(t, Collect(Sparse1(42, 200, 5)), Collect(Sparse1(42, 200, 5.0)))
+}
+
+func TestSparseMargin(t *testing.T) {
+	const w, h, margin = 300, 200, 20
+	pts := Collect(Sparse2Margin(42, w, h, 12, margin))
+	assert.NotEmpty(t, pts)
+	for _, p := range pts {
+		assert.True(t, p[0] >= margin && p[0] < w-margin)
+		assert.True(t, p[1] >= margin && p[1] < h-margin)
+	}
+
+	// Points still reach the inner edges
+	var lo, hi int = w, 0
+	for _, p := range pts {
+		lo, hi = min(lo, p[0]), max(hi, p[0])
+	}
+	assert.Less(t, lo, margin+12)
+	assert.Greater(t, hi, w-margin-12)
+
+	xs := Collect(Sparse1Margin(42, w, 7.5, margin))
+	assert.NotEmpty(t, xs)
+	for _, x := range xs {
+		assert.True(t, x >= margin && x < w-margin)
+	}
+
+	// Zero margin is a plain fill, and too wide margins leave nothing
+	assert.Equal(t, Collect(Sparse2(42, w, h, 12)), Collect(Sparse2Margin(42, w, h, 12, 0)))
+	assert.Equal(t, Collect(Sparse1(42, w, 12)), Collect(Sparse1Margin(42, w, 12, -5)))
+	assert.Empty(t, Collect(Sparse2Margin(42, w, h, 12, 100)))
+	assert.Empty(t, Collect(Sparse1Margin(42, w, 12, 150)))
+}
@@ -0,0 +1,195 @@
+package noise
+
+import (
+	"iter"
+	"math"
+	"math/bits"
+	"math/cmplx"
+)
+
+// ---------------------------------- Fields ----------------------------------
+
+// Periodogram returns the power spectrum of a size × size field stored in row-major
+// order, where size is a power of two. The mean is removed first, so the power at
+// zero frequency is zero. The result is shifted so that zero frequency sits in the
+// center at (size/2, size/2), which is the layout expected when plotting it.
+func Periodogram(field []float32, size int) []float64 {
+	if size <= 0 || size&(size-1) != 0 || len(field) != size*size {
+		panic("noise: periodogram size must be a power of two matching the field")
+	}
+
+	var mean float64
+	for _, v := range field {
+		mean += float64(v)
+	}
+	mean /= float64(len(field))
+
+	data := make([]complex128, len(field))
+	for i, v := range field {
+		data[i] = complex(float64(v)-mean, 0)
+	}
+	fft2(data, size, false)
+
+	// Normalize so that white noise has a flat spectrum equal to its variance
+	half, norm := size/2, float64(len(field))
+	power := make([]float64, len(field))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			v := data[y*size+x]
+			sx, sy := (x+half)%size, (y+half)%size
+			power[sy*size+sx] = (real(v)*real(v) + imag(v)*imag(v)) / norm
+		}
+	}
+	return power
+}
+
+// RadialSpectrum averages a centered periodogram over rings of equal frequency. The
+// result has size/2 bins, where bin i holds the mean power at i cycles across the
+// field, so it can be plotted directly as a 1D power spectrum.
+func RadialSpectrum(power []float64, size int) []float64 {
+	half := size / 2
+	sum := make([]float64, half)
+	count := make([]int, half)
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			dx, dy := float64(x-half), float64(y-half)
+			if r := int(math.Round(math.Hypot(dx, dy))); r < half {
+				sum[r] += power[y*size+x]
+				count[r]++
+			}
+		}
+	}
+
+	for i := range sum {
+		if count[i] > 0 {
+			sum[i] /= float64(count[i])
+		}
+	}
+	return sum
+}
+
+// FieldSpectrum samples a size × size grid of the source with the given step and
+// returns its radial power spectrum. Smooth noise concentrates its power in the
+// first bins, while white noise spreads it evenly.
+//
+// Example:
+//
+//	spectrum := FieldSpectrum(NewFBM(12345), 256, 0.05)
+//	for i, p := range spectrum {
+//	    fmt.Println(i, p) // frequency, power
+//	}
+func FieldSpectrum(src Source2, size int, step float32) []float64 {
+	field := make([]float32, size*size)
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			field[y*size+x] = src.Eval2(float32(x)*step, float32(y)*step)
+		}
+	}
+	return RadialSpectrum(Periodogram(field, size), size)
+}
+
+// ---------------------------------- Point Sets ----------------------------------
+
+// PointSpectrum returns the radial power spectrum of a point set inside the
+// rectangle [0, w) × [0, h), evaluated at integer frequencies up to size/2 cycles
+// across the rectangle. The power is normalized by the number of points, so a
+// Poisson process such as Scatter2 has a flat spectrum around 1, while blue noise
+// such as SSI2 or Sparse2 has almost no power below its characteristic frequency.
+// Complexity: O(n·size²).
+//
+// Example:
+//
+//	spectrum := PointSpectrum(Sparse2(12345, 256, 256, 8), 256, 256, 128)
+func PointSpectrum[T Number](points iter.Seq[[2]T], w, h float32, size int) []float64 {
+	if size <= 0 || w <= 0 || h <= 0 {
+		panic("noise: point spectrum needs a positive size and area")
+	}
+
+	half := size / 2
+	power := make([]complex128, size*size)
+	ex := make([]complex128, size)
+	ey := make([]complex128, size)
+
+	n := 0
+	for p := range points {
+		x, y := float64(p[0])/float64(w), float64(p[1])/float64(h)
+		for k := 0; k < size; k++ {
+			f := float64(k - half)
+			ex[k] = cmplx.Exp(complex(0, -2*math.Pi*f*x))
+			ey[k] = cmplx.Exp(complex(0, -2*math.Pi*f*y))
+		}
+
+		for ky := 0; ky < size; ky++ {
+			row := power[ky*size : (ky+1)*size]
+			for kx := range row {
+				row[kx] += ex[kx] * ey[ky]
+			}
+		}
+		n++
+	}
+
+	out := make([]float64, len(power))
+	if n == 0 {
+		return RadialSpectrum(out, size)
+	}
+
+	for i, v := range power {
+		out[i] = (real(v)*real(v) + imag(v)*imag(v)) / float64(n)
+	}
+	out[half*size+half] = 0 // the zero frequency only counts the points
+	return RadialSpectrum(out, size)
+}
+
+// ---------------------------------- FFT ----------------------------------
+
+// fft2 computes the in-place 2D discrete Fourier transform of a size × size grid
+func fft2(data []complex128, size int, inverse bool) {
+	for y := 0; y < size; y++ {
+		fft(data[y*size:(y+1)*size], inverse)
+	}
+
+	col := make([]complex128, size)
+	for x := 0; x < size; x++ {
+		for y := 0; y < size; y++ {
+			col[y] = data[y*size+x]
+		}
+		fft(col, inverse)
+		for y := 0; y < size; y++ {
+			data[y*size+x] = col[y]
+		}
+	}
+}
+
+// fft computes the in-place iterative radix-2 Cooley-Tukey transform of a slice
+// whose length is a power of two. The inverse transform is not scaled.
+func fft(data []complex128, inverse bool) {
+	n := len(data)
+	if n <= 1 {
+		return
+	}
+
+	// Bit-reversal permutation
+	shift := 64 - bits.TrailingZeros(uint(n))
+	for i := range data {
+		if j := int(bits.Reverse64(uint64(i)) >> shift); j > i {
+			data[i], data[j] = data[j], data[i]
+		}
+	}
+
+	sign := -1.0
+	if inverse {
+		sign = 1
+	}
+
+	for length := 2; length <= n; length <<= 1 {
+		step := cmplx.Exp(complex(0, sign*2*math.Pi/float64(length)))
+		for i := 0; i < n; i += length {
+			w := complex(1, 0)
+			for k := 0; k < length/2; k++ {
+				a, b := data[i+k], data[i+k+length/2]*w
+				data[i+k], data[i+k+length/2] = a+b, a-b
+				w *= step
+			}
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package noise
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFFT(t *testing.T) {
+	data := make([]complex128, 16)
+	for i := range data {
+		data[i] = complex(Float64(1, uint64(i)), 0)
+	}
+
+	// Compare against a naive DFT
+	want := make([]complex128, len(data))
+	for k := range want {
+		for j, v := range data {
+			want[k] += v * cmplx.Exp(complex(0, -2*math.Pi*float64(j*k)/16))
+		}
+	}
+
+	got := append([]complex128(nil), data...)
+	fft(got, false)
+	for i := range got {
+		assert.InDelta(t, real(want[i]), real(got[i]), 1e-9)
+		assert.InDelta(t, imag(want[i]), imag(got[i]), 1e-9)
+	}
+
+	// Inverse transform restores the input scaled by n
+	fft(got, true)
+	for i := range got {
+		assert.InDelta(t, real(data[i]), real(got[i])/16, 1e-9)
+	}
+}
+
+func TestPeriodogram(t *testing.T) {
+	const size = 64
+	field := make([]float32, size*size)
+	for i := range field {
+		field[i] = Float32(1, uint64(i))
+	}
+
+	power := Periodogram(field, size)
+	assert.Len(t, power, size*size)
+	assert.Equal(t, 0.0, math.Round(power[size/2*size+size/2]*1e9))
+
+	// White noise is flat at its variance, 1/12 for a uniform distribution
+	spectrum := RadialSpectrum(power, size)
+	assert.Len(t, spectrum, size/2)
+	for _, p := range spectrum[4:] {
+		assert.InDelta(t, 1.0/12, p, 0.04)
+	}
+
+	assert.Panics(t, func() { Periodogram(field, 63) })
+	assert.Panics(t, func() { Periodogram(field[:10], size) })
+}
+
+func TestFieldSpectrum(t *testing.T) {
+	spectrum := FieldSpectrum(NewSimplex(1), 128, 0.1)
+
+	// Smooth noise concentrates its power at low frequencies
+	var low, high float64
+	for _, p := range spectrum[1:8] {
+		low += p
+	}
+	for _, p := range spectrum[40:] {
+		high += p
+	}
+	assert.Greater(t, low, 100*high)
+}
+
+func TestPointSpectrum(t *testing.T) {
+	white := PointSpectrum(Scatter2(1, 128, 128, func(x, y int) float32 {
+		return 0.1
+	}), 128, 128, 64)
+	blue := PointSpectrum(Sparse2(1, 128, 128, 4), 128, 128, 64)
+	assert.Len(t, white, 32)
+	assert.Len(t, blue, 32)
+
+	// Poisson points are white, blue noise has little low frequency power
+	for i := 1; i < 10; i++ {
+		assert.InDelta(t, 1, white[i], 0.5)
+		assert.Less(t, blue[i], 0.5)
+	}
+
+	empty := PointSpectrum(Sparse2(1, 0, 0, 4), 1, 1, 8)
+	assert.Equal(t, []float64{0, 0, 0, 0}, empty)
+	assert.Panics(t, func() { PointSpectrum(Sparse2(1, 8, 8, 4), 0, 8, 8) })
+}
+
+func BenchmarkSpectrum(b *testing.B) {
+	field := make([]float32, 256*256)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Periodogram(field, 256)
+	}
+}
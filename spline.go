@@ -0,0 +1,97 @@
+package noise
+
+import "sort"
+
+// ---------------------------------- Spline ----------------------------------
+
+// Spline is a piecewise cubic Hermite curve used to remap noise values, for example
+// to shape continentalness, erosion or peaks into terrain height the way modern
+// voxel games do. Each control point has a location, a value and a slope; between
+// points the curve is a cubic that matches both values and slopes, and beyond the
+// first and last points it is extended linearly along their slopes.
+type Spline struct {
+	points []splinePoint // sorted by location
+}
+
+// splinePoint is a control point of a spline
+type splinePoint struct {
+	x, y, slope float32
+}
+
+// NewSpline creates an empty spline, to be built with Point
+//
+// Example:
+//
+//	height := NewSpline().
+//	    Point(-1, -0.8, 0).   // deep ocean
+//	    Point(-0.2, -0.1, 1). // coast
+//	    Point(0.5, 0.6, 0.5). // hills
+//	    Point(1, 1, 0)        // peaks
+//	y := height.Eval(continents.Eval2(x, z))
+func NewSpline() *Spline {
+	return new(Spline)
+}
+
+// Point adds a control point at location x with value y and the given slope, and
+// returns the spline for chaining. Points may be added in any order, but adding a
+// second point at the same location panics.
+func (s *Spline) Point(x, y, slope float32) *Spline {
+	i := sort.Search(len(s.points), func(i int) bool { return s.points[i].x >= x })
+	if i < len(s.points) && s.points[i].x == x {
+		panic("noise: spline already has a point at this location")
+	}
+
+	s.points = append(s.points, splinePoint{})
+	copy(s.points[i+1:], s.points[i:])
+	s.points[i] = splinePoint{x: x, y: y, slope: slope}
+	return s
+}
+
+// Eval maps a value through the spline. An empty spline returns v unchanged.
+func (s *Spline) Eval(v float32) float32 {
+	n := len(s.points)
+	switch {
+	case n == 0:
+		return v
+	case v <= s.points[0].x:
+		p := s.points[0]
+		return p.y + float32(p.slope*(v-p.x))
+	case v >= s.points[n-1].x:
+		p := s.points[n-1]
+		return p.y + float32(p.slope*(v-p.x))
+	}
+
+	// Find the segment [p0, p1] containing v
+	i := sort.Search(n, func(i int) bool { return s.points[i].x > v })
+	p0, p1 := s.points[i-1], s.points[i]
+
+	// Cubic Hermite in the form lerp(t, y0, y1) + t(1-t)·lerp(t, a, b)
+	h := p1.x - p0.x
+	t := (v - p0.x) / h
+	dy := p1.y - p0.y
+	a := float32(p0.slope*h) - dy
+	b := dy - float32(p1.slope*h)
+	return lerp(p0.y, p1.y, t) + float32(float32(t*(1-t))*lerp(a, b, t))
+}
+
+// Curve returns a source that maps the output of src through the spline, so the
+// remapping can be used as a module of a pipeline.
+func Curve(src Source, spline *Spline) Source {
+	return &curve{src: src, spline: spline}
+}
+
+// curve remaps a source through a spline
+type curve struct {
+	src    Source
+	spline *Spline
+}
+
+// Eval2 evaluates the remapped source in 2D
+func (c *curve) Eval2(x, y float32) float32 {
+	return c.spline.Eval(c.src.Eval2(x, y))
+}
+
+// Eval3 evaluates the remapped source in 3D
+func (c *curve) Eval3(x, y, z float32) float32 {
+	return c.spline.Eval(c.src.Eval3(x, y, z))
+}
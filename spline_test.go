@@ -0,0 +1,51 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpline(t *testing.T) {
+	s := NewSpline().
+		Point(1, 1, 0).
+		Point(-1, -0.8, 0).
+		Point(0, 0.2, 1)
+
+	// Passes through the control points
+	assert.Equal(t, float32(-0.8), s.Eval(-1))
+	assert.Equal(t, float32(0.2), s.Eval(0))
+	assert.Equal(t, float32(1), s.Eval(1))
+
+	// Extends linearly along the end slopes
+	assert.Equal(t, float32(-0.8), s.Eval(-5))
+	assert.Equal(t, float32(1), s.Eval(5))
+
+	// Matches the slope at a control point
+	const d = 1e-3
+	assert.InDelta(t, 1, (s.Eval(d)-s.Eval(-d))/(2*d), 1e-2)
+
+	// Monotonic between increasing points with non-negative slopes
+	prev := s.Eval(-1)
+	for i := 0; i <= 200; i++ {
+		v := s.Eval(-1 + float32(i)*0.01)
+		assert.GreaterOrEqual(t, v, prev)
+		prev = v
+	}
+}
+
+func TestSplineEdgeCases(t *testing.T) {
+	assert.Equal(t, float32(0.3), NewSpline().Eval(0.3))
+	assert.Equal(t, float32(2), NewSpline().Point(0, 1, 1).Eval(1))
+	assert.Panics(t, func() {
+		NewSpline().Point(0, 1, 0).Point(0, 2, 0)
+	})
+}
+
+func TestCurve(t *testing.T) {
+	s := NewSimplex(42)
+	sp := NewSpline().Point(-1, 0, 0).Point(1, 1, 0)
+	c := Curve(s, sp)
+	assert.Equal(t, sp.Eval(s.Eval2(1.3, 2.7)), c.Eval2(1.3, 2.7))
+	assert.Equal(t, sp.Eval(s.Eval3(1.3, 2.7, 3.1)), c.Eval3(1.3, 2.7, 3.1))
+}
@@ -0,0 +1,121 @@
+package noise
+
+import (
+	"iter"
+	"math"
+)
+
+// ---------------------------------- Stamps ----------------------------------
+
+// Profile is the height of a radial feature at a distance r from its center, in
+// units of its radius. Profiles are evaluated for r in [0, 2], so that the ring
+// between 1 and 2 can hold ejecta or a skirt, and should reach 0 at r = 2.
+type Profile func(r float32) float32
+
+// Crater returns the profile of an impact crater: a bowl of the given depth below the
+// ground inside the radius, surrounded by a raised rim which slopes back down to the
+// ground at twice the radius.
+func Crater(depth, rim float32) Profile {
+	return func(r float32) float32 {
+		if r < 1 {
+			return lerp(-depth, rim, float32(r*r))
+		}
+		return float32(rim * (1 - fade(min(r-1, 1))))
+	}
+}
+
+// Cone returns the profile of a volcano of the given height, whose slopes fall off
+// linearly to the radius, with a crater at its summit of the given relative size.
+func Cone(height, crater float32) Profile {
+	return func(r float32) float32 {
+		switch {
+		case r >= 1:
+			return 0
+		case r >= crater:
+			return float32(height * (1 - r))
+		default:
+			return float32(height * (1 - crater - float32((crater-r)*0.5)))
+		}
+	}
+}
+
+// Mesa returns the profile of a flat-topped hill of the given height, whose cliffs
+// take up the given relative width at its edge.
+func Mesa(height, edge float32) Profile {
+	return func(r float32) float32 {
+		t := min(max((r-1+edge)/max(edge, 1e-6), 0), 1)
+		return float32(height * (1 - fade(t)))
+	}
+}
+
+// StampBlend is how a stamp is combined with the heightmap
+type StampBlend int
+
+// Supported blend modes
+const (
+	BlendAdd StampBlend = iota // Add the profile to the terrain, for craters
+	BlendMax                   // Raise the terrain to the profile above the ground, for hills
+)
+
+// Stamp is a radial feature which can be stamped into a heightmap
+type Stamp struct {
+	Profile   Profile    // Shape of the feature
+	Radius    float32    // Radius of the feature in cells
+	Variation float32    // Relative random variation of the radius and height, in [0, 1)
+	Blend     StampBlend // How the feature is combined with the terrain
+}
+
+// ApplyStamp stamps the feature into a w×h heightmap in place, centered on each of
+// the points, such as those of Sparse2 or SSI2. Every stamp varies its radius and
+// height by up to the variation, keyed by the seed and its position, so that the
+// result does not depend on the order of the points. With BlendMax, the profile
+// sits on the ground level at the center of the stamp.
+//
+// Example:
+//
+//	craters := Stamp{Profile: Crater(8, 2), Radius: 12, Variation: 0.5}
+//	ApplyStamp(12345, heights, 512, 512, craters, Sparse2(12345, 512, 512, 40))
+func ApplyStamp[T Number](seed uint32, heights []float32, w, h int, stamp Stamp, points iter.Seq[[2]T]) {
+	if w <= 0 || h <= 0 || len(heights) < w*h || stamp.Profile == nil {
+		return
+	}
+
+	for p := range points {
+		cx, cy := float32(p[0]), float32(p[1])
+		key := uint64(math.Float32bits(cx))<<32 | uint64(math.Float32bits(cy))
+		radius := float32(stamp.Radius * (1 + float32(stamp.Variation*(Float32(seed, key)*2-1))))
+		amp := 1 + float32(stamp.Variation*(Float32(seed^1, key)*2-1))
+		if radius <= 0 {
+			continue
+		}
+
+		var base float32
+		if stamp.Blend == BlendMax {
+			ix, iy := min(max(int(cx), 0), w-1), min(max(int(cy), 0), h-1)
+			base = heights[iy*w+ix]
+		}
+
+		// Visit the cells within twice the radius, where the profile is defined
+		x0, x1 := max(int(cx-2*radius), 0), min(int(cx+2*radius)+1, w-1)
+		y0, y1 := max(int(cy-2*radius), 0), min(int(cy+2*radius)+1, h-1)
+		for y := y0; y <= y1; y++ {
+			for x := x0; x <= x1; x++ {
+				dx, dy := float32(x)-cx, float32(y)-cy
+				r := float32(math.Sqrt(float64(float32(dx*dx)+float32(dy*dy)))) / radius
+				if r > 2 {
+					continue
+				}
+
+				v := float32(stamp.Profile(r) * amp)
+				switch i := y*w + x; stamp.Blend {
+				case BlendMax:
+					if v > 0 {
+						heights[i] = max(heights[i], base+v)
+					}
+				default:
+					heights[i] += v
+				}
+			}
+		}
+	}
+}
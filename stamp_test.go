@@ -0,0 +1,71 @@
+package noise
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfiles(t *testing.T) {
+	crater := Crater(4, 1)
+	assert.Equal(t, float32(-4), crater(0))
+	assert.Equal(t, float32(1), crater(1))
+	assert.Equal(t, float32(0), crater(2))
+	assert.Less(t, crater(0.5), float32(0))
+
+	cone := Cone(10, 0.2)
+	assert.Equal(t, float32(8), cone(0.2))
+	assert.Equal(t, float32(7), cone(0))
+	assert.Equal(t, float32(0), cone(1))
+	assert.Equal(t, float32(5), cone(0.5))
+
+	mesa := Mesa(3, 0.2)
+	assert.Equal(t, float32(3), mesa(0.5))
+	assert.Equal(t, float32(0), mesa(1))
+	assert.Equal(t, float32(0), mesa(1.5))
+}
+
+func TestApplyStamp(t *testing.T) {
+	const w, h = 64, 64
+	heights := make([]float32, w*h)
+	ApplyStamp(1, heights, w, h, Stamp{Profile: Crater(4, 1), Radius: 8}, slices.Values([][2]int{{32, 32}}))
+	assert.Equal(t, float32(-4), heights[32*w+32])
+	assert.Equal(t, float32(1), heights[32*w+40])
+	assert.Equal(t, float32(0), heights[32*w+48])
+	assert.Equal(t, float32(0), heights[0])
+
+	// Hills sit on the ground at their center and never lower the terrain
+	for i := range heights {
+		heights[i] = 10
+	}
+	heights[0] = 50
+	ApplyStamp(1, heights, w, h, Stamp{Profile: Mesa(5, 0.1), Radius: 40, Blend: BlendMax}, slices.Values([][2]int{{32, 32}}))
+	assert.Equal(t, float32(15), heights[32*w+32])
+	assert.Equal(t, float32(50), heights[0])
+}
+
+func TestApplyStampVariation(t *testing.T) {
+	const w, h = 128, 128
+	points := [][2]float32{{20, 20}, {100, 30}, {60, 100}}
+	stamp := Stamp{Profile: Cone(10, 0.1), Radius: 10, Variation: 0.5}
+
+	a := make([]float32, w*h)
+	ApplyStamp(1, a, w, h, stamp, slices.Values(points))
+
+	// The order of the points does not matter, but the seed does
+	b := make([]float32, w*h)
+	slices.Reverse(points)
+	ApplyStamp(1, b, w, h, stamp, slices.Values(points))
+	assert.Equal(t, a, b)
+
+	c := make([]float32, w*h)
+	ApplyStamp(2, c, w, h, stamp, slices.Values(points))
+	assert.NotEqual(t, a, c)
+
+	// Every stamp varies within the bounds
+	for _, p := range points {
+		peak := a[int(p[1])*w+int(p[0])]
+		assert.True(t, peak >= 4.5 && peak <= 13.5)
+	}
+}
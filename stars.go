@@ -0,0 +1,196 @@
+package noise
+
+import (
+	"image/color"
+	"iter"
+	"math"
+)
+
+// ---------------------------------- Star Fields ----------------------------------
+
+// skyDegrees is the area of the celestial sphere, in square degrees
+const skyDegrees = 41252.96
+
+// Star is a single generated star
+type Star struct {
+	Position    [3]float32 // Unit direction on the sky, or (x, y, 0) in a rectangle
+	Magnitude   float32    // Apparent magnitude in [-1.5, 6.5], lower is brighter
+	Temperature float32    // Surface temperature in Kelvin
+	Color       color.RGBA // Color of the star, derived from its temperature
+}
+
+// Stars emits stars uniformly distributed over the celestial sphere, with density
+// being the expected number of stars per square degree. The naked-eye sky has
+// about 0.2 stars per square degree. Magnitudes follow the usual star counts where
+// faint stars vastly outnumber bright ones, and colors follow the black body color
+// of a temperature which is biased towards cool red and orange stars.
+// Deterministic for a given seed. Complexity: O(n).
+//
+// Example:
+//
+//	for s := range Stars(12345, 0.2) {
+//	    dir, mag := s.Position, s.Magnitude
+//	    // draw the star
+//	}
+func Stars(seed uint32, density float32) iter.Seq[Star] {
+	return func(yield func(Star) bool) {
+		count := poisson(seed, float64(density)*skyDegrees, uint64(seed))
+		for k := 0; k < count; k++ {
+			hk := xxhash64(uint64(k), uint64(seed))
+			z := 2*Float64(seed, hk) - 1
+			phi := 2 * math.Pi * Float64(seed^1, hk)
+			r := math.Sqrt(1 - float64(z*z))
+
+			star := newStar(seed, hk)
+			star.Position = [3]float32{float32(r * math.Cos(phi)), float32(r * math.Sin(phi)), float32(z)}
+			if !yield(star) {
+				return
+			}
+		}
+	}
+}
+
+// StarsRect emits stars scattered over the rectangle [0, w) × [0, h), with density
+// being the expected number of stars per unit cell. Positions come from Scatter2 so
+// stars may clump, while magnitudes and colors are drawn as in Stars.
+//
+// Example:
+//
+//	for s := range StarsRect(12345, 1920, 1080, 0.001) {
+//	    x, y := s.Position[0], s.Position[1]
+//	    // draw the star
+//	}
+func StarsRect(seed uint32, w, h int, density float32) iter.Seq[Star] {
+	return func(yield func(Star) bool) {
+		for p := range Scatter2(seed, w, h, func(x, y int) float32 { return density }) {
+			hp := xxhash64(uint64(math.Float32bits(p[0]))|uint64(math.Float32bits(p[1]))<<32, uint64(seed))
+			star := newStar(seed, hp)
+			star.Position = [3]float32{p[0], p[1], 0}
+			if !yield(star) {
+				return
+			}
+		}
+	}
+}
+
+// newStar draws the magnitude and temperature of a star from its hash
+func newStar(seed uint32, h uint64) Star {
+	const faintest, brightest = 6.5, -1.5
+
+	// The number of stars grows by about 10^0.6 per magnitude
+	u := max(Float64(seed^2, h), 1e-12)
+	mag := max(faintest+math.Log10(u)/0.6, brightest)
+
+	// Cool stars are far more common than hot ones
+	t := Float64(seed^3, h)
+	temp := 2500 + float64(27500*t*t*t)
+	return Star{
+		Magnitude:   float32(mag),
+		Temperature: float32(temp),
+		Color:       blackbody(temp),
+	}
+}
+
+// blackbody approximates the color of a black body at the given temperature
+func blackbody(kelvin float64) color.RGBA {
+	t := kelvin / 100
+	channel := func(v float64) uint8 {
+		return uint8(min(max(v, 0), 255))
+	}
+
+	var r, g, b float64
+	if t <= 66 {
+		r = 255
+		g = float64(99.4708025861*math.Log(t)) - 161.1195681661
+	} else {
+		r = 329.698727446 * math.Pow(t-60, -0.1332047592)
+		g = 288.1221695283 * math.Pow(t-60, -0.0755148492)
+	}
+
+	switch {
+	case t >= 66:
+		b = 255
+	case t > 19:
+		b = float64(138.5177312231*math.Log(t-10)) - 305.0447927307
+	}
+
+	return color.RGBA{channel(r), channel(g), channel(b), 255}
+}
+
+// ---------------------------------- Galaxy ----------------------------------
+
+// Galaxy configures the spiral galaxy scatterer. Zero fields take the default
+// values listed below, so Galaxy{} is a sensible starting point.
+type Galaxy struct {
+	Arms      int     // Number of spiral arms, default 2
+	Radius    float32 // Radius of the disk, default 1
+	Twist     float32 // Rotation of the arms from center to edge in radians, default 6
+	Spread    float32 // Angular spread of the stars around an arm in radians, default 0.4
+	Thickness float32 // Thickness of the disk relative to its radius, default 0.02
+	Bulge     float32 // Fraction of stars in the central bulge, default 0.2
+}
+
+// withDefaults returns the options with the zero fields replaced by defaults
+func (o Galaxy) withDefaults() Galaxy {
+	defaults := func(v *float32, d float32) {
+		if *v == 0 {
+			*v = d
+		}
+	}
+
+	if o.Arms == 0 {
+		o.Arms = 2
+	}
+
+	defaults(&o.Radius, 1)
+	defaults(&o.Twist, 6)
+	defaults(&o.Spread, 0.4)
+	defaults(&o.Thickness, 0.02)
+	defaults(&o.Bulge, 0.2)
+	return o
+}
+
+// Scatter emits n stars of a spiral galaxy centered on the origin, with the disk in
+// the xy plane. Disk stars are distributed along logarithmic-looking arms which
+// wind by Twist from the center to the edge, with a gaussian spread that narrows
+// towards the rim, while bulge stars form a gaussian blob at the center.
+// Deterministic for a given seed. Complexity: O(n).
+//
+// Example:
+//
+//	for s := range (Galaxy{Arms: 4}).Scatter(12345, 100000) {
+//	    x, y, z := s.Position[0], s.Position[1], s.Position[2]
+//	    // draw the star
+//	}
+func (g Galaxy) Scatter(seed uint32, n int) iter.Seq[Star] {
+	o := g.withDefaults()
+	return func(yield func(Star) bool) {
+		for k := 0; k < n; k++ {
+			hk := xxhash64(uint64(k), uint64(seed))
+			star := newStar(seed, hk)
+
+			switch {
+			case Float32(seed^4, hk) < o.Bulge:
+				s := float32(o.Radius * 0.15)
+				star.Position = [3]float32{
+					Norm32(seed^5, hk) * s,
+					Norm32(seed^6, hk) * s,
+					Norm32(seed^7, hk) * s * 0.6,
+				}
+			default:
+				r := Float32(seed^5, hk)
+				arm := float32(k%o.Arms) * 2 * math.Pi / float32(o.Arms)
+				theta := arm + float32(o.Twist*r) + float32(Norm32(seed^6, hk)*o.Spread*(1-r/2))
+				star.Position = [3]float32{
+					float32(r*o.Radius) * float32(math.Cos(float64(theta))),
+					float32(r*o.Radius) * float32(math.Sin(float64(theta))),
+					Norm32(seed^7, hk) * float32(o.Thickness*o.Radius),
+				}
+			}
+
+			if !yield(star) {
+				return
+			}
+		}
+	}
+}
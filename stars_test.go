@@ -0,0 +1,61 @@
+package noise
+
+import (
+	"image/color"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStars(t *testing.T) {
+	stars := slices.Collect(Stars(42, 0.1))
+	assert.Equal(t, stars, slices.Collect(Stars(42, 0.1)))
+	assert.InDelta(t, 0.1*skyDegrees, len(stars), 200)
+
+	var faint int
+	for _, s := range stars {
+		p := s.Position
+		assert.InDelta(t, 1, math.Sqrt(float64(p[0]*p[0]+p[1]*p[1]+p[2]*p[2])), 1e-5)
+		assert.True(t, s.Magnitude >= -1.5 && s.Magnitude <= 6.5)
+		assert.True(t, s.Temperature >= 2500 && s.Temperature <= 30000)
+		assert.Equal(t, uint8(255), s.Color.A)
+		if s.Magnitude > 5 {
+			faint++
+		}
+	}
+
+	// Faint stars outnumber bright ones
+	assert.Greater(t, faint, len(stars)/2)
+}
+
+func TestStarsRect(t *testing.T) {
+	stars := slices.Collect(StarsRect(42, 200, 100, 0.05))
+	assert.InDelta(t, 1000, len(stars), 150)
+	for _, s := range stars {
+		assert.True(t, s.Position[0] >= 0 && s.Position[0] < 200)
+		assert.True(t, s.Position[1] >= 0 && s.Position[1] < 100)
+		assert.Equal(t, float32(0), s.Position[2])
+	}
+}
+
+func TestBlackbody(t *testing.T) {
+	red, blue := blackbody(3000), blackbody(20000)
+	assert.Greater(t, red.R, red.B)
+	assert.Greater(t, blue.B, blue.R)
+	assert.Equal(t, color.RGBA{255, 255, 255, 255}, blackbody(6600))
+}
+
+func TestGalaxy(t *testing.T) {
+	stars := slices.Collect(Galaxy{Radius: 10}.Scatter(42, 5000))
+	assert.Len(t, stars, 5000)
+	assert.Equal(t, stars, slices.Collect(Galaxy{Radius: 10}.Scatter(42, 5000)))
+
+	// The disk is flat and within a few radii of the center
+	for _, s := range stars {
+		p := s.Position
+		assert.Less(t, math.Abs(float64(p[2])), 5.0)
+		assert.Less(t, math.Hypot(float64(p[0]), float64(p[1])), 15.0)
+	}
+}
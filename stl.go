@@ -0,0 +1,111 @@
+package noise
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// ---------------------------------- STL Export ----------------------------------
+
+// Solid configures the extrusion of a heightmap into a printable solid
+type Solid struct {
+	Spacing float32 // Distance between two samples in millimetres, default 1
+	Scale   float32 // Millimetres per unit of height, default 1
+	Base    float32 // Thickness of the base under the lowest sample in millimetres, default 2
+}
+
+// withDefaults returns the options with the zero fields replaced by defaults
+func (o Solid) withDefaults() Solid {
+	if o.Spacing <= 0 {
+		o.Spacing = 1
+	}
+	if o.Scale == 0 {
+		o.Scale = 1
+	}
+	if o.Base <= 0 {
+		o.Base = 2
+	}
+	return o
+}
+
+// WriteSTL extrudes a w×h heightmap into a watertight solid and writes it as binary
+// STL, the format read by slicers for 3D printing. The model is z-up and its lowest
+// sample sits Base millimetres above the bed. The terrain is closed by side walls
+// and a flat bottom, whose triangles share every edge with a neighbour so that the
+// mesh is manifold.
+//
+// Example:
+//
+//	err := WriteSTL(file, heights, 256, 256, Solid{Spacing: 0.5, Scale: 20, Base: 3})
+func WriteSTL(dst io.Writer, heights []float32, w, h int, opts Solid) error {
+	if w < 2 || h < 2 || len(heights) < w*h {
+		return fmt.Errorf("noise: heightmap of %d values is too small for a %dx%d solid", len(heights), w, h)
+	}
+
+	opts = opts.withDefaults()
+	lo, _ := bounds(heights[:w*h])
+	top := func(x, y int) [3]float32 {
+		return [3]float32{float32(x) * opts.Spacing, float32(h-1-y) * opts.Spacing, opts.Base + (heights[y*w+x]-lo)*opts.Scale}
+	}
+	bottom := func(x, y int) [3]float32 {
+		return [3]float32{float32(x) * opts.Spacing, float32(h-1-y) * opts.Spacing, 0}
+	}
+
+	// Border of the grid, counter-clockwise seen from above
+	border := make([][2]int, 0, 2*(w+h-2))
+	for x := 0; x < w-1; x++ {
+		border = append(border, [2]int{x, h - 1})
+	}
+	for y := h - 1; y > 0; y-- {
+		border = append(border, [2]int{w - 1, y})
+	}
+	for x := w - 1; x > 0; x-- {
+		border = append(border, [2]int{x, 0})
+	}
+	for y := 0; y < h-1; y++ {
+		border = append(border, [2]int{0, y})
+	}
+
+	buf := bufio.NewWriter(dst)
+	buf.Write(make([]byte, 80))
+	binary.Write(buf, binary.LittleEndian, uint32(2*(w-1)*(h-1)+3*len(border)))
+
+	// Each facet is followed by a zero attribute byte count
+	facet := make([]float32, 12)
+	tri := func(a, b, c [3]float32) {
+		ux, uy, uz := b[0]-a[0], b[1]-a[1], b[2]-a[2]
+		vx, vy, vz := c[0]-a[0], c[1]-a[1], c[2]-a[2]
+		nx, ny, nz := uy*vz-uz*vy, uz*vx-ux*vz, ux*vy-uy*vx
+		if n := float32(math.Sqrt(float64(nx*nx + ny*ny + nz*nz))); n > 0 {
+			nx, ny, nz = nx/n, ny/n, nz/n
+		}
+
+		facet = append(facet[:0], nx, ny, nz)
+		facet = append(facet, a[:]...)
+		facet = append(facet, b[:]...)
+		facet = append(facet, c[:]...)
+		binary.Write(buf, binary.LittleEndian, facet)
+		buf.Write([]byte{0, 0})
+	}
+
+	// Terrain surface
+	for y := 0; y < h-1; y++ {
+		for x := 0; x < w-1; x++ {
+			tri(top(x, y), top(x, y+1), top(x+1, y))
+			tri(top(x+1, y), top(x, y+1), top(x+1, y+1))
+		}
+	}
+
+	// Side walls, and a bottom fanning out from its center
+	center := [3]float32{float32(w-1) * opts.Spacing / 2, float32(h-1) * opts.Spacing / 2, 0}
+	for i, p := range border {
+		q := border[(i+1)%len(border)]
+		tri(bottom(p[0], p[1]), bottom(q[0], q[1]), top(q[0], q[1]))
+		tri(bottom(p[0], p[1]), top(q[0], q[1]), top(p[0], p[1]))
+		tri(center, bottom(q[0], q[1]), bottom(p[0], p[1]))
+	}
+	return buf.Flush()
+}
@@ -0,0 +1,78 @@
+package noise
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// readSTL decodes the facets of a binary STL file
+func readSTL(t *testing.T, data []byte) [][4][3]float32 {
+	r := bytes.NewReader(data[80:])
+	var count uint32
+	assert.NoError(t, binary.Read(r, binary.LittleEndian, &count))
+	assert.Equal(t, 84+50*int(count), len(data))
+
+	facets := make([][4][3]float32, count)
+	for i := range facets {
+		assert.NoError(t, binary.Read(r, binary.LittleEndian, &facets[i]))
+		r.Seek(2, 1)
+	}
+	return facets
+}
+
+func TestWriteSTL(t *testing.T) {
+	const w, h = 5, 4
+	heights := make([]float32, w*h)
+	NewSimplex(42).Fill2D(heights, w, h, 0, 0, 0.3)
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteSTL(&buf, heights, w, h, Solid{Spacing: 2, Scale: 10, Base: 3}))
+	facets := readSTL(t, buf.Bytes())
+	assert.Len(t, facets, 2*(w-1)*(h-1)+3*2*(w+h-2))
+
+	// Every edge is shared with exactly one facet walking it the other way, so
+	// the solid is watertight and consistently oriented
+	edges := map[[2][3]float32]int{}
+	var volume, lowest float64
+	lowest = 1e9
+	for _, f := range facets {
+		a, b, c := f[1], f[2], f[3]
+		edges[[2][3]float32{a, b}]++
+		edges[[2][3]float32{b, c}]++
+		edges[[2][3]float32{c, a}]++
+
+		// Signed volume of the tetrahedron with the origin is positive when outward
+		volume += float64(a[0]*(b[1]*c[2]-b[2]*c[1])-a[1]*(b[0]*c[2]-b[2]*c[0])+a[2]*(b[0]*c[1]-b[1]*c[0])) / 6
+		for _, v := range f[1:] {
+			if v[2] > 0 {
+				lowest = min(lowest, float64(v[2]))
+			}
+		}
+	}
+
+	for e, n := range edges {
+		assert.Equal(t, 1, n)
+		assert.Equal(t, 1, edges[[2][3]float32{e[1], e[0]}])
+	}
+
+	assert.Greater(t, volume, 3.0*8*6)
+	assert.InDelta(t, 3, lowest, 1e-5)
+}
+
+func TestWriteSTLNormals(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, WriteSTL(&buf, make([]float32, 4), 2, 2, Solid{}))
+
+	facets := readSTL(t, buf.Bytes())
+	assert.Equal(t, [3]float32{0, 0, 1}, facets[0][0])
+	assert.Equal(t, [3]float32{0, 0, 1}, facets[1][0])
+	assert.Equal(t, [3]float32{0, -1, 0}, facets[2][0])
+	assert.Equal(t, [3]float32{0, 0, -1}, facets[4][0])
+	assert.Equal(t, [3]float32{0, 1, 2}, facets[0][1]) // the first row is at the back
+
+	assert.Error(t, WriteSTL(&buf, make([]float32, 3), 2, 2, Solid{}))
+	assert.Error(t, WriteSTL(&buf, make([]float32, 3), 3, 1, Solid{}))
+}
@@ -0,0 +1,59 @@
+package noise
+
+import "iter"
+
+// ---------------------------------- Stratified Sampling ----------------------------------
+
+// Jitter2 generates a jittered grid as a streaming iterator: one point placed
+// uniformly at random within each unit cell of [0, nx) × [0, ny), in row-major
+// order. It is much cheaper than hard-core sampling and avoids the clumps of pure
+// random points, which is enough for many scattering and integration tasks.
+// Deterministic for a given seed. Complexity: O(nx·ny).
+//
+// Example:
+//
+//	for p := range Jitter2(12345, 64, 64) {
+//	    x, y := p[0]*8, p[1]*8 // one point per 8×8 pixel block
+//	}
+func Jitter2(seed uint32, nx, ny int) iter.Seq[[2]float32] {
+	return func(yield func([2]float32) bool) {
+		for iy := 0; iy < ny; iy++ {
+			for ix := 0; ix < nx; ix++ {
+				h := xxhash64(uint64(int64(ix))*0x9e3779b97f4a7c15^uint64(int64(iy))*0xc2b2ae3d27d4eb4f, uint64(seed))
+				x := float32(ix) + Float32(seed, h)
+				y := float32(iy) + Float32(seed^1, h)
+				if !yield([2]float32{x, y}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// NRooks generates n points in [0, n) × [0, n) such that every unit column and
+// every unit row holds exactly one point, like rooks on a chessboard that cannot
+// attack each other. Its projections on both axes are perfectly stratified, which
+// makes it a good choice for Monte Carlo integration in few samples.
+// Deterministic for a given seed. Complexity: O(n).
+//
+// Example:
+//
+//	for p := range NRooks(12345, 16) {
+//	    u, v := p[0]/16, p[1]/16 // 16 samples in the unit square
+//	}
+func NRooks(seed uint32, n int) iter.Seq[[2]float32] {
+	return func(yield func([2]float32) bool) {
+		if n <= 0 {
+			return
+		}
+
+		rows := Perm(seed, 0, n)
+		for i, row := range rows {
+			x := float32(i) + Float32(seed, uint64(i))
+			y := float32(row) + Float32(seed^1, uint64(i))
+			if !yield([2]float32{x, y}) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJitter2(t *testing.T) {
+	pts := Collect(Jitter2(1, 16, 8))
+	assert.Len(t, pts, 16*8)
+
+	// One point per cell, in row-major order
+	for i, p := range pts {
+		assert.Equal(t, i%16, int(p[0]))
+		assert.Equal(t, i/16, int(p[1]))
+	}
+
+	assert.Equal(t, pts, Collect(Jitter2(1, 16, 8)))
+	assert.NotEqual(t, pts, Collect(Jitter2(2, 16, 8)))
+	assert.Empty(t, Collect(Jitter2(1, 0, 8)))
+	assert.Len(t, Collect(Take(Jitter2(1, 16, 8), 5)), 5)
+}
+
+func TestNRooks(t *testing.T) {
+	const n = 64
+	pts := Collect(NRooks(1, n))
+	assert.Len(t, pts, n)
+
+	// Exactly one point per column and per row
+	var cols, rows [n]int
+	for _, p := range pts {
+		assert.True(t, p[0] >= 0 && p[0] < n && p[1] >= 0 && p[1] < n)
+		cols[int(p[0])]++
+		rows[int(p[1])]++
+	}
+	for i := 0; i < n; i++ {
+		assert.Equal(t, 1, cols[i])
+		assert.Equal(t, 1, rows[i])
+	}
+
+	assert.Equal(t, pts, Collect(NRooks(1, n)))
+	assert.NotEqual(t, pts, Collect(NRooks(2, n)))
+	assert.Empty(t, Collect(NRooks(1, 0)))
+}
+
+func TestStratifiedIntegration(t *testing.T) {
+	// Integrate x·y over the unit square, whose exact value is 1/4
+	const n = 256
+	var jitter, rooks float64
+	for p := range Jitter2(1, 16, 16) {
+		jitter += float64(p[0]/16) * float64(p[1]/16)
+	}
+	for p := range NRooks(1, n) {
+		rooks += float64(p[0]/n) * float64(p[1]/n)
+	}
+
+	assert.InDelta(t, 0.25, jitter/n, 0.005)
+	assert.InDelta(t, 0.25, rooks/n, 0.02)
+}
@@ -0,0 +1,74 @@
+package noise
+
+// ---------------------------------- Stream ----------------------------------
+
+// Stream is a counter-based random number generator: the i-th value is simply the
+// hash of i, so seeking to any position is O(1) and the values match those of
+// Uint64 and Float64 with the same seed and position as the key. It also
+// implements rand.Source, so it can drive the math/rand/v2 functions.
+// A Stream is not safe for concurrent use, but copies are independent.
+//
+// Example:
+//
+//	s := NewStream(12345)
+//	s.Skip(1_000_000) // jump ahead without generating the values
+//	v := s.NextFloat()
+//	r := rand.New(s)  // use with math/rand/v2
+type Stream struct {
+	seed uint32
+	pos  uint64
+	hash Hasher // nil for the default xxhash
+}
+
+// NewStream creates a stream with the given seed, starting at position 0
+func NewStream(seed uint32) *Stream {
+	return &Stream{seed: seed}
+}
+
+// NewStreamWith creates a stream with the given seed and hash backend, such as
+// WyHash or SplitMix64, starting at position 0
+//
+// Example:
+//
+//	s := NewStreamWith(12345, SplitMix64{}) // the SplitMix64 sequence of seed 12345
+func NewStreamWith(seed uint32, hash Hasher) *Stream {
+	if _, ok := hash.(XXHash); ok {
+		hash = nil
+	}
+	return &Stream{seed: seed, hash: hash}
+}
+
+// Next returns the value at the current position and advances by one
+func (s *Stream) Next() uint64 {
+	v := s.At(s.pos)
+	s.pos++
+	return v
+}
+
+// NextFloat returns a float64 in [0.0, 1.0) at the current position and advances by one
+func (s *Stream) NextFloat() float64 {
+	return float64(s.Next()) / float64(1<<64)
+}
+
+// Uint64 returns the same value as Next, implementing rand.Source
+func (s *Stream) Uint64() uint64 {
+	return s.Next()
+}
+
+// Skip advances the stream by n positions in O(1)
+func (s *Stream) Skip(n uint64) {
+	s.pos += n
+}
+
+// At returns the value at position i, without moving the stream
+func (s *Stream) At(i uint64) uint64 {
+	if s.hash == nil {
+		return xxhash64(i, uint64(s.seed))
+	}
+	return s.hash.Hash64(i, uint64(s.seed))
+}
+
+// Position returns the current position of the stream
+func (s *Stream) Position() uint64 {
+	return s.pos
+}
@@ -0,0 +1,90 @@
+package noise
+
+import (
+	"math/bits"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream(t *testing.T) {
+	s := NewStream(42)
+	a, b := s.Next(), s.Next()
+	assert.NotEqual(t, a, b)
+	assert.Equal(t, a, s.At(0))
+	assert.Equal(t, b, s.At(1))
+	assert.Equal(t, uint64(2), s.Position())
+
+	// Skipping is the same as generating the values
+	s.Skip(1000)
+	skipped := s.Next()
+	seq := NewStream(42)
+	for i := 0; i < 1002; i++ {
+		seq.Next()
+	}
+	assert.Equal(t, seq.Next(), skipped)
+
+	// Floats share the positions of the stream
+	f := NewStream(42)
+	f.Skip(5)
+	assert.Equal(t, Float64(42, 5), f.NextFloat())
+	assert.Equal(t, uint64(6), f.Position())
+}
+
+func TestStreamRand(t *testing.T) {
+	r1 := rand.New(NewStream(7))
+	r2 := rand.New(NewStream(7))
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, r1.IntN(100), r2.IntN(100))
+	}
+
+	var s rand.Source = NewStream(7)
+	assert.Equal(t, Uint64(7, 0), s.Uint64())
+}
+
+func TestStreamWith(t *testing.T) {
+	assert.Equal(t, NewStream(42).Next(), NewStreamWith(42, XXHash{}).Next())
+
+	// SplitMix64 reproduces the reference sequence for seed 0
+	s := NewStreamWith(0, SplitMix64{})
+	assert.Equal(t, uint64(0xe220a8397b1dcdaf), s.Next())
+	assert.Equal(t, uint64(0x6e789e6aa1b965f4), s.Next())
+	assert.Equal(t, uint64(0x06c45d188009454f), s.Next())
+
+	for _, h := range []Hasher{XXHash{}, WyHash{}, SplitMix64{}} {
+		s := NewStreamWith(7, h)
+		seen := make(map[uint64]bool)
+		for i := 0; i < 1000; i++ {
+			v := s.NextFloat()
+			assert.True(t, v >= 0 && v < 1)
+			seen[s.At(uint64(i))] = true
+		}
+		assert.Len(t, seen, 1000)
+		assert.NotEqual(t, s.At(0), NewStreamWith(8, h).At(0))
+	}
+}
+
+func TestHasherAvalanche(t *testing.T) {
+	// Flipping a single input bit flips about half of the output bits
+	for _, h := range []Hasher{XXHash{}, WyHash{}, SplitMix64{}} {
+		var flips int
+		for v := uint64(0); v < 256; v++ {
+			for b := 0; b < 64; b++ {
+				flips += bits.OnesCount64(h.Hash64(v, 42) ^ h.Hash64(v^1<<b, 42))
+			}
+		}
+		assert.InDelta(t, 32, float64(flips)/(256*64), 1)
+	}
+}
+
+func BenchmarkHasher(b *testing.B) {
+	for name, h := range map[string]Hasher{"xxhash": XXHash{}, "wyhash": WyHash{}, "splitmix64": SplitMix64{}} {
+		b.Run(name, func(b *testing.B) {
+			s := NewStreamWith(42, h)
+			for i := 0; i < b.N; i++ {
+				s.Next()
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package noise
+
+import "math"
+
+// ---------------------------------- Terrain Analysis ----------------------------------
+
+// Slope returns the steepness of every cell of a w×h heightmap in degrees, from 0 on
+// flat ground to nearly 90 on cliffs. Spacing is the horizontal distance between two
+// cells, in the same unit as the heights.
+//
+// Example:
+//
+//	slope := Slope(heights, 512, 512, 1)
+//	if slope[y*512+x] < 30 {
+//	    // gentle enough for a tree
+//	}
+func Slope(heights []float32, w, h int, spacing float32) []float32 {
+	return analyze(heights, w, h, func(x, y int) float32 {
+		gx, gy := gradient(heights, w, h, x, y, spacing)
+		return float32(math.Atan(math.Hypot(gx, gy)) * 180 / math.Pi)
+	})
+}
+
+// Aspect returns the compass direction that every cell of a w×h heightmap faces,
+// which is the direction of steepest descent, in degrees clockwise from north. North
+// is towards -y, the top of the map, and east is towards +x. Flat cells are -1.
+func Aspect(heights []float32, w, h int, spacing float32) []float32 {
+	return analyze(heights, w, h, func(x, y int) float32 {
+		gx, gy := gradient(heights, w, h, x, y, spacing)
+		if gx == 0 && gy == 0 {
+			return -1
+		}
+
+		// Downhill is against the gradient, and north is -y
+		deg := math.Atan2(-gx, gy) * 180 / math.Pi
+		if deg < 0 {
+			deg += 360
+		}
+		return float32(deg)
+	})
+}
+
+// Curvature returns the curvature of every cell of a w×h heightmap, the negated
+// Laplacian of the heights. It is positive on convex terrain such as peaks and
+// ridges, negative in concave terrain such as valleys and pits, and zero on planes.
+func Curvature(heights []float32, w, h int, spacing float32) []float32 {
+	at := func(x, y int) float64 {
+		return float64(heights[min(max(y, 0), h-1)*w+min(max(x, 0), w-1)])
+	}
+
+	s2 := float64(spacing) * float64(spacing)
+	return analyze(heights, w, h, func(x, y int) float32 {
+		c := at(x, y)
+		lap := at(x-1, y) + at(x+1, y) + at(x, y-1) + at(x, y+1) - 4*c
+		return float32(-lap / s2)
+	})
+}
+
+// analyze evaluates a function over every cell of a heightmap
+func analyze(heights []float32, w, h int, fn func(x, y int) float32) []float32 {
+	if w <= 0 || h <= 0 || len(heights) < w*h {
+		return nil
+	}
+
+	out := make([]float32, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			out[y*w+x] = fn(x, y)
+		}
+	}
+	return out
+}
+
+// gradient returns the gradient of the heightmap at a cell, using central
+// differences inside and one-sided differences on the border
+func gradient(heights []float32, w, h, x, y int, spacing float32) (gx, gy float64) {
+	x0, x1 := max(x-1, 0), min(x+1, w-1)
+	y0, y1 := max(y-1, 0), min(y+1, h-1)
+	if x1 > x0 {
+		gx = float64(heights[y*w+x1]-heights[y*w+x0]) / (float64(x1-x0) * float64(spacing))
+	}
+	if y1 > y0 {
+		gy = float64(heights[y1*w+x]-heights[y0*w+x]) / (float64(y1-y0) * float64(spacing))
+	}
+	return
+}
@@ -0,0 +1,64 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// plane returns a heightmap rising by dx per cell along x and dy along y
+func plane(w, h int, dx, dy float32) []float32 {
+	out := make([]float32, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			out[y*w+x] = float32(x)*dx + float32(y)*dy
+		}
+	}
+	return out
+}
+
+func TestSlope(t *testing.T) {
+	for _, v := range Slope(plane(8, 8, 1, 0), 8, 8, 1) {
+		assert.InDelta(t, 45, float64(v), 1e-4)
+	}
+	for _, v := range Slope(plane(8, 8, 0, 2), 8, 8, 2) {
+		assert.InDelta(t, 45, float64(v), 1e-4)
+	}
+	for _, v := range Slope(make([]float32, 16), 4, 4, 1) {
+		assert.Equal(t, float32(0), v)
+	}
+	assert.Nil(t, Slope(nil, 4, 4, 1))
+}
+
+func TestAspect(t *testing.T) {
+	for _, tc := range []struct {
+		dx, dy float32
+		want   float64
+	}{
+		{0, 1, 0},    // rising southwards, faces north
+		{-1, 0, 90},  // rising westwards, faces east
+		{0, -1, 180}, // rising northwards, faces south
+		{1, 0, 270},  // rising eastwards, faces west
+		{1, 1, 315},  // faces north-west
+	} {
+		aspect := Aspect(plane(4, 4, tc.dx, tc.dy), 4, 4, 1)
+		assert.InDelta(t, tc.want, float64(aspect[5]), 1e-4)
+	}
+
+	assert.Equal(t, float32(-1), Aspect(make([]float32, 4), 2, 2, 1)[0])
+}
+
+func TestCurvature(t *testing.T) {
+	for _, v := range Curvature(plane(8, 8, 1, 2), 8, 8, 1)[9:14] {
+		assert.InDelta(t, 0, float64(v), 1e-5)
+	}
+
+	// A peak is convex and a pit is concave
+	peak := make([]float32, 9)
+	peak[4] = 1
+	assert.Greater(t, Curvature(peak, 3, 3, 1)[4], float32(0))
+
+	peak[4] = -1
+	assert.Less(t, Curvature(peak, 3, 3, 1)[4], float32(0))
+	assert.Equal(t, float32(1), Curvature(peak, 3, 3, 1)[1]) // the rim of the pit
+}
@@ -0,0 +1,143 @@
+package noise
+
+import "math"
+
+// ---------------------------------- Marble ----------------------------------
+
+// Marble is a marble texture, made of sine bands along the x axis whose phase is
+// displaced by fBM so that the veins meander. The exported fields may be tuned
+// after NewMarble.
+type Marble struct {
+	Frequency  float32 // Number of veins per unit along x, default 1
+	Turbulence float32 // Displacement of the veins by the fBM, default 4
+	Sharpness  float32 // Exponent narrowing the dark veins, default 1
+	fbm        *FBM
+}
+
+// NewMarble creates a marble texture with the given seed
+func NewMarble(seed uint32) *Marble {
+	return &Marble{Frequency: 1, Turbulence: 4, Sharpness: 1, fbm: NewFBM(seed)}
+}
+
+// Eval2 evaluates the texture in 2D, implementing Source2
+func (m *Marble) Eval2(x, y float32) float32 {
+	return m.veins(float32(x*m.Frequency) + float32(m.Turbulence*m.fbm.Eval2(x, y)))
+}
+
+// Eval3 evaluates the texture in 3D, implementing Source3
+func (m *Marble) Eval3(x, y, z float32) float32 {
+	return m.veins(float32(x*m.Frequency) + float32(m.Turbulence*m.fbm.Eval3(x, y, z)))
+}
+
+// veins maps the phase of the bands to [-1, 1], with dark veins at -1
+func (m *Marble) veins(phase float32) float32 {
+	v := float32(math.Abs(math.Sin(float64(phase) * math.Pi)))
+	if m.Sharpness != 1 {
+		v = float32(math.Pow(float64(v), 1/float64(m.Sharpness)))
+	}
+	return v*2 - 1
+}
+
+// ---------------------------------- Wood ----------------------------------
+
+// Wood is a wood texture, made of concentric growth rings around the z axis whose
+// radius is displaced by fBM. The exported fields may be tuned after NewWood.
+type Wood struct {
+	Rings      float32 // Number of rings per unit of radius, default 8
+	Turbulence float32 // Displacement of the rings by the fBM, default 0.1
+	fbm        *FBM
+}
+
+// NewWood creates a wood texture with the given seed
+func NewWood(seed uint32) *Wood {
+	return &Wood{Rings: 8, Turbulence: 0.1, fbm: NewFBM(seed)}
+}
+
+// Eval2 evaluates a cross section of the trunk, implementing Source2
+func (w *Wood) Eval2(x, y float32) float32 {
+	return w.rings(x, y, w.fbm.Eval2(x, y))
+}
+
+// Eval3 evaluates the texture in 3D with the trunk along the z axis, implementing Source3
+func (w *Wood) Eval3(x, y, z float32) float32 {
+	return w.rings(x, y, w.fbm.Eval3(x, y, z))
+}
+
+// rings returns a sawtooth of the displaced distance to the trunk axis in [-1, 1],
+// so each ring brightens slowly and ends with a sharp dark edge
+func (w *Wood) rings(x, y, n float32) float32 {
+	r := float32(math.Sqrt(float64(float32(x*x) + float32(y*y))))
+	d := float32((r + float32(w.Turbulence*n)) * w.Rings)
+	return float32(d-float32(math.Floor(float64(d))))*2 - 1
+}
+
+// ---------------------------------- Clouds ----------------------------------
+
+// Clouds is a cloud texture made of turbulence, the fBM of the absolute value of
+// simplex noise, which is then thresholded by the coverage. The result is -1 in
+// clear sky and up to 1 inside thick clouds. The exported fields may be tuned
+// after NewClouds.
+type Clouds struct {
+	Coverage  float32 // Fraction of the sky covered by clouds in [0, 1], default 0.5
+	Sharpness float32 // Contrast of the cloud edges, default 2
+	fbm       *FBM
+}
+
+// NewClouds creates a cloud texture with the given seed
+func NewClouds(seed uint32) *Clouds {
+	fbm := NewFBMWith(Ridged(NewSimplex(seed)))
+	fbm.Octaves = 6
+	return &Clouds{Coverage: 0.5, Sharpness: 2, fbm: fbm}
+}
+
+// Eval2 evaluates the texture in 2D, implementing Source2
+func (c *Clouds) Eval2(x, y float32) float32 {
+	return c.cover(-c.fbm.Eval2(x, y))
+}
+
+// Eval3 evaluates the texture in 3D, implementing Source3. Using z as time
+// makes the clouds evolve.
+func (c *Clouds) Eval3(x, y, z float32) float32 {
+	return c.cover(-c.fbm.Eval3(x, y, z))
+}
+
+// cover shifts the turbulence by the coverage and applies the contrast
+func (c *Clouds) cover(t float32) float32 {
+	return min(max((t+c.Coverage*2-1)*c.Sharpness, -1), 1)
+}
+
+// ---------------------------------- Fire ----------------------------------
+
+// Fire is an animated fire texture. Flames rise along the y axis from y = 0 and
+// fade out at Height, with turbulence scrolling upwards over time. The result is
+// -1 outside of the flames and up to 1 in their hottest part. The exported fields
+// may be tuned after NewFire.
+type Fire struct {
+	Height     float32 // Height at which the flames die out, default 1
+	Speed      float32 // Speed at which the turbulence rises, in units per second, default 1
+	Turbulence float32 // Strength of the flickering, default 0.6
+	Scale      float32 // Frequency of the turbulence, default 4
+	fbm        *FBM
+}
+
+// NewFire creates a fire texture with the given seed
+func NewFire(seed uint32) *Fire {
+	return &Fire{Height: 1, Speed: 1, Turbulence: 0.6, Scale: 4, fbm: NewFBMWith(Ridged(NewSimplex(seed)))}
+}
+
+// Eval2 evaluates a still frame of the fire, implementing Source2
+func (f *Fire) Eval2(x, y float32) float32 {
+	return f.Eval3(x, y, 0)
+}
+
+// Eval3 evaluates the fire at (x, y) and time t in seconds, implementing Source3
+func (f *Fire) Eval3(x, y, t float32) float32 {
+	if y < 0 {
+		return -1
+	}
+
+	s := f.Scale
+	n := -f.fbm.Eval3(x*s, float32((y-float32(t*f.Speed))*s), float32(t*0.5))
+	heat := 1 - y/f.Height + float32(f.Turbulence*n)
+	return min(max(heat*2-1, -1), 1)
+}
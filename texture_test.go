@@ -0,0 +1,68 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextures(t *testing.T) {
+	for name, src := range map[string]Source{
+		"marble": NewMarble(42),
+		"wood":   NewWood(42),
+		"clouds": NewClouds(42),
+		"fire":   NewFire(42),
+	} {
+		var lo, hi float32 = 1, -1
+		for y := float32(0); y < 2; y += 0.05 {
+			for x := float32(-1); x < 1; x += 0.05 {
+				v2, v3 := src.Eval2(x, y), src.Eval3(x, y, 0.3)
+				assert.True(t, v2 >= -1 && v2 <= 1, name)
+				assert.True(t, v3 >= -1 && v3 <= 1, name)
+				lo, hi = min(lo, v2), max(hi, v2)
+			}
+		}
+
+		// Each texture has some contrast
+		assert.Greater(t, hi-lo, float32(0.5), name)
+	}
+}
+
+func TestMarbleSharpness(t *testing.T) {
+	m := NewMarble(1)
+	soft := m.Eval2(0.3, 0.7)
+	m.Sharpness = 4
+	assert.Greater(t, m.Eval2(0.3, 0.7), soft)
+}
+
+func TestWoodRings(t *testing.T) {
+	w := NewWood(1)
+	w.Turbulence = 0
+	assert.Equal(t, float32(-1), w.Eval2(0, 0))
+	assert.InDelta(t, 0, w.Eval2(1.0/16, 0), 1e-5)
+}
+
+func TestCloudsCoverage(t *testing.T) {
+	c := NewClouds(1)
+	covered := func() (n int) {
+		for y := float32(0); y < 4; y += 0.1 {
+			for x := float32(0); x < 4; x += 0.1 {
+				if c.Eval2(x, y) > -1 {
+					n++
+				}
+			}
+		}
+		return
+	}
+
+	half := covered()
+	c.Coverage = 0.9
+	assert.Greater(t, covered(), half)
+}
+
+func TestFireHeight(t *testing.T) {
+	f := NewFire(1)
+	assert.Equal(t, float32(-1), f.Eval2(0, -0.5))
+	assert.Equal(t, float32(-1), f.Eval3(0, 5, 1))
+	assert.Greater(t, f.Eval3(0, 0.05, 1), f.Eval3(0, 0.95, 1))
+}
@@ -0,0 +1,188 @@
+// Package tileserver serves any noise source as slippy-map PNG tiles, so that web
+// front-ends such as Leaflet or OpenLayers can interactively preview procedurally
+// generated worlds.
+package tileserver
+
+import (
+	"bytes"
+	"container/list"
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kelindar/noise"
+)
+
+// Server is an http.Handler rendering tiles at /{z}/{x}/{y}.png. The tile at zoom 0
+// covers the square [0, Scale) × [0, Scale) of the source's coordinate space, and
+// each zoom level halves the area covered by a tile. Tiles are rendered on demand,
+// deterministically, and the most recently requested ones are kept in memory.
+type Server struct {
+	source noise.Source
+	scale  float32
+	size   int
+	color  func(v float32) color.Color
+
+	mu    sync.Mutex
+	limit int
+	order *list.List               // most recently used tiles first
+	tiles map[string]*list.Element // cached tiles by key
+}
+
+// tile is a rendered tile kept in the cache
+type tile struct {
+	key  string
+	data []byte
+	etag string // digest of the image, which changes along with the source
+}
+
+// New creates a tile server for a noise source, where scale is the extent of the
+// source covered by the single tile at zoom level 0. Tiles are 256 pixels wide,
+// rendered in greyscale and up to 1024 of them are cached.
+//
+// Example:
+//
+//	terrain := noise.NewFBM(12345)
+//	http.Handle("/tiles/", http.StripPrefix("/tiles", tileserver.New(terrain, 16)))
+func New(src noise.Source, scale float32) *Server {
+	return &Server{
+		source: src,
+		scale:  scale,
+		size:   256,
+		color:  grey,
+		limit:  1024,
+		order:  list.New(),
+		tiles:  make(map[string]*list.Element),
+	}
+}
+
+// WithColor sets the function mapping noise values in [-1, 1] to colors
+func (s *Server) WithColor(fn func(v float32) color.Color) *Server {
+	s.color = fn
+	return s
+}
+
+// WithCache sets the maximum number of tiles kept in memory, 0 disables caching
+func (s *Server) WithCache(limit int) *Server {
+	s.limit = max(limit, 0)
+	return s
+}
+
+// ServeHTTP serves a PNG tile at /{z}/{x}/{y}.png
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	z, x, y, ok := parse(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	key := fmt.Sprintf("%d/%d/%d", z, x, y)
+	t, err := s.load(key, func() ([]byte, error) {
+		return s.Render(z, x, y)
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// The URL of a tile stays the same when the source, seed or colors change, so
+	// clients only keep it briefly and revalidate it against the image digest
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.Header().Set("ETag", t.etag)
+	if r.Header.Get("If-None-Match") == t.etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Write(t.data)
+}
+
+// Render renders the tile at zoom z and tile coordinates (x, y) as a PNG image
+func (s *Server) Render(z, x, y int) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, s.size, s.size))
+	span := s.scale / float32(uint64(1)<<z)
+	step := span / float32(s.size)
+	x0, y0 := float32(x)*span, float32(y)*span
+	for py := 0; py < s.size; py++ {
+		for px := 0; px < s.size; px++ {
+			v := s.source.Eval2(x0+float32(px)*step, y0+float32(py)*step)
+			img.Set(px, py, s.color(v))
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// load returns a cached tile or renders and caches it
+func (s *Server) load(key string, render func() ([]byte, error)) (*tile, error) {
+	s.mu.Lock()
+	if e, ok := s.tiles[key]; ok {
+		s.order.MoveToFront(e)
+		s.mu.Unlock()
+		return e.Value.(*tile), nil
+	}
+	s.mu.Unlock()
+
+	// Render outside of the lock, concurrent requests for the same tile are rare
+	// and produce identical output
+	data, err := render()
+	if err != nil {
+		return nil, err
+	}
+
+	h := fnv.New64a()
+	h.Write(data)
+	t := &tile{key: key, data: data, etag: fmt.Sprintf(`"%016x"`, h.Sum64())}
+	if s.limit == 0 {
+		return t, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.tiles[key]; !ok {
+		s.tiles[key] = s.order.PushFront(t)
+	}
+
+	for s.order.Len() > s.limit {
+		last := s.order.Back()
+		s.order.Remove(last)
+		delete(s.tiles, last.Value.(*tile).key)
+	}
+	return t, nil
+}
+
+// parse extracts the tile coordinates of a /{z}/{x}/{y}.png path
+func parse(path string) (z, x, y int, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(parts) != 3 || !strings.HasSuffix(parts[2], ".png") {
+		return 0, 0, 0, false
+	}
+
+	var err [3]error
+	z, err[0] = strconv.Atoi(parts[0])
+	x, err[1] = strconv.Atoi(parts[1])
+	y, err[2] = strconv.Atoi(strings.TrimSuffix(parts[2], ".png"))
+	switch {
+	case err[0] != nil || err[1] != nil || err[2] != nil:
+		return 0, 0, 0, false
+	case z < 0 || z > 30 || x < 0 || y < 0 || x >= 1<<z || y >= 1<<z:
+		return 0, 0, 0, false
+	default:
+		return z, x, y, true
+	}
+}
+
+// grey maps a noise value in [-1, 1] to a shade of grey
+func grey(v float32) color.Color {
+	return color.Gray{Y: uint8(min(max((v+1)/2, 0), 1) * 255)}
+}
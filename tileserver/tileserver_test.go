@@ -0,0 +1,99 @@
+package tileserver
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kelindar/noise"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServeTile(t *testing.T) {
+	s := New(noise.NewSimplex(42), 16)
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest("GET", "/2/1/3.png", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+
+	img, err := png.Decode(bytes.NewReader(w.Body.Bytes()))
+	assert.NoError(t, err)
+	assert.Equal(t, 256, img.Bounds().Dx())
+
+	// Deterministic across servers
+	data, err := New(noise.NewSimplex(42), 16).Render(2, 1, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, data, w.Body.Bytes())
+
+	// Conditional requests
+	r := httptest.NewRequest("GET", "/2/1/3.png", nil)
+	r.Header.Set("If-None-Match", w.Header().Get("ETag"))
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+
+	// Tiles of another seed have another tag, and are only cached briefly
+	other := httptest.NewRecorder()
+	New(noise.NewSimplex(43), 16).ServeHTTP(other, httptest.NewRequest("GET", "/2/1/3.png", nil))
+	assert.NotEqual(t, w.Header().Get("ETag"), other.Header().Get("ETag"))
+	assert.NotContains(t, other.Header().Get("Cache-Control"), "immutable")
+
+	w = httptest.NewRecorder()
+	New(noise.NewSimplex(43), 16).ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestServeInvalid(t *testing.T) {
+	s := New(noise.NewSimplex(42), 16)
+	for _, path := range []string{"/", "/1/2.png", "/a/0/0.png", "/1/0/0.jpg", "/1/2/0.png", "/-1/0/0.png", "/31/0/0.png"} {
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		assert.Equal(t, http.StatusNotFound, w.Code, path)
+	}
+}
+
+func TestCache(t *testing.T) {
+	calls := 0
+	src := noise.SourceFunc(func(x, y, z float32) float32 {
+		calls++
+		return 0
+	})
+
+	s := New(src, 1).WithCache(1)
+	s.size = 1
+
+	get := func(path string) {
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+
+	get("/0/0/0.png")
+	get("/0/0/0.png")
+	assert.Equal(t, 1, calls)
+
+	get("/1/0/0.png")
+	get("/0/0/0.png")
+	assert.Equal(t, 3, calls)
+
+	s.WithCache(0)
+	get("/1/0/0.png")
+	get("/1/0/0.png")
+	assert.Equal(t, 5, calls)
+}
+
+func TestColor(t *testing.T) {
+	red := color.RGBA{255, 0, 0, 255}
+	s := New(noise.NewSimplex(42), 16).WithColor(func(v float32) color.Color { return red })
+	data, err := s.Render(0, 0, 0)
+	assert.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	assert.NoError(t, err)
+	r, g, b, _ := img.At(10, 10).RGBA()
+	assert.Equal(t, []uint32{0xffff, 0, 0}, []uint32{r, g, b})
+}
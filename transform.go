@@ -0,0 +1,96 @@
+package noise
+
+import "math"
+
+// ---------------------------------- Transforms ----------------------------------
+
+// translate offsets the input coordinates of a source
+type translate struct {
+	src        Source
+	dx, dy, dz float32
+}
+
+// Translate returns a source that evaluates src at (x+dx, y+dy, z+dz). Offsetting
+// several sources built from the same seed is an easy way to decorrelate them.
+func Translate(src Source, dx, dy, dz float32) Source {
+	return &translate{src: src, dx: dx, dy: dy, dz: dz}
+}
+
+// Eval2 evaluates the translated source in 2D
+func (t *translate) Eval2(x, y float32) float32 {
+	return t.src.Eval2(x+t.dx, y+t.dy)
+}
+
+// Eval3 evaluates the translated source in 3D
+func (t *translate) Eval3(x, y, z float32) float32 {
+	return t.src.Eval3(x+t.dx, y+t.dy, z+t.dz)
+}
+
+// scale multiplies the input coordinates of a source
+type scale struct {
+	src        Source
+	sx, sy, sz float32
+}
+
+// Scale returns a source that evaluates src at (x*sx, y*sy, z*sz), i.e. with the
+// frequency scaled per axis. Unequal factors stretch the noise anisotropically,
+// for example to elongate dunes or rock strata along one axis.
+func Scale(src Source, sx, sy, sz float32) Source {
+	return &scale{src: src, sx: sx, sy: sy, sz: sz}
+}
+
+// Eval2 evaluates the scaled source in 2D
+func (s *scale) Eval2(x, y float32) float32 {
+	return s.src.Eval2(x*s.sx, y*s.sy)
+}
+
+// Eval3 evaluates the scaled source in 3D
+func (s *scale) Eval3(x, y, z float32) float32 {
+	return s.src.Eval3(x*s.sx, y*s.sy, z*s.sz)
+}
+
+// rotate rotates the input coordinates of a source
+type rotate struct {
+	src    Source
+	angles [3]float32    // rotation around the x, y and z axes, in degrees
+	m      [3][3]float32 // 3D rotation matrix
+	s, c   float32       // sine and cosine of the z angle, for 2D rotation
+}
+
+// Rotate returns a source that evaluates src at the input coordinates rotated
+// around the x, y and z axes by the given angles, in degrees. Rotating the domain
+// hides the axis-aligned artifacts of lattice noise. In 2D, only the rotation
+// around the z axis applies, so the input is rotated within the xy plane.
+func Rotate(src Source, ax, ay, az float32) Source {
+	sx, cx := math.Sincos(float64(ax) * math.Pi / 180)
+	sy, cy := math.Sincos(float64(ay) * math.Pi / 180)
+	sz, cz := math.Sincos(float64(az) * math.Pi / 180)
+
+	// Rz * Ry * Rx, applied to column vectors
+	return &rotate{
+		src:    src,
+		angles: [3]float32{ax, ay, az},
+		m: [3][3]float32{
+			{float32(cz * cy), float32(cz*sy*sx - sz*cx), float32(cz*sy*cx + sz*sx)},
+			{float32(sz * cy), float32(sz*sy*sx + cz*cx), float32(sz*sy*cx - cz*sx)},
+			{float32(-sy), float32(cy * sx), float32(cy * cx)},
+		},
+		s: float32(sz),
+		c: float32(cz),
+	}
+}
+
+// Eval2 evaluates the rotated source in 2D, rotating around the z axis only
+func (r *rotate) Eval2(x, y float32) float32 {
+	return r.src.Eval2(float32(r.c*x)-float32(r.s*y), float32(r.s*x)+float32(r.c*y))
+}
+
+// Eval3 evaluates the rotated source in 3D
+func (r *rotate) Eval3(x, y, z float32) float32 {
+	m := &r.m
+	return r.src.Eval3(
+		float32(m[0][0]*x)+float32(m[0][1]*y)+float32(m[0][2]*z),
+		float32(m[1][0]*x)+float32(m[1][1]*y)+float32(m[1][2]*z),
+		float32(m[2][0]*x)+float32(m[2][1]*y)+float32(m[2][2]*z),
+	)
+}
@@ -0,0 +1,40 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslate(t *testing.T) {
+	s := NewSimplex(42)
+	src := Translate(s, 10, -20, 5)
+	assert.Equal(t, s.Eval2(11.5, -17.5), src.Eval2(1.5, 2.5))
+	assert.Equal(t, s.Eval3(11.5, -17.5, 8.5), src.Eval3(1.5, 2.5, 3.5))
+}
+
+func TestScale(t *testing.T) {
+	s := NewSimplex(42)
+	src := Scale(s, 2, 0.5, 4)
+	assert.Equal(t, s.Eval2(3, 1.25), src.Eval2(1.5, 2.5))
+	assert.Equal(t, s.Eval3(3, 1.25, 14), src.Eval3(1.5, 2.5, 3.5))
+}
+
+func TestRotate(t *testing.T) {
+	probe := SourceFunc(func(x, y, z float32) float32 { return x })
+
+	// A quarter turn around z maps x to y
+	r := Rotate(probe, 0, 0, 90)
+	assert.InDelta(t, -2, r.Eval2(1, 2), 1e-6)
+	assert.InDelta(t, -2, r.Eval3(1, 2, 3), 1e-6)
+
+	// A quarter turn around y maps x to z
+	r = Rotate(probe, 0, 90, 0)
+	assert.InDelta(t, 3, r.Eval3(1, 2, 3), 1e-6)
+
+	// The origin is a fixed point and a zero rotation is the identity
+	s := NewSimplex(42)
+	r = Rotate(s, 30, 45, 60)
+	assert.Equal(t, s.Eval3(0, 0, 0), r.Eval3(0, 0, 0))
+	assert.Equal(t, Rotate(s, 0, 0, 0).Eval2(1.3, 2.7), s.Eval2(1.3, 2.7))
+}
@@ -0,0 +1,93 @@
+package noise
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ---------------------------------- Errors ----------------------------------
+
+// Errors returned by the Try variants, in place of the panics of the regular
+// functions. They are handy when parameters come from config files or user input.
+var (
+	ErrInvalidArgument = errors.New("noise: invalid argument")
+	ErrInvalidRange    = errors.New("noise: invalid range, a > b")
+	ErrDimensions      = errors.New("noise: unsupported number of coordinates")
+)
+
+// TryIntN returns a deterministic int in [0, n) based on x, or an error if n is zero
+func TryIntN(seed uint32, n, x uint64) (int, error) {
+	if n == 0 {
+		return 0, ErrInvalidArgument
+	}
+	return IntN(seed, n, x), nil
+}
+
+// TryIntIn returns a deterministic int in [a, b] (inclusive) based on x, or an
+// error if a > b
+func TryIntIn(seed uint32, a, b int, x uint64) (int, error) {
+	if a > b {
+		return 0, ErrInvalidRange
+	}
+	return IntIn(seed, a, b, x), nil
+}
+
+// TryFloat32In returns a deterministic float32 in [a, b) based on x, or an error
+// if a > b
+func TryFloat32In(seed uint32, a, b float32, x uint64) (float32, error) {
+	if a > b {
+		return 0, ErrInvalidRange
+	}
+	return Float32In(seed, a, b, x), nil
+}
+
+// TryFloat64In returns a deterministic float64 in [a, b) based on x, or an error
+// if a > b
+func TryFloat64In(seed uint32, a, b float64, x uint64) (float64, error) {
+	if a > b {
+		return 0, ErrInvalidRange
+	}
+	return Float64In(seed, a, b, x), nil
+}
+
+// TryEval evaluates simplex noise at 1 to 3 coordinates, or returns an error for
+// any other number of coordinates
+func (s *Simplex) TryEval(coords ...float32) (float32, error) {
+	if len(coords) < 1 || len(coords) > 3 {
+		return 0, ErrDimensions
+	}
+	return s.Eval(coords...), nil
+}
+
+// TryEval evaluates fractal Brownian motion at 1 to 3 coordinates, or returns an
+// error for any other number of coordinates
+func (f *FBM) TryEval(lacunarity, gain float32, octaves int, coords ...float32) (float32, error) {
+	if len(coords) < 1 || len(coords) > 3 {
+		return 0, ErrDimensions
+	}
+	return f.Eval(lacunarity, gain, octaves, coords...), nil
+}
+
+// Try calls fn and turns a panic with a string value into an error wrapping
+// ErrInvalidArgument, for functions without a dedicated Try variant. Functions of
+// this package panic with strings on invalid arguments, but so may fn itself, and
+// such panics are converted the same way. Panics with any other value, such as
+// runtime errors, are propagated unchanged.
+//
+// Example:
+//
+//	tier, err := Try(func() int {
+//	    return Weighted(seed, key, config.Weights)
+//	})
+func Try[T any](fn func() T) (v T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			msg, ok := r.(string)
+			if !ok {
+				panic(r)
+			}
+			err = fmt.Errorf("%w: %s", ErrInvalidArgument, msg)
+		}
+	}()
+	return fn(), nil
+}
@@ -0,0 +1,78 @@
+package noise
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTryBounded(t *testing.T) {
+	v, err := TryIntN(1, 10, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, IntN(1, 10, 5), v)
+
+	_, err = TryIntN(1, 0, 5)
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+
+	i, err := TryIntIn(1, -5, 5, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, IntIn(1, -5, 5, 5), i)
+
+	_, err = TryIntIn(1, 5, -5, 5)
+	assert.True(t, errors.Is(err, ErrInvalidRange))
+
+	f32, err := TryFloat32In(1, 2, 3, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, Float32In(1, 2, 3, 5), f32)
+
+	_, err = TryFloat32In(1, 3, 2, 5)
+	assert.True(t, errors.Is(err, ErrInvalidRange))
+
+	f64, err := TryFloat64In(1, 2, 3, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, Float64In(1, 2, 3, 5), f64)
+
+	_, err = TryFloat64In(1, 3, 2, 5)
+	assert.True(t, errors.Is(err, ErrInvalidRange))
+}
+
+func TestTryEval(t *testing.T) {
+	s := NewSimplex(1)
+	v, err := s.TryEval(1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, s.Eval2(1, 2), v)
+
+	_, err = s.TryEval()
+	assert.True(t, errors.Is(err, ErrDimensions))
+	_, err = s.TryEval(1, 2, 3, 4)
+	assert.True(t, errors.Is(err, ErrDimensions))
+
+	f := NewFBM(1)
+	v, err = f.TryEval(2, 0.5, 4, 1, 2, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, f.Eval3(1, 2, 3), v)
+
+	_, err = f.TryEval(2, 0.5, 4)
+	assert.True(t, errors.Is(err, ErrDimensions))
+}
+
+func TestTry(t *testing.T) {
+	v, err := Try(func() int { return Weighted(1, 2, []float32{1, 2}) })
+	assert.NoError(t, err)
+	assert.Equal(t, Weighted(1, 2, []float32{1, 2}), v)
+
+	_, err = Try(func() int { return Weighted(1, 2, nil) })
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+	assert.ErrorContains(t, err, "Weighted")
+
+	// Any string panic is converted, including those of the caller
+	_, err = Try(func() int { panic("bad config") })
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+	assert.ErrorContains(t, err, "bad config")
+
+	// Other panics are not swallowed
+	assert.Panics(t, func() {
+		Try(func() int { panic(errors.New("boom")) })
+	})
+}
@@ -0,0 +1,72 @@
+package noise
+
+// ---------------------------------- Value Noise ----------------------------------
+
+// Value represents a value noise generator, which smoothly interpolates random
+// values assigned to the integer lattice. It is cheaper than simplex noise but has
+// more visible axis-aligned artifacts, and makes a good basis for fractal noise.
+type Value struct {
+	seed uint32
+}
+
+// NewValue creates a new value noise generator with the given seed
+func NewValue(seed uint32) *Value {
+	return &Value{seed: seed}
+}
+
+// Eval2 evaluates 2D value noise in [-1, 1], implementing Source2
+func (v *Value) Eval2(x, y float32) float32 {
+	ix, iy := floor(x), floor(y)
+	u := fade(x - float32(ix))
+	w := fade(y - float32(iy))
+
+	v00 := v.lattice(ix, iy, 0)
+	v10 := v.lattice(ix+1, iy, 0)
+	v01 := v.lattice(ix, iy+1, 0)
+	v11 := v.lattice(ix+1, iy+1, 0)
+	return lerp(lerp(v00, v10, u), lerp(v01, v11, u), w)
+}
+
+// Eval3 evaluates 3D value noise in [-1, 1], implementing Source3
+func (v *Value) Eval3(x, y, z float32) float32 {
+	ix, iy, iz := floor(x), floor(y), floor(z)
+	u := fade(x - float32(ix))
+	w := fade(y - float32(iy))
+	t := fade(z - float32(iz))
+
+	v000 := v.lattice(ix, iy, iz)
+	v100 := v.lattice(ix+1, iy, iz)
+	v010 := v.lattice(ix, iy+1, iz)
+	v110 := v.lattice(ix+1, iy+1, iz)
+	v001 := v.lattice(ix, iy, iz+1)
+	v101 := v.lattice(ix+1, iy, iz+1)
+	v011 := v.lattice(ix, iy+1, iz+1)
+	v111 := v.lattice(ix+1, iy+1, iz+1)
+	return lerp(
+		lerp(lerp(v000, v100, u), lerp(v010, v110, u), w),
+		lerp(lerp(v001, v101, u), lerp(v011, v111, u), w),
+		t,
+	)
+}
+
+// lattice returns the random value in [-1, 1] of a lattice point
+func (v *Value) lattice(ix, iy, iz int) float32 {
+	return Float32(v.seed, hashCell(ix, iy, iz, v.seed))*2 - 1
+}
+
+// hashCell hashes the integer coordinates of a lattice cell
+func hashCell(ix, iy, iz int, seed uint32) uint64 {
+	h := uint64(int64(ix))*0x9e3779b97f4a7c15 ^ uint64(int64(iy))*0xc2b2ae3d27d4eb4f ^ uint64(int64(iz))*0x165667b19e3779f9
+	return xxhash64(h, uint64(seed))
+}
+
+// fade is the quintic smoothstep 6t^5 - 15t^4 + 10t^3. The explicit conversions
+// prevent fused multiply-adds, so the result is the same on every platform.
+func fade(t float32) float32 {
+	return t * t * t * (float32(t*(float32(t*6)-15)) + 10)
+}
+
+// lerp linearly interpolates between a and b
+func lerp(a, b, t float32) float32 {
+	return a + float32(t*(b-a))
+}
@@ -0,0 +1,30 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValue(t *testing.T) {
+	v := NewValue(42)
+	assert.Equal(t, v.Eval2(1.3, 2.7), NewValue(42).Eval2(1.3, 2.7))
+	assert.NotEqual(t, v.Eval2(1.3, 2.7), NewValue(43).Eval2(1.3, 2.7))
+
+	for i := -50; i < 50; i++ {
+		x := float32(i) * 0.13
+		v2, v3 := v.Eval2(x, -x), v.Eval3(x, -x, 2*x)
+		assert.True(t, v2 >= -1 && v2 <= 1)
+		assert.True(t, v3 >= -1 && v3 <= 1)
+
+		// Continuous across lattice boundaries
+		assert.InDelta(t, v.Eval2(x, -x), v.Eval2(x+0.001, -x), 0.01)
+		assert.InDelta(t, v.Eval3(x, -x, x), v.Eval3(x+0.001, -x, x), 0.01)
+	}
+}
+
+func TestValueLattice(t *testing.T) {
+	v := NewValue(42)
+	assert.Equal(t, v.lattice(3, -4, 0), v.Eval2(3, -4))
+	assert.Equal(t, v.lattice(3, -4, 5), v.Eval3(3, -4, 5))
+}
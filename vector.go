@@ -0,0 +1,57 @@
+package noise
+
+import "sync/atomic"
+
+// ---------------------------------- Vector Noise ----------------------------------
+
+// simplexCache keeps recently used generators of the functional API, so that calls
+// with the same seed do not rebuild the permutation table. Each slot holds one
+// immutable generator and is replaced when a different seed maps to it.
+var simplexCache [64]atomic.Pointer[Simplex]
+
+// simplexOf returns a simplex generator for the seed, reusing a cached one if possible
+func simplexOf(seed uint32) *Simplex {
+	slot := &simplexCache[xxhash64(uint64(seed), 0)%uint64(len(simplexCache))]
+	if s := slot.Load(); s != nil && s.seed == seed {
+		return s
+	}
+
+	s := NewSimplex(seed)
+	slot.Store(s)
+	return s
+}
+
+// component returns the seed of the k-th component of a vector noise
+func component(seed uint32, k uint64) uint32 {
+	return uint32(xxhash64(k, uint64(seed)))
+}
+
+// Vec2Noise returns a 2D vector of simplex noise at (x, y), each component in
+// [-1, 1]. The components come from generators with different seeds so they are
+// decorrelated, which is what wind fields, UV distortion and flow maps need.
+// Generators are cached per seed, so repeated calls are cheap.
+//
+// Example:
+//
+//	d := Vec2Noise(12345, x*0.01, y*0.01)
+//	u, v := x+d[0]*4, y+d[1]*4 // distorted texture coordinates
+func Vec2Noise(seed uint32, x, y float32) [2]float32 {
+	return [2]float32{
+		simplexOf(component(seed, 0)).noise2D(x, y),
+		simplexOf(component(seed, 1)).noise2D(x, y),
+	}
+}
+
+// Vec3Noise returns a 3D vector of simplex noise at (x, y, z), each component in
+// [-1, 1] and decorrelated from the others.
+//
+// Example:
+//
+//	wind := Vec3Noise(12345, x*0.01, y*0.01, t)
+func Vec3Noise(seed uint32, x, y, z float32) [3]float32 {
+	return [3]float32{
+		simplexOf(component(seed, 0)).noise3D(x, y, z),
+		simplexOf(component(seed, 1)).noise3D(x, y, z),
+		simplexOf(component(seed, 2)).noise3D(x, y, z),
+	}
+}
@@ -0,0 +1,53 @@
+package noise
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVecNoise(t *testing.T) {
+	v2 := Vec2Noise(42, 1.3, 2.7)
+	assert.Equal(t, v2, Vec2Noise(42, 1.3, 2.7))
+	assert.NotEqual(t, v2[0], v2[1])
+	assert.Equal(t, NewSimplex(component(42, 0)).Eval2(1.3, 2.7), v2[0])
+
+	v3 := Vec3Noise(42, 1.3, 2.7, 0.4)
+	assert.Equal(t, v3, Vec3Noise(42, 1.3, 2.7, 0.4))
+	assert.Equal(t, NewSimplex(component(42, 2)).Eval3(1.3, 2.7, 0.4), v3[2])
+
+	// Components are decorrelated
+	var dot, xx, yy float64
+	for i := 0; i < 2000; i++ {
+		h := xxhash64(uint64(i), 7)
+		v := Vec2Noise(42, Float32(1, h)*50, Float32(2, h)*50)
+		dot += float64(v[0] * v[1])
+		xx += float64(v[0] * v[0])
+		yy += float64(v[1] * v[1])
+	}
+	assert.Less(t, dot*dot/(xx*yy), 0.05)
+}
+
+func TestSimplexCache(t *testing.T) {
+	assert.Same(t, simplexOf(5), simplexOf(5))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for seed := uint32(0); seed < 200; seed++ {
+				assert.Equal(t, seed, simplexOf(seed).seed)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkVec2Noise(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Vec2Noise(42, float32(i)*0.01, 0.5)
+	}
+}
@@ -0,0 +1,116 @@
+package noise
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelfTest(t *testing.T) {
+	assert.NoError(t, SelfTest())
+}
+
+func TestSelfTestMismatch(t *testing.T) {
+	defer func(v uint64) { golden[Latest][0].digest = v }(golden[Latest][0].digest)
+	golden[Latest][0].digest ^= 1
+	assert.ErrorContains(t, SelfTest(), "simplex2d")
+}
+
+func TestVersion(t *testing.T) {
+	assert.Equal(t, V2, Latest)
+	assert.Equal(t, "v1", V1.String())
+	assert.Equal(t, "v2", V2.String())
+}
+
+func TestVersionFixtures(t *testing.T) {
+	fixtures := map[Version][]struct {
+		x, y, z float32
+		v2, v3  uint32
+	}{
+		V1: {
+			{0.5, 0.5, 0.5, 0x3f1d439e, 0x00000000},
+			{-3.25, 7.75, 1.5, 0x3e90c346, 0x3f097fd7},
+			{100.1, -42.9, 0.3, 0x3dbc3d87, 0xbea63344},
+		},
+		V2: {
+			{0.5, 0.5, 0.5, 0x00000000, 0x00000000},
+			{-3.25, 7.75, 1.5, 0x3e90c33b, 0x3e7a0648},
+			{100.1, -42.9, 0.3, 0x3e3c3930, 0xbf2c982c},
+		},
+	}
+
+	for v, cases := range fixtures {
+		s := NewSimplexVersion(7, v)
+		for _, c := range cases {
+			assert.Equal(t, c.v2, math.Float32bits(s.Eval2(c.x, c.y)), "%s at (%v, %v)", v, c.x, c.y)
+			assert.Equal(t, c.v3, math.Float32bits(s.Eval3(c.x, c.y, c.z)), "%s at (%v, %v, %v)", v, c.x, c.y, c.z)
+		}
+	}
+}
+
+func TestVersionChangesOutput(t *testing.T) {
+	v1, v2 := NewSimplexV1(7), NewSimplexV2(7)
+
+	// The V2 gradient fix alone changes 3D output, given the same table
+	fixed := v1.Clone()
+	fixed.version = uint8(V2)
+	assert.Equal(t, v1.Eval2(-3.25, 7.75), fixed.Eval2(-3.25, 7.75))
+	assert.NotEqual(t, v1.Eval3(-3.25, 7.75, 1.5), fixed.Eval3(-3.25, 7.75, 1.5))
+	assert.NotEqual(t, v1.Eval3(-3.25, 7.75, 1.5), v2.Eval3(-3.25, 7.75, 1.5))
+
+	// The version survives both encodings
+	data, err := v2.MarshalBinary()
+	assert.NoError(t, err)
+	restored := new(Simplex)
+	assert.NoError(t, restored.UnmarshalBinary(data))
+	assert.Equal(t, v2.Eval3(-3.25, 7.75, 1.5), restored.Eval3(-3.25, 7.75, 1.5))
+
+	data, err = MarshalSource(v2)
+	assert.NoError(t, err)
+	src, err := UnmarshalSource(data)
+	assert.NoError(t, err)
+	assert.Equal(t, v2.Eval3(-3.25, 7.75, 1.5), src.(*Simplex).Eval3(-3.25, 7.75, 1.5))
+
+	// A V1 table flagged as V2 is a custom table for JSON
+	_, err = MarshalSource(fixed)
+	assert.ErrorContains(t, err, "custom table")
+}
+
+func TestNewSimplexVersion(t *testing.T) {
+	assert.Equal(t, NewSimplex(1).perm, NewSimplexV1(1).perm)
+	assert.Equal(t, NewSimplexV1(1).perm, NewSimplexVersion(1, V1).perm)
+	assert.Equal(t, NewSimplexV2(1).perm, NewSimplexVersion(1, V2).perm)
+	assert.NotEqual(t, NewSimplexV1(1).perm, NewSimplexV2(1).perm)
+	assert.Panics(t, func() { NewSimplexVersion(1, 0) })
+
+	// Each table is a permutation, duplicated for wrapping
+	var seen [256]bool
+	s := NewSimplexV2(1)
+	for i, v := range s.perm[:256] {
+		assert.False(t, seen[v])
+		assert.Equal(t, v, s.perm[i+256])
+		seen[v] = true
+	}
+}
+
+func TestVersionJSON(t *testing.T) {
+	for _, v := range []Version{V1, V2} {
+		data, err := MarshalSource(NewFBMWith(NewSimplexVersion(7, v)))
+		assert.NoError(t, err)
+
+		src, err := UnmarshalSource(data)
+		assert.NoError(t, err)
+		assert.Equal(t, NewSimplexVersion(7, v).perm, src.(*FBM).simplex.perm)
+	}
+
+	_, err := UnmarshalSource([]byte(`{"type":"simplex","version":9}`))
+	assert.ErrorContains(t, err, "version")
+}
+
+func TestVersionJSONCustomTable(t *testing.T) {
+	s := NewSimplex(7)
+	s.perm[0], s.perm[1] = s.perm[1], s.perm[0]
+	_, err := MarshalSource(s)
+	assert.ErrorContains(t, err, "custom table")
+}
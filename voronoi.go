@@ -0,0 +1,96 @@
+package noise
+
+import "math"
+
+// ---------------------------------- Voronoi Regions ----------------------------------
+
+// Voronoi partitions the plane into regions around randomly placed sites, one per
+// grid cell of the given size. Unlike Cellular which only returns a distance, it
+// reports which region a point belongs to, which is useful for province maps,
+// shattered-rock textures and territory assignment.
+type Voronoi struct {
+	size float32
+	seed uint32
+}
+
+// VoronoiCell describes the region that owns a point
+type VoronoiCell struct {
+	ID     uint64     // Stable identifier of the region
+	Center [2]float32 // Position of the region's site
+	Edge   float32    // Distance from the point to the nearest region border
+}
+
+// NewVoronoi creates a Voronoi region map with the given seed and region size,
+// which is the average spacing between sites.
+//
+// Example:
+//
+//	provinces := NewVoronoi(12345, 64)
+//	cell := provinces.At(100, 200)
+//	owner := cell.ID % 8 // assign one of 8 factions
+func NewVoronoi(seed uint32, size float32) *Voronoi {
+	if size <= 0 {
+		panic("noise: voronoi size must be positive")
+	}
+
+	return &Voronoi{size: size, seed: seed}
+}
+
+// At returns the region that owns (x, y) along with its center and the distance
+// from (x, y) to the region's border.
+func (v *Voronoi) At(x, y float32) VoronoiCell {
+	x, y = x/v.size, y/v.size
+	ix, iy := floor(x), floor(y)
+
+	// Find the nearest site, which owns the point
+	var cell VoronoiCell
+	var nx, ny int
+	best := float32(math.MaxFloat32)
+	for cy := iy - 1; cy <= iy+1; cy++ {
+		for cx := ix - 1; cx <= ix+1; cx++ {
+			px, py, h := v.site(cx, cy)
+			dx, dy := px-x, py-y
+			if d := float32(dx*dx) + float32(dy*dy); d < best {
+				best, nx, ny = d, cx, cy
+				cell.ID, cell.Center = h, [2]float32{px, py}
+			}
+		}
+	}
+
+	// The border with each neighbour is the bisector of the two sites, so the
+	// distance to the border is the projection onto the line joining them.
+	ax, ay := cell.Center[0], cell.Center[1]
+	edge := float32(math.MaxFloat32)
+	for cy := ny - 2; cy <= ny+2; cy++ {
+		for cx := nx - 2; cx <= nx+2; cx++ {
+			if cx == nx && cy == ny {
+				continue
+			}
+
+			px, py, _ := v.site(cx, cy)
+			dx, dy := px-ax, py-ay
+			n := float32(math.Sqrt(float64(float32(dx*dx) + float32(dy*dy))))
+			if n == 0 {
+				continue
+			}
+
+			mx, my := (ax+px)/2-x, (ay+py)/2-y
+			edge = min(edge, (float32(mx*dx)+float32(my*dy))/n)
+		}
+	}
+
+	cell.Center = [2]float32{ax * v.size, ay * v.size}
+	cell.Edge = max(edge, 0) * v.size
+	return cell
+}
+
+// Eval2 returns a flat random value in [-1, 1] per region, implementing Source2
+func (v *Voronoi) Eval2(x, y float32) float32 {
+	return Float32(v.seed^2, v.At(x, y).ID)*2 - 1
+}
+
+// site returns the position of the site in grid cell (cx, cy) and its identifier
+func (v *Voronoi) site(cx, cy int) (float32, float32, uint64) {
+	h := hashCell(cx, cy, 0, v.seed)
+	return float32(cx) + Float32(v.seed, h), float32(cy) + Float32(v.seed^1, h), h
+}
@@ -0,0 +1,85 @@
+package noise
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVoronoi(t *testing.T) {
+	v := NewVoronoi(42, 16)
+	assert.Equal(t, v.At(10, 20), NewVoronoi(42, 16).At(10, 20))
+	assert.Panics(t, func() { NewVoronoi(42, 0) })
+
+	ids := make(map[uint64][2]float32)
+	for y := float32(0); y < 128; y += 1.5 {
+		for x := float32(0); x < 128; x += 1.5 {
+			c := v.At(x, y)
+			assert.GreaterOrEqual(t, c.Edge, float32(0))
+
+			// Each region has a single, stable center which is the closest site
+			if center, ok := ids[c.ID]; ok {
+				assert.Equal(t, center, c.Center)
+			}
+			ids[c.ID] = c.Center
+
+			// Eval2 is flat within a region
+			assert.Equal(t, Float32(42^2, c.ID)*2-1, v.Eval2(x, y))
+		}
+	}
+
+	assert.Greater(t, len(ids), 32)
+}
+
+func TestVoronoiEdge(t *testing.T) {
+	v := NewVoronoi(7, 10)
+	c := v.At(50, 50)
+
+	// Stepping by less than the edge distance stays within the same region
+	step := c.Edge * 0.9
+	for _, d := range [][2]float32{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+		assert.Equal(t, c.ID, v.At(50+d[0]*step, 50+d[1]*step).ID)
+	}
+
+	// The site itself is owned by its region
+	assert.Equal(t, c.ID, v.At(c.Center[0], c.Center[1]).ID)
+}
+
+func TestVoronoiValueIndependent(t *testing.T) {
+	v := NewVoronoi(42, 1)
+
+	// Region values must not follow the position of their site within its cell
+	var values, xs, ys []float64
+	for cy := 0; cy < 64; cy++ {
+		for cx := 0; cx < 64; cx++ {
+			px, py, _ := v.site(cx, cy)
+			values = append(values, float64(v.Eval2(px, py)))
+			xs = append(xs, float64(px)-float64(cx))
+			ys = append(ys, float64(py)-float64(cy))
+		}
+	}
+
+	assert.Less(t, math.Abs(correlation(values, xs)), 0.1)
+	assert.Less(t, math.Abs(correlation(values, ys)), 0.1)
+}
+
+// correlation returns the Pearson correlation coefficient of two samples
+func correlation(a, b []float64) float64 {
+	var ma, mb float64
+	for i := range a {
+		ma += a[i]
+		mb += b[i]
+	}
+	ma /= float64(len(a))
+	mb /= float64(len(b))
+
+	var cov, va, vb float64
+	for i := range a {
+		da, db := a[i]-ma, b[i]-mb
+		cov += da * db
+		va += da * da
+		vb += db * db
+	}
+	return cov / math.Sqrt(va*vb)
+}
@@ -0,0 +1,65 @@
+package noise
+
+// ---------------------------------- Wander ----------------------------------
+
+// Wander is a smooth, bounded 1D random signal over time, the building block of
+// camera shake, bobbing and idle animations. It sums octaves of 1D gradient noise
+// and returns the analytic derivative along with the value, so velocities can be
+// derived without finite differences. The exported fields may be tuned after
+// NewWander.
+type Wander struct {
+	Frequency float32 // Number of oscillations per unit of time, default 1
+	Amplitude float32 // Maximum absolute value of the signal, default 1
+	Octaves   int     // Number of octaves, each at twice the frequency and half the amplitude, default 2
+	seed      uint32
+}
+
+// NewWander creates a 1D random signal with the given seed
+//
+// Example:
+//
+//	sway := NewWander(12345)
+//	sway.Frequency = 0.5
+//	angle, velocity := sway.At(elapsed)
+func NewWander(seed uint32) *Wander {
+	return &Wander{Frequency: 1, Amplitude: 1, Octaves: 2, seed: seed}
+}
+
+// At returns the value of the signal at time t, within [-Amplitude, Amplitude],
+// and its derivative with respect to t.
+func (w *Wander) At(t float32) (value, derivative float32) {
+	if w.Octaves <= 0 {
+		return 0, 0
+	}
+
+	var sum, slope, total float32
+	amp, freq := float32(1), w.Frequency
+	for o := 0; o < w.Octaves; o++ {
+		v, d := w.gradient(float32(t*freq), uint64(o))
+		sum += float32(amp * v)
+		slope += float32(amp * float32(d*freq))
+		total += amp
+		amp *= 0.5
+		freq *= 2
+	}
+
+	return float32(sum*w.Amplitude) / total, float32(slope*w.Amplitude) / total
+}
+
+// gradient evaluates 1D gradient noise in [-1, 1] and its derivative. The noise is
+// zero at integer positions and blends the ramps of the two surrounding gradients.
+func (w *Wander) gradient(x float32, octave uint64) (float32, float32) {
+	i := floor(x)
+	f := x - float32(i)
+	g0 := Float32(w.seed, hashCell(i, 0, 0, w.seed)^octave)*2 - 1
+	g1 := Float32(w.seed, hashCell(i+1, 0, 0, w.seed)^octave)*2 - 1
+
+	// v = a + s·(b - a) where a = g0·f, b = g1·(f - 1) and s is the quintic fade,
+	// scaled by 2 since 1D gradient noise peaks at 0.5
+	a, b := float32(g0*f), float32(g1*(f-1))
+	s := fade(f)
+	ds := float32(30*f*f) * float32((f-1)*(f-1))
+	value := lerp(a, b, s)
+	slope := g0 + float32(ds*(b-a)) + float32(s*(g1-g0))
+	return value * 2, slope * 2
+}
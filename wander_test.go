@@ -0,0 +1,48 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWander(t *testing.T) {
+	w := NewWander(42)
+	w.Amplitude = 3
+	v, d := w.At(1.7)
+	v2, d2 := NewWander(42).At(1.7)
+	assert.InDelta(t, v, v2*3, 1e-5)
+	assert.InDelta(t, d, d2*3, 1e-5)
+
+	var lo, hi float32
+	for i := 0; i < 10000; i++ {
+		v, _ := w.At(float32(i) * 0.013)
+		lo, hi = min(lo, v), max(hi, v)
+	}
+
+	assert.True(t, lo >= -3 && hi <= 3, "range [%v, %v]", lo, hi)
+	assert.Greater(t, hi-lo, float32(2))
+}
+
+func TestWanderDerivative(t *testing.T) {
+	w := NewWander(7)
+	w.Frequency = 2.5
+	w.Octaves = 3
+
+	const e = 1e-3
+	for i := 0; i < 200; i++ {
+		x := float32(i)*0.037 + 0.01
+		lo, _ := w.At(x - e)
+		hi, _ := w.At(x + e)
+		_, d := w.At(x)
+		assert.InDelta(t, (hi-lo)/(2*e), d, float64(0.05*max(1, abs(d))))
+	}
+}
+
+func TestWanderZero(t *testing.T) {
+	w := NewWander(1)
+	w.Octaves = 0
+	v, d := w.At(1)
+	assert.Equal(t, float32(0), v)
+	assert.Equal(t, float32(0), d)
+}
@@ -0,0 +1,111 @@
+package noise
+
+// ---------------------------------- Weighted Choice ----------------------------------
+
+// Weighted deterministically picks an index in [0, len(weights)) with a probability
+// proportional to its weight. Negative weights count as zero and at least one
+// weight must be positive. Complexity: O(n), use an AliasTable for repeated picks.
+//
+// Example:
+//
+//	rarity := Weighted(12345, x, []float32{70, 25, 4, 1}) // common to legendary
+func Weighted(seed uint32, key uint64, weights []float32) int {
+	var total float32
+	for _, w := range weights {
+		total += max(w, 0)
+	}
+
+	if total <= 0 {
+		panic("invalid argument to Weighted")
+	}
+
+	// Walk the cumulative weights, falling back to the last positive weight in
+	// case rounding leaves the target just above the sum
+	target := Float32(seed, key) * total
+	last := 0
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+
+		if target -= w; target < 0 {
+			return i
+		}
+		last = i
+	}
+	return last
+}
+
+// AliasTable samples indices with probabilities proportional to a fixed set of
+// weights in O(1), using Vose's alias method. It is immutable once constructed,
+// so it is safe for concurrent use.
+type AliasTable struct {
+	prob  []float32 // probability of keeping the column rather than its alias
+	alias []int32   // alternative index of each column
+}
+
+// NewAliasTable builds an alias table in O(n) for the weights. Negative weights
+// count as zero and at least one weight must be positive.
+//
+// Example:
+//
+//	tiles := NewAliasTable([]float32{10, 5, 1})
+//	tile := tiles.Sample(12345, uint64(x)<<32|uint64(y))
+func NewAliasTable(weights []float32) *AliasTable {
+	n := len(weights)
+	var total float64
+	for _, w := range weights {
+		total += float64(max(w, 0))
+	}
+
+	if total <= 0 {
+		panic("invalid argument to NewAliasTable")
+	}
+
+	// Scale the weights so that their average is 1, and split them into the
+	// columns that are under-full and over-full
+	t := &AliasTable{prob: make([]float32, n), alias: make([]int32, n)}
+	scaled := make([]float64, n)
+	var small, large []int32
+	for i, w := range weights {
+		scaled[i] = float64(max(w, 0)) * float64(n) / total
+		t.alias[i] = int32(i)
+		if scaled[i] < 1 {
+			small = append(small, int32(i))
+		} else {
+			large = append(large, int32(i))
+		}
+	}
+
+	// Fill each under-full column with the excess of an over-full one
+	for len(small) > 0 && len(large) > 0 {
+		s, l := small[len(small)-1], large[len(large)-1]
+		small = small[:len(small)-1]
+
+		t.prob[s], t.alias[s] = float32(scaled[s]), l
+		if scaled[l] -= 1 - scaled[s]; scaled[l] < 1 {
+			large = large[:len(large)-1]
+			small = append(small, l)
+		}
+	}
+
+	// The remaining columns are full, up to rounding errors
+	for _, i := range append(small, large...) {
+		t.prob[i] = 1
+	}
+	return t
+}
+
+// Len returns the number of weights in the table
+func (t *AliasTable) Len() int {
+	return len(t.prob)
+}
+
+// Sample deterministically picks an index with a probability proportional to its weight
+func (t *AliasTable) Sample(seed uint32, key uint64) int {
+	i := IntN(seed, uint64(len(t.prob)), key)
+	if Float32(seed^1, key) < t.prob[i] {
+		return i
+	}
+	return int(t.alias[i])
+}
@@ -0,0 +1,52 @@
+package noise
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeighted(t *testing.T) {
+	weights := []float32{70, 0, 25, -3, 5}
+	var counts [5]int
+	for key := uint64(0); key < 20000; key++ {
+		counts[Weighted(42, key, weights)]++
+	}
+
+	assert.InDelta(t, 14000, counts[0], 300)
+	assert.Equal(t, 0, counts[1])
+	assert.InDelta(t, 5000, counts[2], 300)
+	assert.Equal(t, 0, counts[3])
+	assert.InDelta(t, 1000, counts[4], 150)
+
+	assert.Equal(t, Weighted(42, 7, weights), Weighted(42, 7, weights))
+	assert.Panics(t, func() { Weighted(42, 1, []float32{0, -1}) })
+	assert.Panics(t, func() { Weighted(42, 1, nil) })
+}
+
+func TestAliasTable(t *testing.T) {
+	table := NewAliasTable([]float32{10, 0, 5, 1, -2})
+	assert.Equal(t, 5, table.Len())
+
+	var counts [5]int
+	for key := uint64(0); key < 32000; key++ {
+		counts[table.Sample(42, key)]++
+	}
+
+	assert.InDelta(t, 20000, counts[0], 400)
+	assert.Equal(t, 0, counts[1])
+	assert.InDelta(t, 10000, counts[2], 400)
+	assert.InDelta(t, 2000, counts[3], 200)
+	assert.Equal(t, 0, counts[4])
+
+	assert.Equal(t, 0, NewAliasTable([]float32{3}).Sample(42, 1))
+	assert.Panics(t, func() { NewAliasTable([]float32{0}) })
+}
+
+func BenchmarkAliasTable(b *testing.B) {
+	table := NewAliasTable([]float32{50, 30, 15, 4, 1})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		table.Sample(42, uint64(i))
+	}
+}
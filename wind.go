@@ -0,0 +1,116 @@
+package noise
+
+import "math"
+
+// ---------------------------------- Wind ----------------------------------
+
+// Wind is a time-varying wind field made of a prevailing wind plus turbulent gusts.
+// The gusts are the curl of fBM potentials, so the field is divergence-free and
+// particles advected by it swirl around instead of piling up or thinning out.
+// The exported fields may be tuned after NewWind.
+type Wind struct {
+	Direction float32 // Direction of the prevailing wind in radians, in the xy plane, default 0
+	Speed     float32 // Speed of the prevailing wind, default 1
+	Gust      float32 // Strength of the turbulent gusts, default 0.5
+	Scale     float32 // Spatial frequency of the gusts, default 0.05
+	Rate      float32 // Rate at which the gusts evolve over time, default 0.2
+	potential [3]*Simplex
+}
+
+// NewWind creates a wind field with the given seed
+func NewWind(seed uint32) *Wind {
+	return &Wind{
+		Speed: 1,
+		Gust:  0.5,
+		Scale: 0.05,
+		Rate:  0.2,
+		potential: [3]*Simplex{
+			NewSimplex(component(seed, 0)),
+			NewSimplex(component(seed, 1)),
+			NewSimplex(component(seed, 2)),
+		},
+	}
+}
+
+// At2 returns the 2D wind velocity at (x, y) and time t
+//
+// Example:
+//
+//	wind := NewWind(12345)
+//	v := wind.At2(p.X, p.Y, t)
+//	p.X += v[0] * dt
+//	p.Y += v[1] * dt
+func (w *Wind) At2(x, y, t float32) [2]float32 {
+	const e = 0.01
+	px, py, pt := x*w.Scale, y*w.Scale, t*w.Rate
+	s := w.potential[0]
+
+	// The curl of a scalar potential ψ is (∂ψ/∂y, -∂ψ/∂x)
+	dx := (fbm3(s, px+e, py, pt) - fbm3(s, px-e, py, pt)) / (2 * e)
+	dy := (fbm3(s, px, py+e, pt) - fbm3(s, px, py-e, pt)) / (2 * e)
+	wx, wy := w.prevailing()
+	return [2]float32{
+		wx + float32(dy*w.Gust),
+		wy - float32(dx*w.Gust),
+	}
+}
+
+// At3 returns the 3D wind velocity at (x, y, z) and time t
+func (w *Wind) At3(x, y, z, t float32) [3]float32 {
+	const e = 0.01
+	px, py, pz, pt := x*w.Scale, y*w.Scale, z*w.Scale, t*w.Rate
+
+	// Partial derivatives of the vector potential (ψ0, ψ1, ψ2)
+	var d [3][3]float32 // d[i][j] = ∂ψi/∂j
+	for i, s := range w.potential {
+		d[i][0] = (fbm4(s, px+e, py, pz, pt) - fbm4(s, px-e, py, pz, pt)) / (2 * e)
+		d[i][1] = (fbm4(s, px, py+e, pz, pt) - fbm4(s, px, py-e, pz, pt)) / (2 * e)
+		d[i][2] = (fbm4(s, px, py, pz+e, pt) - fbm4(s, px, py, pz-e, pt)) / (2 * e)
+	}
+
+	wx, wy := w.prevailing()
+	return [3]float32{
+		wx + float32((d[2][1]-d[1][2])*w.Gust),
+		wy + float32((d[0][2]-d[2][0])*w.Gust),
+		float32((d[1][0] - d[0][1]) * w.Gust),
+	}
+}
+
+// Fill2 fills dst with a w×h grid of 2D wind velocities at time t in row-major
+// order, where the cell (ix, iy) is sampled at (x + ix*step, y + iy*step).
+//
+// Example:
+//
+//	field := make([][2]float32, 64*64)
+//	wind.Fill2(field, 64, 64, 0, 0, 1, t)
+func (w *Wind) Fill2(dst [][2]float32, width, height int, x, y, step, t float32) {
+	switch {
+	case width <= 0 || height <= 0:
+		return
+	case len(dst) < width*height:
+		panic("noise: destination is too small for the grid")
+	}
+
+	for iy := 0; iy < height; iy++ {
+		fy := y + float32(float32(iy)*step)
+		for ix := 0; ix < width; ix++ {
+			dst[iy*width+ix] = w.At2(x+float32(float32(ix)*step), fy, t)
+		}
+	}
+}
+
+// prevailing returns the velocity of the prevailing wind
+func (w *Wind) prevailing() (float32, float32) {
+	sin, cos := math.Sincos(float64(w.Direction))
+	return float32(cos * float64(w.Speed)), float32(sin * float64(w.Speed))
+}
+
+// fbm3 sums 3 octaves of 3D simplex noise
+func fbm3(s *Simplex, x, y, z float32) float32 {
+	return (s.noise3D(x, y, z) + 0.5*s.noise3D(x*2, y*2, z*2) + 0.25*s.noise3D(x*4, y*4, z*4)) / 1.75
+}
+
+// fbm4 sums 3 octaves of 4D simplex noise
+func fbm4(s *Simplex, x, y, z, w float32) float32 {
+	return (s.noise4D(x, y, z, w) + 0.5*s.noise4D(x*2, y*2, z*2, w*2) + 0.25*s.noise4D(x*4, y*4, z*4, w*4)) / 1.75
+}
@@ -0,0 +1,53 @@
+package noise
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWind(t *testing.T) {
+	w := NewWind(42)
+	assert.Equal(t, w.At2(10, 20, 1), NewWind(42).At2(10, 20, 1))
+	assert.NotEqual(t, w.At2(10, 20, 1), w.At2(10, 20, 5))
+
+	// Without gusts, only the prevailing wind is left
+	w.Gust = 0
+	w.Direction = math.Pi / 2
+	v := w.At2(10, 20, 1)
+	assert.InDelta(t, 0, v[0], 1e-6)
+	assert.InDelta(t, 1, v[1], 1e-6)
+	assert.InDelta(t, 1, w.At3(1, 2, 3, 4)[1], 1e-6)
+}
+
+func TestWindDivergence(t *testing.T) {
+	w := NewWind(42)
+	w.Speed = 0
+
+	// The divergence is small relative to the magnitude of the derivatives
+	const h = 0.5
+	var div, mag float64
+	for i := 0; i < 200; i++ {
+		x, y, z := float32(i%20)*3, float32(i/20)*3, float32(i%7)
+		dx := w.At2(x+h, y, 1)[0] - w.At2(x-h, y, 1)[0]
+		dy := w.At2(x, y+h, 1)[1] - w.At2(x, y-h, 1)[1]
+		div += math.Abs(float64(dx + dy))
+		mag += math.Abs(float64(dx)) + math.Abs(float64(dy))
+
+		d3 := w.At3(x+h, y, z, 1)[0] - w.At3(x-h, y, z, 1)[0] +
+			w.At3(x, y+h, z, 1)[1] - w.At3(x, y-h, z, 1)[1] +
+			w.At3(x, y, z+h, 1)[2] - w.At3(x, y, z-h, 1)[2]
+		assert.Less(t, math.Abs(float64(d3)), 0.1)
+	}
+
+	assert.Less(t, div, mag*0.1)
+}
+
+func TestWindFill(t *testing.T) {
+	w := NewWind(7)
+	grid := make([][2]float32, 8*4)
+	w.Fill2(grid, 8, 4, 1, 2, 0.5, 3)
+	assert.Equal(t, w.At2(1+5*0.5, 2+3*0.5, 3), grid[3*8+5])
+	assert.Panics(t, func() { w.Fill2(grid, 8, 8, 0, 0, 1, 0) })
+}
@@ -0,0 +1,159 @@
+package noise
+
+import (
+	"container/list"
+	"sync"
+)
+
+// ---------------------------------- World ----------------------------------
+
+// Chunking configures an infinite chunked world. Zero fields take the default values
+// listed below, so Chunking{} is a sensible starting point.
+type Chunking struct {
+	Size    int       // Cells per side of a chunk, default 64
+	Step    float32   // Distance in noise units between two cells, default 0.005
+	Cache   int       // Number of chunks kept in memory, default 64
+	Terrain Source2   // Source of the heights in noise units, default the biome elevation
+	Biomes  Whittaker // Biome classification, default DefaultWhittaker
+}
+
+// withDefaults returns the options with the zero fields replaced by defaults
+func (o Chunking) withDefaults() Chunking {
+	if o.Size <= 0 {
+		o.Size = 64
+	}
+	if o.Step == 0 {
+		o.Step = 0.005
+	}
+	if o.Cache <= 0 {
+		o.Cache = 64
+	}
+	if o.Biomes.Table == nil {
+		o.Biomes = DefaultWhittaker()
+	}
+	return o
+}
+
+// Chunk is a square tile of a world, holding Size+1 samples per side so that the
+// last row and column of a chunk are the first ones of its neighbours, and meshes
+// built from adjacent chunks stitch without seams. Chunks are shared by the cache,
+// so they must be treated as read-only.
+type Chunk struct {
+	X, Y    int       // Coordinates of the chunk, in chunks
+	Size    int       // Cells per side, the grids have a stride of Size+1
+	Heights []float32 // Heights of the samples, in row-major order
+	Biomes  []Biome   // Dominant biome of the samples, in row-major order
+}
+
+// At returns the height and biome of a sample of the chunk, x and y in [0, Size]
+func (c *Chunk) At(x, y int) (float32, Biome) {
+	i := y*(c.Size+1) + x
+	return c.Heights[i], c.Biomes[i]
+}
+
+// World is an infinite heightmap split into chunks which are generated on demand,
+// deterministically from the seed, and kept in a least-recently-used cache. It is
+// safe for concurrent use.
+type World struct {
+	seed   uint32
+	opts   Chunking
+	fields biomeFields
+	mu     sync.Mutex
+	lru    *list.List // chunks, the most recently used first
+	chunks map[[2]int]*list.Element
+}
+
+// NewWorld creates a world for the seed
+//
+// Example:
+//
+//	world := NewWorld(12345, Chunking{Size: 32})
+//	chunk := world.Chunk(-3, 7)
+//	height, biome := chunk.At(0, 0)
+func NewWorld(seed uint32, opts Chunking) *World {
+	return &World{
+		seed:   seed,
+		opts:   opts.withDefaults(),
+		fields: newBiomeFields(seed),
+		lru:    list.New(),
+		chunks: make(map[[2]int]*list.Element),
+	}
+}
+
+// Chunk returns the chunk at (cx, cy), generating it if it is not cached
+func (w *World) Chunk(cx, cy int) *Chunk {
+	key := [2]int{cx, cy}
+	w.mu.Lock()
+	if e, ok := w.chunks[key]; ok {
+		w.lru.MoveToFront(e)
+		w.mu.Unlock()
+		return e.Value.(*Chunk)
+	}
+	w.mu.Unlock()
+
+	// Generate outside of the lock, another goroutine may have won the race
+	c := w.generate(cx, cy)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if e, ok := w.chunks[key]; ok {
+		w.lru.MoveToFront(e)
+		return e.Value.(*Chunk)
+	}
+
+	w.chunks[key] = w.lru.PushFront(c)
+	if w.lru.Len() > w.opts.Cache {
+		last := w.lru.Back()
+		old := w.lru.Remove(last).(*Chunk)
+		delete(w.chunks, [2]int{old.X, old.Y})
+	}
+	return c
+}
+
+// Height returns the height at the world cell (x, y), through its chunk
+func (w *World) Height(x, y int) float32 {
+	size := w.opts.Size
+	cx, cy := floorDiv(x, size), floorDiv(y, size)
+	h, _ := w.Chunk(cx, cy).At(x-cx*size, y-cy*size)
+	return h
+}
+
+// generate computes a chunk from the absolute coordinates of its samples
+func (w *World) generate(cx, cy int) *Chunk {
+	size := w.opts.Size
+	stride := size + 1
+	c := &Chunk{
+		X:       cx,
+		Y:       cy,
+		Size:    size,
+		Heights: make([]float32, stride*stride),
+		Biomes:  make([]Biome, stride*stride),
+	}
+
+	for y := 0; y < stride; y++ {
+		for x := 0; x < stride; x++ {
+			fx := float32(cx*size+x) * w.opts.Step
+			fy := float32(cy*size+y) * w.opts.Step
+
+			var height float32
+			if w.opts.Terrain != nil {
+				height = w.opts.Terrain.Eval2(fx, fy)
+			} else {
+				height = w.fields.elevation.Eval2(fx, fy)
+			}
+
+			i := y*stride + x
+			c.Heights[i] = height
+			c.Biomes[i], _ = w.fields.classify(&w.opts.Biomes, fx, fy, height)
+		}
+	}
+	return c
+}
+
+// floorDiv divides rounding towards negative infinity
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,81 @@
+package noise
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorldChunk(t *testing.T) {
+	w := NewWorld(1, Chunking{Size: 16})
+	c := w.Chunk(-2, 3)
+	assert.Equal(t, -2, c.X)
+	assert.Equal(t, 3, c.Y)
+	assert.Len(t, c.Heights, 17*17)
+	assert.Same(t, c, w.Chunk(-2, 3))
+
+	// Another world with the same seed generates the same chunk
+	assert.Equal(t, c, NewWorld(1, Chunking{Size: 16}).Chunk(-2, 3))
+	assert.NotEqual(t, c.Heights, NewWorld(2, Chunking{Size: 16}).Chunk(-2, 3).Heights)
+}
+
+func TestWorldBorders(t *testing.T) {
+	w := NewWorld(1, Chunking{Size: 16})
+	c, right, below := w.Chunk(0, 0), w.Chunk(1, 0), w.Chunk(0, 1)
+	for i := 0; i <= 16; i++ {
+		h1, b1 := c.At(16, i)
+		h2, b2 := right.At(0, i)
+		assert.Equal(t, h1, h2)
+		assert.Equal(t, b1, b2)
+
+		h1, _ = c.At(i, 16)
+		h2, _ = below.At(i, 0)
+		assert.Equal(t, h1, h2)
+	}
+
+	// World cells map to their chunks, including negative coordinates
+	h, _ := w.Chunk(-1, -1).At(15, 15)
+	assert.Equal(t, h, w.Height(-1, -1))
+	h, _ = w.Chunk(1, 0).At(0, 5)
+	assert.Equal(t, h, w.Height(16, 5))
+}
+
+func TestWorldCache(t *testing.T) {
+	w := NewWorld(1, Chunking{Size: 4, Cache: 2})
+	a := w.Chunk(0, 0)
+	w.Chunk(1, 0)
+	assert.Same(t, a, w.Chunk(0, 0))
+
+	// The least recently used chunk is evicted and generated again
+	w.Chunk(2, 0)
+	assert.Equal(t, 2, w.lru.Len())
+	b := w.Chunk(1, 0)
+	assert.Equal(t, a.Size, b.Size)
+	assert.NotSame(t, a, w.Chunk(0, 0))
+}
+
+func TestWorldTerrain(t *testing.T) {
+	flat := SourceFunc(func(x, y, z float32) float32 { return -0.5 })
+	c := NewWorld(1, Chunking{Size: 8, Terrain: flat}).Chunk(0, 0)
+	for i := range c.Heights {
+		assert.Equal(t, float32(-0.5), c.Heights[i])
+		assert.Equal(t, BiomeOcean, c.Biomes[i])
+	}
+}
+
+func TestWorldConcurrent(t *testing.T) {
+	w := NewWorld(1, Chunking{Size: 8, Cache: 4})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for k := 0; k < 50; k++ {
+				assert.Equal(t, k%6, w.Chunk(k%6, i%2).X)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, w.lru.Len(), 4)
+}
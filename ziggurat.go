@@ -0,0 +1,75 @@
+package noise
+
+import "math"
+
+// ---------------------------------- Ziggurat ----------------------------------
+
+// zigR is the start of the tail of the 128-layer ziggurat of the normal density
+const zigR = 3.442619855899
+
+// zigK, zigW and zigF are the ziggurat tables of Marsaglia and Tsang: the
+// acceptance thresholds, the widths and the heights of each layer.
+var zigK, zigW, zigF = zigTables()
+
+// zigTables computes the layers of the ziggurat, which all have the same area
+func zigTables() (k [128]uint32, w, f [128]float64) {
+	const m = 1 << 31
+	const v = 9.91256303526217e-3 // area of each layer
+
+	d, t := zigR, zigR
+	q := v / math.Exp(-0.5*d*d)
+	k[0], k[1] = uint32(d/q*m), 0
+	w[0], w[127] = q/m, d/m
+	f[0], f[127] = 1, math.Exp(-0.5*d*d)
+	for i := 126; i >= 1; i-- {
+		d = math.Sqrt(-2 * math.Log(v/d+math.Exp(-0.5*d*d)))
+		k[i+1] = uint32(d / t * m)
+		t = d
+		f[i] = math.Exp(-0.5 * d * d)
+		w[i] = d / m
+	}
+	return
+}
+
+// ziggurat returns a normally distributed float64 for the key using the ziggurat
+// method. About 99% of the samples only need a single hash and a multiplication,
+// the rest are resolved by rejection with further hashes derived from the key.
+func ziggurat(seed uint32, x uint64) float64 {
+	for attempt := uint64(0); ; attempt++ {
+		h := xxhash64(x, uint64(seed)+attempt*0x9e3779b97f4a7c15)
+		j := int32(h)
+		i := (h >> 32) & 127
+		if abs32(j) < zigK[i] {
+			return float64(j) * zigW[i]
+		}
+
+		// Sample from the tail beyond zigR
+		if i == 0 {
+			for n := uint64(1); ; n++ {
+				u := xxhash64(h, n)
+				a := -math.Log(1-float64(u>>11)/(1<<53)) / zigR
+				b := -math.Log(1 - Float64(seed, u))
+				if b+b >= float64(a*a) {
+					if j < 0 {
+						return -(zigR + a)
+					}
+					return zigR + a
+				}
+			}
+		}
+
+		// Accept a wedge sample if it is under the density
+		v := float64(j) * zigW[i]
+		if zigF[i]+float64(Float64(seed, h)*(zigF[i-1]-zigF[i])) < math.Exp(-0.5*v*v) {
+			return v
+		}
+	}
+}
+
+// abs32 returns the absolute value of v, as an unsigned value for math.MinInt32
+func abs32(v int32) uint32 {
+	if v < 0 {
+		return uint32(-int64(v))
+	}
+	return uint32(v)
+}
@@ -0,0 +1,58 @@
+package noise
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZiggurat(t *testing.T) {
+	mean, variance := moments(100000, func(x uint64) float64 {
+		return NormFast64(42, x)
+	})
+	assert.InDelta(t, 0, mean, 0.01)
+	assert.InDelta(t, 1, variance, 0.02)
+
+	// The proportions within 1, 2 and 3 standard deviations match the normal
+	// distribution, including the tail beyond the last layer
+	var within [3]int
+	var tail int
+	for x := uint64(0); x < 200000; x++ {
+		v := math.Abs(NormFast64(7, x))
+		for k := range within {
+			if v < float64(k+1) {
+				within[k]++
+			}
+		}
+		if v > zigR {
+			tail++
+		}
+	}
+
+	assert.InDelta(t, 0.6827, float64(within[0])/200000, 0.005)
+	assert.InDelta(t, 0.9545, float64(within[1])/200000, 0.003)
+	assert.InDelta(t, 0.9973, float64(within[2])/200000, 0.001)
+	assert.InDelta(t, 0.000576*200000, tail, 40)
+}
+
+func TestNormPinned(t *testing.T) {
+	// Norm64 keeps the Box-Muller values it always had, the ziggurat is opt-in
+	assert.InDelta(t, -0.2546538797721675, Norm64(42, 5), 1e-15)
+	assert.Equal(t, uint64(0xbff398d498ef1455), math.Float64bits(NormFast64(42, 5)))
+	assert.Equal(t, float32(NormFast64(42, 5)), NormFast32(42, 5))
+}
+
+func BenchmarkNorm64(b *testing.B) {
+	b.Run("ziggurat", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			NormFast64(42, uint64(i))
+		}
+	})
+
+	b.Run("box-muller", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Norm64(42, uint64(i))
+		}
+	})
+}